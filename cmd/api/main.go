@@ -27,7 +27,11 @@ func main() {
 	}
 
 	// Initialize dependency container
-	container := dependency.NewContainer()
+	container, err := dependency.NewContainer(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize application: %v", err)
+	}
+	defer container.Close()
 
 	// Create Gin router
 	router := gin.New()
@@ -57,11 +61,16 @@ func main() {
 		}
 	}()
 
+	// Keep appointment series booked ahead as time passes
+	stopExtending := make(chan struct{})
+	go extendAppointmentSeries(container, cfg.Recurrence.ExtendInterval, stopExtending)
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	close(stopExtending)
 
 	// Give outstanding requests 30 seconds to complete
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -74,6 +83,29 @@ func main() {
 	log.Println("Server exited")
 }
 
+// extendAppointmentSeries moves the horizon of appointment series forward
+// on start and then every interval, until stop is closed.
+func extendAppointmentSeries(container *dependency.Container, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		booked, err := container.ExtendAppointmentSeriesUseCase.Execute()
+		if err != nil {
+			log.Printf("Failed to extend appointment series: %v", err)
+		}
+		if booked > 0 {
+			log.Printf("Booked %d appointment series occurrences", booked)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
 func setupRoutes(router *gin.Engine, container *dependency.Container) {
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -95,15 +127,39 @@ func setupRoutes(router *gin.Engine, container *dependency.Container) {
 			appointments.GET("/:id", container.AppointmentController.GetAppointment)
 			appointments.PUT("/:id", container.AppointmentController.UpdateAppointment)
 			appointments.DELETE("/:id", container.AppointmentController.CancelAppointment)
+			appointments.PUT("/:id/attendees/:participant_id/response", container.AppointmentController.RespondToAppointment)
+			appointments.POST("/:id/confirm", container.AppointmentController.ConfirmAppointment)
+			appointments.POST("/:id/start", container.AppointmentController.StartAppointment)
+			appointments.POST("/:id/complete", container.AppointmentController.CompleteAppointment)
+			appointments.POST("/:id/no-show", container.AppointmentController.MarkAppointmentNoShow)
+			appointments.POST("/:id/reopen", container.AppointmentController.ReopenAppointment)
+		}
+
+		// Appointment series routes
+		appointmentSeries := v1.Group("/appointment-series")
+		{
+			appointmentSeries.POST("", container.AppointmentSeriesController.CreateSeries)
+			appointmentSeries.GET("/:id", container.AppointmentSeriesController.GetSeries)
+			appointmentSeries.DELETE("/:id", container.AppointmentSeriesController.CancelSeries)
+			appointmentSeries.PUT("/:id/occurrences/:recurrence_id", container.AppointmentSeriesController.UpdateOccurrence)
+			appointmentSeries.DELETE("/:id/occurrences/:recurrence_id", container.AppointmentSeriesController.CancelOccurrence)
 		}
 
 		// Schedule routes
 		schedules := v1.Group("/schedules")
 		{
+			schedules.POST("", container.ScheduleController.CreateSchedule)
 			schedules.POST("/availability", container.ScheduleController.FindAvailableTimeSlots)
 			schedules.GET("/:owner_id/overview", container.ScheduleController.GetScheduleOverview)
 			schedules.GET("/:owner_id/detail", container.ScheduleController.GetScheduleDetail)
+			schedules.PUT("/:owner_id/working-hours", container.ScheduleController.UpdateWorkingHours)
+			schedules.PUT("/:owner_id/working-hours/overrides/:date", container.ScheduleController.SetWorkingHoursOverride)
+			schedules.DELETE("/:owner_id/working-hours/overrides/:date", container.ScheduleController.RemoveWorkingHoursOverride)
+			schedules.PUT("/:owner_id/holiday-calendars", container.ScheduleController.UpdateHolidayCalendars)
+			schedules.PUT("/:owner_id/conflict-policy", container.ScheduleController.UpdateConflictPolicy)
 			schedules.POST("/:owner_id/blocked-times", container.ScheduleController.AddBlockedTime)
+			schedules.GET("/:owner_id/blocked-times", container.ScheduleController.ListBlockedTimes)
+			schedules.DELETE("/:owner_id/blocked-times/:blocked_time_id", container.ScheduleController.RemoveBlockedTime)
 		}
 
 		// Participant routes
@@ -112,8 +168,23 @@ func setupRoutes(router *gin.Engine, container *dependency.Container) {
 			participants.POST("", container.ParticipantController.CreateParticipant)
 			participants.GET("/:id", container.ParticipantController.GetParticipant)
 			participants.PUT("/:id", container.ParticipantController.UpdateParticipant)
+			participants.DELETE("/:id", container.ParticipantController.DeleteParticipant)
 			participants.POST("/:id/availability", container.ParticipantController.AddAvailability)
 			participants.GET("/:id/availability", container.ParticipantController.GetAvailability)
+			participants.DELETE("/:id/availability/:availability_id", container.ParticipantController.RemoveAvailability)
+		}
+
+		// Recurrence routes
+		recurrences := v1.Group("/recurrences")
+		{
+			recurrences.POST("/preview", container.RecurrenceController.PreviewRecurrence)
+		}
+
+		// Holiday calendar routes
+		holidayCalendars := v1.Group("/holiday-calendars")
+		{
+			holidayCalendars.GET("", container.HolidayController.ListCalendars)
+			holidayCalendars.GET("/:region/holidays", container.HolidayController.ListHolidays)
 		}
 	}
 }
@@ -122,7 +193,8 @@ func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, If-Match")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
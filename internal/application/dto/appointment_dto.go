@@ -7,43 +7,96 @@ import (
 )
 
 type CreateAppointmentRequest struct {
-	Title     string    `json:"title" binding:"required"`
-	StartTime time.Time `json:"start_time" binding:"required"`
-	EndTime   time.Time `json:"end_time" binding:"required"`
-	Attendees []string  `json:"attendees" binding:"required,min=1"`
-	Location  string    `json:"location"`
+	Title       string                 `json:"title" binding:"required"`
+	Description string                 `json:"description"`
+	StartTime   time.Time              `json:"start_time" binding:"required"`
+	EndTime     time.Time              `json:"end_time" binding:"required"`
+	Attendees   []AttendeeRequest      `json:"attendees" binding:"required,min=1"`
+	OrganizerID string                 `json:"organizer_id"` // Makes this participant the organizer, inviting them if needed
+	Location    string                 `json:"location"`
+	Tags        []string               `json:"tags"`
+	Metadata    map[string]interface{} `json:"metadata"`  // String, number or boolean values
+	Tentative   bool                   `json:"tentative"` // Hold the time pending approval instead of confirming
 }
 
 type CreateAppointmentResponse struct {
-	ID        string                 `json:"id"`
-	Title     string                 `json:"title"`
-	StartTime time.Time              `json:"start_time"`
-	EndTime   time.Time              `json:"end_time"`
-	Attendees []string               `json:"attendees"`
-	Location  string                 `json:"location"`
-	Status    entities.AppointmentStatus `json:"status"`
-	CreatedAt time.Time              `json:"created_at"`
+	ID          string                     `json:"id"`
+	Title       string                     `json:"title"`
+	Description string                     `json:"description"`
+	StartTime   time.Time                  `json:"start_time"`
+	EndTime     time.Time                  `json:"end_time"`
+	Attendees   []AttendeeResponse         `json:"attendees"`
+	OrganizerID string                     `json:"organizer_id,omitempty"`
+	Location    string                     `json:"location"`
+	Tags        []string                   `json:"tags"`
+	Metadata    map[string]interface{}     `json:"metadata"`
+	Status      entities.AppointmentStatus `json:"status"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	Version     int                        `json:"version"`
 }
 
 type UpdateAppointmentRequest struct {
-	Title     *string    `json:"title,omitempty"`
-	StartTime *time.Time `json:"start_time,omitempty"`
-	EndTime   *time.Time `json:"end_time,omitempty"`
-	Attendees []string   `json:"attendees,omitempty"`
-	Location  *string    `json:"location,omitempty"`
+	Title       *string                `json:"title,omitempty"`
+	Description *string                `json:"description,omitempty"`
+	StartTime   *time.Time             `json:"start_time,omitempty"`
+	EndTime     *time.Time             `json:"end_time,omitempty"`
+	Attendees   []AttendeeRequest      `json:"attendees,omitempty"` // Replaces the attendees; those who stay keep their response
+	OrganizerID *string                `json:"organizer_id,omitempty"`
+	Location    *string                `json:"location,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`     // Replaces the tags; [] removes them all
+	Metadata    map[string]interface{} `json:"metadata,omitempty"` // Replaces the metadata; {} removes it all
 }
 
 type AppointmentResponse struct {
-	ID        string                 `json:"id"`
-	Title     string                 `json:"title"`
-	StartTime time.Time              `json:"start_time"`
-	EndTime   time.Time              `json:"end_time"`
-	Duration  string                 `json:"duration"`
-	Attendees []string               `json:"attendees"`
-	Location  string                 `json:"location"`
-	Status    entities.AppointmentStatus `json:"status"`
-	CreatedAt time.Time              `json:"created_at"`
-	UpdatedAt time.Time              `json:"updated_at"`
+	ID           string                     `json:"id"`
+	Title        string                     `json:"title"`
+	Description  string                     `json:"description"`
+	StartTime    time.Time                  `json:"start_time"`
+	EndTime      time.Time                  `json:"end_time"`
+	Duration     string                     `json:"duration"`
+	Attendees    []AttendeeResponse         `json:"attendees"`
+	OrganizerID  string                     `json:"organizer_id,omitempty"`
+	Location     string                     `json:"location"`
+	Tags         []string                   `json:"tags"`
+	Metadata     map[string]interface{}     `json:"metadata"`
+	Status       entities.AppointmentStatus `json:"status"`
+	CreatedAt    time.Time                  `json:"created_at"`
+	UpdatedAt    time.Time                  `json:"updated_at"`
+	Version      int                        `json:"version"`
+	SeriesID     string                     `json:"series_id,omitempty"`
+	RecurrenceID string                     `json:"recurrence_id,omitempty"`
+
+	StatusHistory []StatusTransitionResponse `json:"status_history"`
+}
+
+// ChangeAppointmentStatusRequest records who moved an appointment through its
+// lifecycle and why.
+type ChangeAppointmentStatusRequest struct {
+	ChangedBy string `json:"changed_by" form:"changed_by"`
+	Reason    string `json:"reason" form:"reason"`
+}
+
+type StatusTransitionResponse struct {
+	From      entities.AppointmentStatus `json:"from"`
+	To        entities.AppointmentStatus `json:"to"`
+	ChangedBy string                     `json:"changed_by,omitempty"`
+	Reason    string                     `json:"reason,omitempty"`
+	ChangedAt time.Time                  `json:"changed_at"`
+}
+
+type ListAppointmentsRequest struct {
+	Page          int       `form:"page"`
+	Limit         int       `form:"limit"`
+	Cursor        string    `form:"cursor"`
+	ParticipantID string    `form:"participant_id"`
+	Status        []string  `form:"status"`
+	From          time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To            time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Location      string    `form:"location"`
+	Search        string    `form:"q"`
+	Tags          []string  `form:"tag"`          // Only appointments with all of these tags
+	MetadataKeys  []string  `form:"metadata_key"` // Only appointments with all of these metadata keys
+	Sort          string    `form:"sort"`         // Field name, prefixed with "-" for descending
 }
 
 type AppointmentListResponse struct {
@@ -51,4 +104,5 @@ type AppointmentListResponse struct {
 	Total        int                   `json:"total"`
 	Page         int                   `json:"page"`
 	Limit        int                   `json:"limit"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
 }
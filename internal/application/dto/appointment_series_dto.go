@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+type CreateAppointmentSeriesRequest struct {
+	Title     string            `json:"title" binding:"required"`
+	StartTime time.Time         `json:"start_time" binding:"required"` // First occurrence
+	EndTime   time.Time         `json:"end_time" binding:"required"`
+	Attendees []AttendeeRequest `json:"attendees" binding:"required,min=1"`
+	Location  string            `json:"location"`
+	RRule     string            `json:"rrule" binding:"required"` // RFC 5545 RRULE, e.g. "FREQ=WEEKLY;BYDAY=MO,WE"
+	Timezone  string            `json:"timezone"`                 // IANA zone the rule is expanded in, UTC by default
+
+	// How occurrences falling in a DST gap ("shift_forward", "next_valid" or
+	// "skip") or overlap ("earlier" or "later") are resolved; the server's
+	// defaults when empty
+	DSTGapPolicy       string `json:"dst_gap_policy"`
+	DSTAmbiguityPolicy string `json:"dst_ambiguity_policy"`
+}
+
+// UpdateAppointmentSeriesRequest changes one occurrence ("this"), that
+// occurrence and the ones after it ("following"), or every occurrence
+// ("all"). Times are for the occurrence being edited; for "following" and
+// "all" the same shift is applied to the rest of the series.
+type UpdateAppointmentSeriesRequest struct {
+	Scope     string     `json:"scope" binding:"required"`
+	Title     *string    `json:"title,omitempty"`
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	Location  *string    `json:"location,omitempty"`
+	RRule     *string    `json:"rrule,omitempty"` // Not allowed for "this"
+}
+
+type OccurrenceOverrideResponse struct {
+	RecurrenceID string     `json:"recurrence_id"`
+	StartTime    *time.Time `json:"start_time,omitempty"`
+	EndTime      *time.Time `json:"end_time,omitempty"`
+	Title        *string    `json:"title,omitempty"`
+	Location     *string    `json:"location,omitempty"`
+}
+
+type AppointmentSeriesResponse struct {
+	ID                 string                       `json:"id"`
+	Title              string                       `json:"title"`
+	StartTime          time.Time                    `json:"start_time"`
+	EndTime            time.Time                    `json:"end_time"`
+	Attendees          []AttendeeResponse           `json:"attendees"`
+	Location           string                       `json:"location"`
+	RRule              string                       `json:"rrule"`
+	Timezone           string                       `json:"timezone"`
+	DSTGapPolicy       string                       `json:"dst_gap_policy"`
+	DSTAmbiguityPolicy string                       `json:"dst_ambiguity_policy"`
+	ExDates            []time.Time                  `json:"exdates"`
+	Overrides          []OccurrenceOverrideResponse `json:"overrides"`
+	MaterializedUntil  time.Time                    `json:"materialized_until"`
+	Status             entities.AppointmentStatus   `json:"status"`
+	CreatedAt          time.Time                    `json:"created_at"`
+	UpdatedAt          time.Time                    `json:"updated_at"`
+	Version            int                          `json:"version"`
+	Occurrences        []AppointmentResponse        `json:"occurrences"`
+}
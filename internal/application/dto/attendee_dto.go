@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+// AttendeeRequest invites a participant. It may also be given as a bare
+// participant ID, which invites them as a required attendee.
+type AttendeeRequest struct {
+	ParticipantID string `json:"participant_id"`
+	Role          string `json:"role"` // organizer, required (the default), optional or resource
+}
+
+func (r *AttendeeRequest) UnmarshalJSON(data []byte) error {
+	var participantID string
+	if err := json.Unmarshal(data, &participantID); err == nil {
+		*r = AttendeeRequest{ParticipantID: participantID}
+		return nil
+	}
+
+	type attendeeObject AttendeeRequest
+	return json.Unmarshal(data, (*attendeeObject)(r))
+}
+
+type AttendeeResponse struct {
+	ParticipantID string                  `json:"participant_id"`
+	Role          entities.AttendeeRole   `json:"role"`
+	Response      entities.ResponseStatus `json:"response"`
+	RespondedAt   *time.Time              `json:"responded_at,omitempty"`
+}
+
+// RespondToAppointmentRequest records an attendee's answer to an invitation.
+type RespondToAppointmentRequest struct {
+	Response string `json:"response" binding:"required"` // needs-action, accepted, tentative or declined
+}
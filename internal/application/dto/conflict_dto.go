@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// ParticipantConflictResponse lists everything that keeps a participant from
+// being booked between StartTime and EndTime.
+type ParticipantConflictResponse struct {
+	ParticipantID string             `json:"participant_id"`
+	StartTime     time.Time          `json:"start_time"`
+	EndTime       time.Time          `json:"end_time"`
+	Severity      string             `json:"severity"` // The most severe of the conflicts
+	Conflicts     []ConflictResponse `json:"conflicts"`
+}
+
+// AlternativeSlotResponse is a time at which an appointment that failed on a
+// conflict would fit every attendee's schedule.
+type AlternativeSlotResponse struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+type ConflictResponse struct {
+	Type         string    `json:"type"`                // appointment, blocked_time, working_hours or holiday
+	SourceID     string    `json:"source_id,omitempty"` // Appointment, blocked time or holiday ID
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	OverlapStart time.Time `json:"overlap_start"`
+	OverlapEnd   time.Time `json:"overlap_end"`
+	Severity     string    `json:"severity"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+type HolidayCalendarResponse struct {
+	Region string `json:"region"`
+	Name   string `json:"name"`
+}
+
+type HolidayCalendarListResponse struct {
+	Calendars []HolidayCalendarResponse `json:"calendars"`
+}
+
+type ListHolidaysRequest struct {
+	Year int `form:"year" binding:"omitempty,min=1,max=9999"`
+}
+
+type HolidayResponse struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+type HolidayListResponse struct {
+	Region   string            `json:"region"`
+	Name     string            `json:"name"`
+	Year     int               `json:"year"`
+	Holidays []HolidayResponse `json:"holidays"`
+}
+
+// UpdateHolidayCalendarsRequest replaces the region codes of the holiday
+// calendars a schedule observes.
+type UpdateHolidayCalendarsRequest struct {
+	Regions []string `json:"regions"`
+}
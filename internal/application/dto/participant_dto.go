@@ -3,30 +3,33 @@ package dto
 import "time"
 
 type CreateParticipantRequest struct {
-	Name     string `json:"name" binding:"required"`
-	Email    string `json:"email" binding:"required,email"`
-	Timezone string `json:"timezone"`
+	Name             string   `json:"name" binding:"required"`
+	Email            string   `json:"email" binding:"required,email"`
+	Timezone         string   `json:"timezone"`
+	HolidayCalendars []string `json:"holiday_calendars"`
 }
 
 type ParticipantResponse struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Timezone string `json:"timezone"`
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	Email            string   `json:"email"`
+	Timezone         string   `json:"timezone"`
+	HolidayCalendars []string `json:"holiday_calendars"`
 }
 
 type UpdateParticipantRequest struct {
-	Name     *string `json:"name,omitempty"`
-	Email    *string `json:"email,omitempty"`
-	Timezone *string `json:"timezone,omitempty"`
+	Name             *string   `json:"name,omitempty"`
+	Email            *string   `json:"email,omitempty"`
+	Timezone         *string   `json:"timezone,omitempty"`
+	HolidayCalendars *[]string `json:"holiday_calendars,omitempty"`
 }
 
 type AddAvailabilityRequest struct {
-	StartTime time.Time `json:"start_time" binding:"required"`
-	EndTime   time.Time `json:"end_time" binding:"required"`
-	Recurring bool      `json:"recurring"`
-	Pattern   string    `json:"pattern,omitempty"`
-	Interval  int       `json:"interval,omitempty"`
+	StartTime time.Time  `json:"start_time" binding:"required"`
+	EndTime   time.Time  `json:"end_time" binding:"required"`
+	Recurring bool       `json:"recurring"`
+	Pattern   string     `json:"pattern,omitempty"`
+	Interval  int        `json:"interval,omitempty"`
 	EndDate   *time.Time `json:"end_date,omitempty"`
 }
 
@@ -36,4 +39,17 @@ type AvailabilityResponse struct {
 	EndTime   time.Time `json:"end_time"`
 	Recurring bool      `json:"recurring"`
 	Pattern   string    `json:"pattern,omitempty"`
+	SeriesID  string    `json:"series_id,omitempty"`
+}
+
+type GetAvailabilityRequest struct {
+	StartDate time.Time `form:"start_date" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndDate   time.Time `form:"end_date" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type AvailabilityListResponse struct {
+	ParticipantID string                 `json:"participant_id"`
+	StartDate     time.Time              `json:"start_date"`
+	EndDate       time.Time              `json:"end_date"`
+	Availability  []AvailabilityResponse `json:"availability"`
 }
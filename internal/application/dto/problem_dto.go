@@ -0,0 +1,23 @@
+package dto
+
+// ProblemResponse is an RFC 7807 problem details body, the response to every
+// failed request. The fields after Instance are extensions that are only set
+// for the failures they describe.
+type ProblemResponse struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	Errors       []FieldErrorResponse          `json:"errors,omitempty"`       // Invalid values in the request
+	Conflicts    []ParticipantConflictResponse `json:"conflicts,omitempty"`    // Scheduling conflicts
+	Alternatives []AlternativeSlotResponse     `json:"alternatives,omitempty"` // Free times nearest the requested one
+}
+
+// FieldErrorResponse names an invalid value by its path in the request, e.g.
+// "attendees[1].role".
+type FieldErrorResponse struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// RecurrenceRuleDTO is a recurrence rule given field by field instead of as
+// an RRULE.
+type RecurrenceRuleDTO struct {
+	Pattern    string     `json:"pattern" binding:"required"` // daily, weekly, monthly or yearly
+	Interval   int        `json:"interval"`                   // 1 when omitted
+	DaysOfWeek []string   `json:"days_of_week"`               // Weekly rules; the start's weekday when omitted
+	DayOfMonth int        `json:"day_of_month"`               // Monthly rules; the start's day when omitted
+	WeekStart  string     `json:"week_start"`                 // Monday when omitted
+	EndDate    *time.Time `json:"end_date"`
+	MaxCount   int        `json:"max_count"`
+}
+
+// PreviewRecurrenceRequest expands a rule, given either as Rule or as RRule,
+// without booking anything.
+type PreviewRecurrenceRequest struct {
+	StartTime time.Time          `json:"start_time" binding:"required"` // First occurrence
+	EndTime   time.Time          `json:"end_time" binding:"required"`
+	Rule      *RecurrenceRuleDTO `json:"rule"`
+	RRule     string             `json:"rrule"`
+	Timezone  string             `json:"timezone"`  // IANA zone the rule is expanded in, UTC by default
+	Attendees []string           `json:"attendees"` // Participants whose schedules are checked for conflicts
+	Limit     int                `json:"limit"`     // Most occurrences returned, 100 by default
+
+	DSTGapPolicy       string `json:"dst_gap_policy"`
+	DSTAmbiguityPolicy string `json:"dst_ambiguity_policy"`
+}
+
+type RecurrenceOccurrenceResponse struct {
+	StartTime   time.Time                     `json:"start_time"`
+	EndTime     time.Time                     `json:"end_time"`
+	HasConflict bool                          `json:"has_conflict"`
+	Conflicts   []ParticipantConflictResponse `json:"conflicts"`
+}
+
+type RecurrencePreviewResponse struct {
+	RRule         string                         `json:"rrule"` // The rule in normalized RRULE form
+	Timezone      string                         `json:"timezone"`
+	Occurrences   []RecurrenceOccurrenceResponse `json:"occurrences"`
+	Count         int                            `json:"count"`
+	ConflictCount int                            `json:"conflict_count"`      // Occurrences with at least one conflict
+	NextDate      *time.Time                     `json:"next_date,omitempty"` // First occurrence left out by max_count or the limit
+	Truncated     bool                           `json:"truncated"`           // Whether the limit, not the rule, ended the preview
+}
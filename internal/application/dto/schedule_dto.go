@@ -5,21 +5,23 @@ import (
 )
 
 type AvailabilityQuery struct {
-	ParticipantIDs []string  `json:"participant_ids" binding:"required,min=1"`
-	StartDate      time.Time `json:"start_date" binding:"required"`
-	EndDate        time.Time `json:"end_date" binding:"required"`
-	Duration       int       `json:"duration_minutes" binding:"required,min=1"`
-	Timezone       string    `json:"timezone"`
+	ParticipantIDs         []string  `json:"participant_ids" binding:"required,min=1"`
+	OptionalParticipantIDs []string  `json:"optional_participant_ids"` // Weighed lower; their conflicts never rule a slot out
+	StartDate              time.Time `json:"start_date" binding:"required"`
+	EndDate                time.Time `json:"end_date" binding:"required"`
+	Duration               int       `json:"duration_minutes" binding:"required,min=1"`
+	Timezone               string    `json:"timezone"`
 }
 
 type TimeSlotResponse struct {
-	StartTime            time.Time `json:"start_time"`
-	EndTime              time.Time `json:"end_time"`
-	Score                float64   `json:"score"`
-	AvailableParticipants int      `json:"available_participants"`
-	TotalParticipants    int       `json:"total_participants"`
-	Reason               string    `json:"reason"`
-	Conflicts            int       `json:"conflicts"`
+	StartTime             time.Time `json:"start_time"`
+	EndTime               time.Time `json:"end_time"`
+	Score                 float64   `json:"score"`
+	AvailableParticipants int       `json:"available_participants"`
+	TotalParticipants     int       `json:"total_participants"`
+	Reason                string    `json:"reason"`
+	Conflicts             int       `json:"conflicts"`
+	OptionalConflicts     int       `json:"optional_conflicts"`
 }
 
 type AvailabilityResult struct {
@@ -36,23 +38,95 @@ type AvailabilityResult struct {
 	} `json:"summary"`
 }
 
+// WorkingIntervalDTO is a wall-clock interval such as 09:00-12:00. An end of
+// 24:00 runs to midnight.
+type WorkingIntervalDTO struct {
+	Start string `json:"start" binding:"required"`
+	End   string `json:"end" binding:"required"`
+}
+
+// WorkingHoursDTO is a weekly template keyed by weekday name ("monday") and
+// dated overrides keyed by local date ("2024-12-24"). An override with no
+// intervals is a day off.
+type WorkingHoursDTO struct {
+	Weekly    map[string][]WorkingIntervalDTO `json:"weekly"`
+	Overrides map[string][]WorkingIntervalDTO `json:"overrides,omitempty"`
+}
+
+// ConflictPolicyDTO sets how close together a schedule's appointments may
+// be and which of them take up time. Omitted fields take the defaults: no
+// buffers, no tolerance, no double-booking, every status but cancelled and
+// every response but declined busy, and severity thresholds of 0.3 and 0.8
+// of the proposed time.
+type ConflictPolicyDTO struct {
+	PreBufferMinutes        int      `json:"pre_buffer_minutes" binding:"min=0"`
+	PostBufferMinutes       int      `json:"post_buffer_minutes" binding:"min=0"`
+	OverlapToleranceMinutes int      `json:"overlap_tolerance_minutes" binding:"min=0"`
+	MaxConcurrent           int      `json:"max_concurrent" binding:"min=0"`
+	BusyStatuses            []string `json:"busy_statuses"`  // Appointment statuses, e.g. "tentative"
+	BusyResponses           []string `json:"busy_responses"` // Attendee responses, e.g. "accepted"
+	MajorThreshold          float64  `json:"major_threshold" binding:"min=0,max=1"`
+	CriticalThreshold       float64  `json:"critical_threshold" binding:"min=0,max=1"`
+}
+
+type CreateScheduleRequest struct {
+	OwnerID          string             `json:"owner_id" binding:"required"`
+	Timezone         string             `json:"timezone"`
+	WorkingHours     *WorkingHoursDTO   `json:"working_hours"` // Defaults to Monday-Friday 09:00-17:00
+	HolidayCalendars []string           `json:"holiday_calendars"`
+	ConflictPolicy   *ConflictPolicyDTO `json:"conflict_policy"`
+}
+
+type UpdateWorkingHoursRequest struct {
+	WorkingHoursDTO
+}
+
+type UpdateConflictPolicyRequest ConflictPolicyDTO
+
+type SetWorkingHoursOverrideRequest struct {
+	Intervals []WorkingIntervalDTO `json:"intervals"`
+}
+
+// ScheduleWindowRequest limits schedule reads to the appointments and
+// blocked times overlapping the window. Either bound may be omitted.
+type ScheduleWindowRequest struct {
+	StartDate time.Time `form:"start_date" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndDate   time.Time `form:"end_date" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type AddBlockedTimeRequest struct {
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+	Reason    string    `json:"reason"`
+}
+
+type BlockedTimeResponse struct {
+	ID        string    `json:"id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+}
+
+type BlockedTimeListResponse struct {
+	OwnerID      string                `json:"owner_id"`
+	BlockedTimes []BlockedTimeResponse `json:"blocked_times"`
+}
+
 type ScheduleOverview struct {
-	OwnerID           string    `json:"owner_id"`
-	Timezone          string    `json:"timezone"`
-	WorkingHoursStart string    `json:"working_hours_start"`
-	WorkingHoursEnd   string    `json:"working_hours_end"`
-	AppointmentsToday int       `json:"appointments_today"`
+	ID                string               `json:"id"`
+	OwnerID           string               `json:"owner_id"`
+	Timezone          string               `json:"timezone"`
+	WorkingHours      WorkingHoursDTO      `json:"working_hours"`
+	HolidayCalendars  []string             `json:"holiday_calendars"`
+	ConflictPolicy    ConflictPolicyDTO    `json:"conflict_policy"`
+	AppointmentsToday int                  `json:"appointments_today"`
 	NextAppointment   *AppointmentResponse `json:"next_appointment,omitempty"`
-	TotalAppointments int       `json:"total_appointments"`
-	LastUpdated       time.Time `json:"last_updated"`
+	TotalAppointments int                  `json:"total_appointments"`
+	LastUpdated       time.Time            `json:"last_updated"`
 }
 
 type ScheduleDetail struct {
 	ScheduleOverview
 	Appointments []AppointmentResponse `json:"appointments"`
-	BlockedTimes []struct {
-		StartTime time.Time `json:"start_time"`
-		EndTime   time.Time `json:"end_time"`
-		Reason    string    `json:"reason"`
-	} `json:"blocked_times"`
+	BlockedTimes []BlockedTimeResponse `json:"blocked_times"`
 }
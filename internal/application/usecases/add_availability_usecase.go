@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+type AddAvailabilityUseCase struct {
+	participantRepo      ParticipantRepository
+	recurrenceCalculator *services.RecurrenceCalculatorService
+}
+
+func NewAddAvailabilityUseCase(
+	participantRepo ParticipantRepository,
+	recurrenceCalculator *services.RecurrenceCalculatorService,
+) *AddAvailabilityUseCase {
+	return &AddAvailabilityUseCase{
+		participantRepo:      participantRepo,
+		recurrenceCalculator: recurrenceCalculator,
+	}
+}
+
+func (uc *AddAvailabilityUseCase) Execute(participantID string, request dto.AddAvailabilityRequest) (*dto.AvailabilityResponse, error) {
+	timeRange, err := valueobjects.NewTimeRange(request.StartTime, request.EndTime)
+	if err != nil {
+		return nil, domainerrors.AtField("end_time", "invalid time range", err)
+	}
+
+	participant, err := uc.participantRepo.FindByID(participantID)
+	if err != nil {
+		return nil, lookupError(err, ErrParticipantNotFound, participantID)
+	}
+
+	var response dto.AvailabilityResponse
+	if request.Recurring {
+		pattern, err := parseAvailabilityPattern(request.Pattern)
+		if err != nil {
+			return nil, domainerrors.AtField("pattern", "", err)
+		}
+
+		interval := request.Interval
+		if interval == 0 {
+			interval = 1
+		}
+
+		// Recurring availability is kept as a rule and expanded when read
+		series, err := entities.NewAvailabilitySeries(timeRange, string(pattern), interval, request.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurring availability: %w", err)
+		}
+
+		rule := seriesRule(series, timeRange.StartTime(), timeRange.EndTime())
+		if err := uc.recurrenceCalculator.ValidateRecurrenceRule(rule); err != nil {
+			return nil, fmt.Errorf("invalid recurring availability: %w", err)
+		}
+
+		participant.AddAvailabilitySeries(series)
+		response = newSeriesAvailabilityResponse(series, series.OccurrenceID(timeRange.StartTime()), timeRange)
+	} else {
+		slot := valueobjects.NewTimeSlot(timeRange, true, "")
+		participant.AddAvailability(slot)
+		response = newSlotAvailabilityResponse(slot)
+	}
+
+	if err := uc.participantRepo.Update(participant); err != nil {
+		return nil, internalError("failed to save availability", err)
+	}
+
+	return &response, nil
+}
+
+func newSlotAvailabilityResponse(slot valueobjects.TimeSlot) dto.AvailabilityResponse {
+	return dto.AvailabilityResponse{
+		ID:        slot.ID(),
+		StartTime: slot.TimeRange().StartTime(),
+		EndTime:   slot.TimeRange().EndTime(),
+	}
+}
+
+func newSeriesAvailabilityResponse(series entities.AvailabilitySeries, id string, occurrence valueobjects.TimeRange) dto.AvailabilityResponse {
+	return dto.AvailabilityResponse{
+		ID:        id,
+		StartTime: occurrence.StartTime(),
+		EndTime:   occurrence.EndTime(),
+		Recurring: true,
+		Pattern:   series.Pattern(),
+		SeriesID:  series.ID(),
+	}
+}
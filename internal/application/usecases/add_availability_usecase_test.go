@@ -0,0 +1,158 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/repositories"
+)
+
+type availabilityFixture struct {
+	participant *entities.Participant
+	berlin      *time.Location
+	add         *usecases.AddAvailabilityUseCase
+	get         *usecases.GetAvailabilityUseCase
+	remove      *usecases.RemoveAvailabilityUseCase
+}
+
+func newAvailabilityFixture(t *testing.T) *availabilityFixture {
+	t.Helper()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	repo := repositories.NewMemoryParticipantRepository()
+	participant, err := entities.NewParticipant("Alice", "alice@example.com", berlin)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(participant))
+
+	calculator := services.NewRecurrenceCalculatorService(valueobjects.DefaultLocalTimePolicy())
+	return &availabilityFixture{
+		participant: participant,
+		berlin:      berlin,
+		add:         usecases.NewAddAvailabilityUseCase(repo, calculator),
+		get:         usecases.NewGetAvailabilityUseCase(repo, calculator),
+		remove:      usecases.NewRemoveAvailabilityUseCase(repo, calculator),
+	}
+}
+
+// list returns the availability between from and to.
+func (f *availabilityFixture) list(t *testing.T, from, to time.Time) []dto.AvailabilityResponse {
+	t.Helper()
+
+	result, err := f.get.Execute(f.participant.ID(), dto.GetAvailabilityRequest{StartDate: from, EndDate: to})
+	require.NoError(t, err)
+	return result.Availability
+}
+
+// TestRecurringAvailabilityExpansion checks that a weekly series keeps its
+// wall-clock time in the participant's timezone across the DST change on
+// 2030-03-31, and is listed between concrete slots in start order.
+func TestRecurringAvailabilityExpansion(t *testing.T) {
+	f := newAvailabilityFixture(t)
+	start := time.Date(2030, 3, 18, 9, 0, 0, 0, f.berlin)
+	endDate := time.Date(2030, 4, 9, 0, 0, 0, 0, f.berlin)
+
+	series, err := f.add.Execute(f.participant.ID(), dto.AddAvailabilityRequest{
+		StartTime: start,
+		EndTime:   start.Add(2 * time.Hour),
+		Recurring: true,
+		Pattern:   "weekly",
+		EndDate:   &endDate,
+	})
+	require.NoError(t, err)
+	assert.True(t, series.Recurring)
+	assert.NotEmpty(t, series.SeriesID)
+
+	slotStart := time.Date(2030, 3, 26, 14, 0, 0, 0, f.berlin)
+	slot, err := f.add.Execute(f.participant.ID(), dto.AddAvailabilityRequest{StartTime: slotStart, EndTime: slotStart.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.False(t, slot.Recurring)
+
+	availability := f.list(t, start, start.AddDate(0, 1, 0))
+	starts := make([]time.Time, len(availability))
+	for i, item := range availability {
+		starts[i] = item.StartTime
+	}
+	require.Equal(t, []time.Time{
+		start,
+		start.AddDate(0, 0, 7),
+		slotStart,
+		start.AddDate(0, 0, 14),
+		start.AddDate(0, 0, 21),
+	}, starts, "the series stops at its end date")
+
+	for _, item := range availability {
+		if item.Recurring {
+			local := item.StartTime.In(f.berlin)
+			assert.Equal(t, 9, local.Hour(), "occurrence on %s", local.Format("2006-01-02"))
+			assert.Equal(t, 2*time.Hour, item.EndTime.Sub(item.StartTime))
+			assert.Equal(t, series.SeriesID, item.SeriesID)
+		}
+	}
+	assert.Equal(t, slot.ID, availability[2].ID)
+
+	t.Run("window", func(t *testing.T) {
+		// Only occurrences overlapping the window are listed
+		windowStart := start.AddDate(0, 0, 14).Add(time.Hour)
+		availability := f.list(t, windowStart, windowStart.Add(time.Hour))
+		require.Len(t, availability, 1)
+		assert.Equal(t, start.AddDate(0, 0, 14), availability[0].StartTime)
+
+		_, err := f.get.Execute(f.participant.ID(), dto.GetAvailabilityRequest{StartDate: start, EndDate: start.AddDate(2, 0, 0)})
+		assertInvalidField(t, err, "end_date")
+	})
+
+	t.Run("remove occurrence", func(t *testing.T) {
+		occurrence := availability[1]
+		require.True(t, occurrence.Recurring)
+		require.NoError(t, f.remove.Execute(f.participant.ID(), occurrence.ID))
+
+		remaining := f.list(t, start, start.AddDate(0, 1, 0))
+		require.Len(t, remaining, 4)
+		for _, item := range remaining {
+			assert.NotEqual(t, occurrence.ID, item.ID)
+		}
+
+		assert.ErrorIs(t, f.remove.Execute(f.participant.ID(), occurrence.ID), usecases.ErrAvailabilityNotFound)
+	})
+
+	t.Run("remove series", func(t *testing.T) {
+		// An occurrence ID for a time the series doesn't produce
+		missing := series.SeriesID + "@" + start.Add(time.Hour).UTC().Format("20060102T150405Z")
+		assert.ErrorIs(t, f.remove.Execute(f.participant.ID(), missing), usecases.ErrAvailabilityNotFound)
+
+		require.NoError(t, f.remove.Execute(f.participant.ID(), series.SeriesID))
+		remaining := f.list(t, start, start.AddDate(0, 1, 0))
+		require.Len(t, remaining, 1)
+		assert.Equal(t, slot.ID, remaining[0].ID)
+	})
+}
+
+func TestAddAvailabilityRejectsInvalidRequests(t *testing.T) {
+	f := newAvailabilityFixture(t)
+	start := time.Date(2030, 3, 18, 9, 0, 0, 0, f.berlin)
+
+	_, err := f.add.Execute(f.participant.ID(), dto.AddAvailabilityRequest{StartTime: start, EndTime: start})
+	assertInvalidField(t, err, "end_time")
+
+	_, err = f.add.Execute(f.participant.ID(), dto.AddAvailabilityRequest{
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Recurring: true,
+		Pattern:   "fortnightly",
+	})
+	assertInvalidField(t, err, "pattern")
+
+	_, err = f.add.Execute("missing", dto.AddAvailabilityRequest{StartTime: start, EndTime: start.Add(time.Hour)})
+	assert.ErrorIs(t, err, usecases.ErrParticipantNotFound)
+
+	assert.Empty(t, f.list(t, start, start.AddDate(0, 1, 0)))
+}
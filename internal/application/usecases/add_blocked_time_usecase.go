@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+type AddBlockedTimeUseCase struct {
+	unitOfWork UnitOfWork
+}
+
+func NewAddBlockedTimeUseCase(unitOfWork UnitOfWork) *AddBlockedTimeUseCase {
+	return &AddBlockedTimeUseCase{
+		unitOfWork: unitOfWork,
+	}
+}
+
+func (uc *AddBlockedTimeUseCase) Execute(ownerID string, request dto.AddBlockedTimeRequest) (*valueobjects.BlockedTime, error) {
+	timeRange, err := valueobjects.NewTimeRange(request.StartTime, request.EndTime)
+	if err != nil {
+		return nil, domainerrors.AtField("end_time", "invalid time range", err)
+	}
+
+	var blocked valueobjects.BlockedTime
+	err = uc.unitOfWork.Do(func(repos TxRepositories) error {
+		schedule, err := repos.Schedules.FindByOwnerID(ownerID)
+		if err != nil {
+			return lookupError(err, ErrScheduleNotFound, ownerID)
+		}
+
+		blocked = schedule.AddBlockedTime(timeRange, request.Reason)
+		return repos.Schedules.Save(schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &blocked, nil
+}
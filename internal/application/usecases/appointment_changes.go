@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"strings"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// AppointmentChange is one field an update changed, with its old and new
+// value as shown to attendees.
+type AppointmentChange struct {
+	Field string // title, description, start_time, end_time, location, attendees, tags, metadata or status; rrule or horizon for a series
+	From  string
+	To    string
+}
+
+// appointmentSnapshot is an appointment's fields before an update, to tell
+// afterwards what the update changed.
+type appointmentSnapshot struct {
+	title       string
+	description string
+	timeRange   valueobjects.TimeRange
+	location    string
+	attendees   []entities.Attendee
+	tags        []string
+	metadata    valueobjects.Metadata
+	status      entities.AppointmentStatus
+}
+
+func snapshotAppointment(appointment *entities.Appointment) appointmentSnapshot {
+	return appointmentSnapshot{
+		title:       appointment.Title(),
+		description: appointment.Description(),
+		timeRange:   appointment.TimeRange(),
+		location:    appointment.Location(),
+		attendees:   append([]entities.Attendee(nil), appointment.Attendees()...),
+		tags:        append([]string(nil), appointment.Tags()...),
+		metadata:    appointment.Metadata().Clone(),
+		status:      appointment.Status(),
+	}
+}
+
+func (s appointmentSnapshot) attendeeIDs() []string {
+	ids := make([]string, len(s.attendees))
+	for i, attendee := range s.attendees {
+		ids[i] = attendee.ParticipantID()
+	}
+	return ids
+}
+
+func (s appointmentSnapshot) attendee(participantID string) (entities.Attendee, bool) {
+	for _, attendee := range s.attendees {
+		if attendee.ParticipantID() == participantID {
+			return attendee, true
+		}
+	}
+	return entities.Attendee{}, false
+}
+
+// changesSince lists the fields of appointment that differ from before, in a
+// fixed order.
+func changesSince(before appointmentSnapshot, appointment *entities.Appointment) []AppointmentChange {
+	changes := make([]AppointmentChange, 0)
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, AppointmentChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add("title", before.title, appointment.Title())
+	add("description", before.description, appointment.Description())
+	add("start_time", before.timeRange.StartTime().Format(time.RFC3339), appointment.TimeRange().StartTime().Format(time.RFC3339))
+	add("end_time", before.timeRange.EndTime().Format(time.RFC3339), appointment.TimeRange().EndTime().Format(time.RFC3339))
+	add("location", before.location, appointment.Location())
+	add("attendees", formatAttendees(before.attendees), formatAttendees(appointment.Attendees()))
+	add("tags", formatTags(before.tags), formatTags(appointment.Tags()))
+	add("metadata", formatMetadata(before.metadata), formatMetadata(appointment.Metadata()))
+	add("status", string(before.status), string(appointment.Status()))
+	return changes
+}
+
+// seriesSnapshot is a series' fields before an edit, to tell afterwards what
+// the edit changed.
+type seriesSnapshot struct {
+	title     string
+	timeRange valueobjects.TimeRange
+	location  string
+	attendees []entities.Attendee
+	rule      string
+	status    entities.AppointmentStatus
+}
+
+func snapshotSeries(series *entities.AppointmentSeries) seriesSnapshot {
+	return seriesSnapshot{
+		title:     series.Title(),
+		timeRange: series.TimeRange(),
+		location:  series.Location(),
+		attendees: append([]entities.Attendee(nil), series.Attendees()...),
+		rule:      series.Rule(),
+		status:    series.Status(),
+	}
+}
+
+// seriesChangesSince lists the fields of series that differ from before, in
+// a fixed order. The times are those of the series' first occurrence.
+func seriesChangesSince(before seriesSnapshot, series *entities.AppointmentSeries) []AppointmentChange {
+	changes := make([]AppointmentChange, 0)
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, AppointmentChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add("title", before.title, series.Title())
+	add("start_time", before.timeRange.StartTime().Format(time.RFC3339), series.TimeRange().StartTime().Format(time.RFC3339))
+	add("end_time", before.timeRange.EndTime().Format(time.RFC3339), series.TimeRange().EndTime().Format(time.RFC3339))
+	add("location", before.location, series.Location())
+	add("attendees", formatAttendees(before.attendees), formatAttendees(series.Attendees()))
+	add("rrule", before.rule, series.Rule())
+	add("status", string(before.status), string(series.Status()))
+	return changes
+}
+
+// formatAttendees lists attendees as "alice (organizer, accepted), bob
+// (required, needs-action)".
+func formatAttendees(attendees []entities.Attendee) string {
+	parts := make([]string, len(attendees))
+	for i, attendee := range attendees {
+		parts[i] = attendee.ParticipantID() + " (" + string(attendee.Role()) + ", " + string(attendee.Response()) + ")"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func statusTransitionResponses(history []entities.StatusTransition) []dto.StatusTransitionResponse {
+	responses := make([]dto.StatusTransitionResponse, len(history))
+	for i, transition := range history {
+		responses[i] = dto.StatusTransitionResponse{
+			From:      transition.From(),
+			To:        transition.To(),
+			ChangedBy: transition.ChangedBy(),
+			Reason:    transition.Reason(),
+			ChangedAt: transition.ChangedAt(),
+		}
+	}
+	return responses
+}
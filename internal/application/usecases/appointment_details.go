@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// parseMetadata converts request metadata, whose values are decoded JSON.
+func parseMetadata(request map[string]interface{}) (valueobjects.Metadata, error) {
+	metadata := make(valueobjects.Metadata, len(request))
+	for key, raw := range request {
+		value, err := valueobjects.ParseMetadataValue(raw)
+		if err != nil {
+			return nil, domainerrors.AtField("metadata."+key, "invalid metadata "+key, err)
+		}
+		metadata[key] = value
+	}
+
+	if err := metadata.Validate(); err != nil {
+		return nil, domainerrors.AtField("metadata", "", err)
+	}
+	return metadata, nil
+}
+
+func metadataResponse(metadata valueobjects.Metadata) map[string]interface{} {
+	response := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		response[key] = value.Value()
+	}
+	return response
+}
+
+// formatMetadata lists metadata as "priority=2, ticket=OPS-1", by key.
+func formatMetadata(metadata valueobjects.Metadata) string {
+	parts := make([]string, 0, len(metadata))
+	for _, key := range metadata.Keys() {
+		parts = append(parts, key+"="+metadata[key].String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatTags lists tags in sorted order, so reordering them isn't a change.
+func formatTags(tags []string) string {
+	sorted := append([]string(nil), tags...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
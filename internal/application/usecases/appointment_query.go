@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+type AppointmentSortField string
+
+const (
+	SortByStartTime AppointmentSortField = "start_time"
+	SortByCreatedAt AppointmentSortField = "created_at"
+	SortByTitle     AppointmentSortField = "title"
+)
+
+// cursorTimeFormat is fixed width so that formatted times compare the same
+// way as strings and as times.
+const cursorTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+func (f AppointmentSortField) IsValid() bool {
+	switch f {
+	case SortByStartTime, SortByCreatedAt, SortByTitle:
+		return true
+	}
+	return false
+}
+
+// Key returns the appointment's value for this sort field in a form that
+// orders correctly as a string. It is what cursors store.
+func (f AppointmentSortField) Key(appointment *entities.Appointment) string {
+	switch f {
+	case SortByCreatedAt:
+		return appointment.CreatedAt().UTC().Format(cursorTimeFormat)
+	case SortByTitle:
+		return appointment.Title()
+	default:
+		return appointment.TimeRange().StartTime().UTC().Format(cursorTimeFormat)
+	}
+}
+
+// ParseKey converts a cursor key back to the value stored for the field.
+func (f AppointmentSortField) ParseKey(key string) (interface{}, error) {
+	if f == SortByTitle {
+		return key, nil
+	}
+	return time.Parse(cursorTimeFormat, key)
+}
+
+// AppointmentCursor marks the last appointment of a page. The next page
+// starts strictly after it in the query's sort order.
+type AppointmentCursor struct {
+	Key string
+	ID  string
+}
+
+type AppointmentQuery struct {
+	ParticipantID string
+	Statuses      []entities.AppointmentStatus
+	From          time.Time // Only appointments ending after From, if set
+	To            time.Time // Only appointments starting before To, if set
+	Location      string    // Case-insensitive exact match
+	TitleSearch   string    // Case-insensitive substring match
+	Tags          []string  // Only appointments with all of these tags
+	MetadataKeys  []string  // Only appointments with all of these metadata keys
+	SortBy        AppointmentSortField
+	Descending    bool
+	Limit         int
+	Offset        int
+	After         *AppointmentCursor
+}
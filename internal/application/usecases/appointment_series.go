@@ -0,0 +1,356 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// seriesOccurrence is an occurrence as the recurrence rule schedules it,
+// before exceptions and overrides are applied.
+type seriesOccurrence struct {
+	recurrenceID time.Time
+	timeRange    valueobjects.TimeRange
+}
+
+// parseSeriesRule parses an RRULE in the series' timezone and returns it in
+// its normalized form along with the parsed rule.
+func parseSeriesRule(value string, timezone *time.Location) (string, services.RecurrenceRule, error) {
+	rule, err := services.ParseRRule(value, timezone)
+	if err != nil {
+		return "", services.RecurrenceRule{}, domainerrors.AtField("rrule", "invalid recurrence rule", err)
+	}
+
+	formatted, err := services.FormatRRule(rule)
+	if err != nil {
+		return "", services.RecurrenceRule{}, domainerrors.AtField("rrule", "invalid recurrence rule", err)
+	}
+	return formatted, rule, nil
+}
+
+// expandAppointmentSeries returns every occurrence the rule schedules from
+// the start of the series up to until. Excluded occurrences are included,
+// since RFC 5545 counts them towards COUNT.
+func expandAppointmentSeries(
+	calculator *services.RecurrenceCalculatorService,
+	series *entities.AppointmentSeries,
+	until time.Time,
+) ([]seriesOccurrence, error) {
+	rule, err := services.ParseRRule(series.Rule(), series.Timezone())
+	if err != nil {
+		return nil, err
+	}
+
+	// Expand in the series' timezone so occurrences keep their wall-clock time
+	rule.Timezone = series.Timezone()
+	rule.LocalTimePolicy = series.LocalTimePolicy()
+	first, err := valueobjects.NewTimeRange(
+		series.TimeRange().StartTime().In(series.Timezone()),
+		series.TimeRange().EndTime().In(series.Timezone()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if first.StartTime().After(until) {
+		return nil, nil
+	}
+
+	if rule.EndDate == nil || rule.EndDate.After(until) {
+		rule.EndDate = &until
+	}
+
+	expanded, err := calculator.CalculateRecurrences(first, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]seriesOccurrence, len(expanded.TimeRanges))
+	for i, timeRange := range expanded.TimeRanges {
+		result[i] = seriesOccurrence{recurrenceID: timeRange.StartTime().UTC(), timeRange: timeRange}
+	}
+	return result, nil
+}
+
+// findSeriesOccurrence returns the occurrence of series whose original start
+// is recurrenceID, and how many occurrences the rule schedules before it.
+func findSeriesOccurrence(
+	calculator *services.RecurrenceCalculatorService,
+	series *entities.AppointmentSeries,
+	recurrenceID time.Time,
+) (seriesOccurrence, int, error) {
+	occurrences, err := expandAppointmentSeries(calculator, series, recurrenceID)
+	if err != nil {
+		return seriesOccurrence{}, 0, err
+	}
+
+	for i, occurrence := range occurrences {
+		if occurrence.recurrenceID.Equal(recurrenceID) && !series.IsExcluded(recurrenceID) {
+			return occurrence, i, nil
+		}
+	}
+	return seriesOccurrence{}, 0, fmt.Errorf("%w: %s", ErrOccurrenceNotFound, entities.FormatRecurrenceID(recurrenceID))
+}
+
+// findCurrentSeriesOccurrence returns the first occurrence of series that
+// hasn't ended by now, looking no further than until, and how many
+// occurrences the rule schedules before it.
+func findCurrentSeriesOccurrence(
+	calculator *services.RecurrenceCalculatorService,
+	series *entities.AppointmentSeries,
+	now, until time.Time,
+) (seriesOccurrence, int, error) {
+	occurrences, err := expandAppointmentSeries(calculator, series, until)
+	if err != nil {
+		return seriesOccurrence{}, 0, err
+	}
+
+	for i, occurrence := range occurrences {
+		if !series.IsExcluded(occurrence.recurrenceID) && !occurrenceHasEnded(series, occurrence, now) {
+			return occurrence, i, nil
+		}
+	}
+	return seriesOccurrence{}, 0, domainerrors.Conflict("appointment series has no upcoming occurrences")
+}
+
+// occurrenceTimeRange is where the occurrence takes place, which an override
+// may have moved it from.
+func occurrenceTimeRange(series *entities.AppointmentSeries, occurrence seriesOccurrence) valueobjects.TimeRange {
+	if override, exists := series.Override(occurrence.recurrenceID); exists && override.TimeRange() != nil {
+		return *override.TimeRange()
+	}
+	return occurrence.timeRange
+}
+
+func occurrenceHasEnded(series *entities.AppointmentSeries, occurrence seriesOccurrence, now time.Time) bool {
+	return !occurrenceTimeRange(series, occurrence).EndTime().After(now)
+}
+
+// materializeAppointmentSeries builds the appointments for the occurrences
+// of series up to its horizon, leaving out excluded ones. A cancelled series
+// has none.
+func materializeAppointmentSeries(
+	calculator *services.RecurrenceCalculatorService,
+	series *entities.AppointmentSeries,
+) ([]*entities.Appointment, error) {
+	if series.Status() == entities.StatusCancelled {
+		return nil, nil
+	}
+
+	occurrences, err := expandAppointmentSeries(calculator, series, series.Horizon())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.Appointment, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		if series.IsExcluded(occurrence.recurrenceID) {
+			continue
+		}
+		result = append(result, series.Occurrence(occurrence.recurrenceID, occurrence.timeRange))
+	}
+	return result, nil
+}
+
+// loadSeriesOccurrences returns the stored appointments for the occurrences
+// of series that are currently materialized.
+func loadSeriesOccurrences(
+	appointments AppointmentRepository,
+	calculator *services.RecurrenceCalculatorService,
+	series *entities.AppointmentSeries,
+) ([]*entities.Appointment, error) {
+	expected, err := materializeAppointmentSeries(calculator, series)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.Appointment, 0, len(expected))
+	for _, occurrence := range expected {
+		appointment, err := appointments.FindByID(occurrence.ID())
+		if errors.Is(err, ErrAppointmentNotFound) {
+			continue // Not materialized, e.g. moved past the horizon
+		}
+		if err != nil {
+			return nil, internalError("failed to look up occurrence "+occurrence.ID(), err)
+		}
+		result = append(result, appointment)
+	}
+	return result, nil
+}
+
+// extendSeriesHorizon materializes the series until horizon past now, or
+// past its first occurrence if that is later. The horizon never moves back,
+// so occurrences that were already booked stay booked.
+func extendSeriesHorizon(series *entities.AppointmentSeries, horizon time.Duration) {
+	from := time.Now()
+	if series.TimeRange().StartTime().After(from) {
+		from = series.TimeRange().StartTime()
+	}
+
+	if until := from.Add(horizon); until.After(series.Horizon()) {
+		series.SetHorizon(until)
+	}
+}
+
+// seriesChanges is what booking a series' occurrences did.
+type seriesChanges struct {
+	created []*entities.Appointment
+	updated []*entities.Appointment
+	removed []*entities.Appointment
+	before  map[string]appointmentSnapshot // Updated occurrences as they were, by ID
+}
+
+// bookSeriesOccurrences brings the stored occurrences of a series in line
+// with desired. New and moved occurrences are checked for conflicts on every
+// attendee's schedule and booked; the rest are updated in place. Occurrences
+// in previous that are no longer desired are taken off the schedules and
+// returned for the caller to delete or cancel. Occurrences that ended by
+// since are left as they took place: stored ones are neither changed nor
+// removed, and new ones aren't booked.
+func bookSeriesOccurrences(
+	repos TxRepositories,
+	conflictDetector *services.ConflictDetectionService,
+	attendees []string,
+	previous []*entities.Appointment,
+	desired []*entities.Appointment,
+	since time.Time,
+) (seriesChanges, error) {
+	changes := seriesChanges{before: make(map[string]appointmentSnapshot)}
+
+	previousByID := make(map[string]*entities.Appointment, len(previous))
+	for _, appointment := range previous {
+		previousByID[appointment.ID()] = appointment
+	}
+
+	desiredIDs := make(map[string]bool, len(desired))
+	toBook := make([]*entities.Appointment, 0)
+	for _, occurrence := range desired {
+		desiredIDs[occurrence.ID()] = true
+
+		existing, exists := previousByID[occurrence.ID()]
+		if !exists {
+			if !occurrence.TimeRange().EndTime().After(since) {
+				continue
+			}
+			changes.created = append(changes.created, occurrence)
+			toBook = append(toBook, occurrence)
+			continue
+		}
+
+		if !existing.TimeRange().EndTime().After(since) {
+			continue
+		}
+
+		moved := !existing.TimeRange().StartTime().Equal(occurrence.TimeRange().StartTime()) ||
+			!existing.TimeRange().EndTime().Equal(occurrence.TimeRange().EndTime())
+		if !moved && existing.Title() == occurrence.Title() && existing.Location() == occurrence.Location() {
+			continue
+		}
+
+		changes.before[existing.ID()] = snapshotAppointment(existing)
+		if existing.Title() != occurrence.Title() {
+			if err := existing.Retitle(occurrence.Title()); err != nil {
+				return changes, err
+			}
+		}
+		if existing.Location() != occurrence.Location() {
+			existing.Relocate(occurrence.Location())
+		}
+		if moved {
+			existing.Reschedule(occurrence.TimeRange())
+			toBook = append(toBook, existing)
+		}
+		changes.updated = append(changes.updated, existing)
+	}
+
+	for _, appointment := range previous {
+		if !desiredIDs[appointment.ID()] && appointment.TimeRange().EndTime().After(since) {
+			changes.removed = append(changes.removed, appointment)
+		}
+	}
+
+	// Take moved and removed occurrences off the schedules first so they
+	// don't conflict with where the series is going
+	unbook := append(append([]*entities.Appointment(nil), changes.removed...), toBook...)
+	schedules := make([]*entities.Schedule, 0, len(attendees))
+	touched := make(map[string]bool, len(attendees))
+	for _, attendeeID := range lockOrder(attendees) {
+		schedule, err := findSchedule(repos.Schedules, attendeeID)
+		if err != nil {
+			return changes, err
+		}
+		if schedule == nil {
+			continue // The participant has no schedule yet
+		}
+
+		for _, appointment := range unbook {
+			if schedule.RemoveAppointment(appointment.ID()) == nil {
+				touched[schedule.OwnerID()] = true
+			}
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	// Book in time order, so an occurrence that overlaps an earlier one of
+	// the same series is reported as the conflict
+	sort.Slice(toBook, func(i, j int) bool {
+		return toBook[i].TimeRange().StartTime().Before(toBook[j].TimeRange().StartTime())
+	})
+	var conflicts conflictCollector
+	for _, occurrence := range toBook {
+		for _, schedule := range schedules {
+			if schedule.Blocks(occurrence) {
+				conflictResult := conflictDetector.DetectConflicts(schedule, occurrence.TimeRange())
+				if conflictResult.HasConflict {
+					conflicts.add(schedule.OwnerID(), occurrence.TimeRange(), conflictResult)
+					continue
+				}
+			}
+
+			if err := schedule.AddAppointment(occurrence); err != nil {
+				return changes, fmt.Errorf("failed to add occurrence to schedule of participant %s: %w", schedule.OwnerID(), err)
+			}
+			touched[schedule.OwnerID()] = true
+		}
+	}
+
+	if err := conflicts.err(); err != nil {
+		return changes, err
+	}
+
+	// Appointments are written before the schedules that refer to them
+	for _, appointment := range changes.created {
+		if err := repos.Appointments.Save(appointment); err != nil {
+			return changes, internalError("failed to save occurrence", err)
+		}
+	}
+	for _, appointment := range changes.updated {
+		if err := repos.Appointments.Update(appointment); err != nil {
+			return changes, internalError("failed to update occurrence", err)
+		}
+	}
+
+	for _, schedule := range schedules {
+		if !touched[schedule.OwnerID()] {
+			continue
+		}
+		if err := repos.Schedules.Save(schedule); err != nil {
+			return changes, internalError("failed to save schedule of participant "+schedule.OwnerID(), err)
+		}
+	}
+
+	return changes, nil
+}
+
+func checkSeriesVersion(series *entities.AppointmentSeries, expectedVersion *int) error {
+	if expectedVersion != nil && *expectedVersion != series.Version() {
+		return fmt.Errorf("%w: series is at version %d, expected %d", ErrPreconditionFailed, series.Version(), *expectedVersion)
+	}
+	return nil
+}
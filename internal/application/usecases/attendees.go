@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"strconv"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+// parseAttendees builds the attendees of a new appointment or series;
+// attendees without a role are required.
+func parseAttendees(requests []dto.AttendeeRequest) ([]entities.Attendee, error) {
+	attendees := make([]entities.Attendee, 0, len(requests))
+	for i, request := range requests {
+		field := "attendees[" + strconv.Itoa(i) + "]"
+		role := entities.RoleRequired
+		if request.Role != "" {
+			parsed, err := entities.ParseAttendeeRole(request.Role)
+			if err != nil {
+				return nil, domainerrors.AtField(field+".role", "", err)
+			}
+			role = parsed
+		}
+
+		attendee, err := entities.NewAttendee(request.ParticipantID, role)
+		if err != nil {
+			return nil, domainerrors.AtField(field, "invalid attendee", err)
+		}
+		attendees = append(attendees, attendee)
+	}
+	return attendees, nil
+}
+
+func attendeeResponses(attendees []entities.Attendee) []dto.AttendeeResponse {
+	responses := make([]dto.AttendeeResponse, len(attendees))
+	for i, attendee := range attendees {
+		responses[i] = dto.AttendeeResponse{
+			ParticipantID: attendee.ParticipantID(),
+			Role:          attendee.Role(),
+			Response:      attendee.Response(),
+			RespondedAt:   attendee.RespondedAt(),
+		}
+	}
+	return responses
+}
@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// maxAvailabilityWindow bounds how far recurring availability is expanded in
+// one request.
+const maxAvailabilityWindow = 366 * 24 * time.Hour
+
+func parseAvailabilityPattern(pattern string) (services.RecurrencePattern, error) {
+	switch services.RecurrencePattern(pattern) {
+	case services.PatternDaily, services.PatternWeekly, services.PatternMonthly, services.PatternYearly:
+		return services.RecurrencePattern(pattern), nil
+	}
+	return "", domainerrors.Invalid("unsupported recurrence pattern: " + pattern)
+}
+
+// seriesRule builds the recurrence rule for series, stopping at until or the
+// series' own end date, whichever comes first.
+func seriesRule(series entities.AvailabilitySeries, first time.Time, until time.Time) services.RecurrenceRule {
+	rule := services.RecurrenceRule{
+		Pattern:  services.RecurrencePattern(series.Pattern()),
+		Interval: series.Interval(),
+		EndDate:  &until,
+	}
+
+	if series.EndDate() != nil && series.EndDate().Before(until) {
+		rule.EndDate = series.EndDate()
+	}
+
+	switch rule.Pattern {
+	case services.PatternWeekly:
+		rule.DaysOfWeek = []time.Weekday{first.Weekday()}
+	case services.PatternMonthly:
+		rule.DayOfMonth = first.Day()
+	}
+
+	return rule
+}
+
+// seriesOccurrences expands series in the participant's timezone and returns
+// the occurrences overlapping window, skipping removed ones.
+func seriesOccurrences(
+	calculator *services.RecurrenceCalculatorService,
+	series entities.AvailabilitySeries,
+	timezone *time.Location,
+	window valueobjects.TimeRange,
+) ([]valueobjects.TimeRange, error) {
+	first, err := valueobjects.NewTimeRange(
+		series.TimeRange().StartTime().In(timezone),
+		series.TimeRange().EndTime().In(timezone),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if first.StartTime().After(window.EndTime()) {
+		return nil, nil
+	}
+
+	rule := seriesRule(series, first.StartTime(), window.EndTime())
+	rule.Timezone = timezone
+	expanded, err := calculator.CalculateRecurrences(first, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]valueobjects.TimeRange, 0)
+	for _, occurrence := range expanded.TimeRanges {
+		if !occurrence.OverlapsWith(window) || series.IsExcluded(occurrence.StartTime()) {
+			continue
+		}
+		result = append(result, occurrence)
+	}
+	return result, nil
+}
+
+// materializeAvailability adds the occurrences of every recurring series that
+// overlap window to the participant's concrete availability, so services that
+// only look at time slots see them.
+func materializeAvailability(
+	calculator *services.RecurrenceCalculatorService,
+	participant *entities.Participant,
+	window valueobjects.TimeRange,
+) error {
+	for _, series := range participant.AvailabilitySeries() {
+		occurrences, err := seriesOccurrences(calculator, series, participant.Timezone(), window)
+		if err != nil {
+			return err
+		}
+
+		for _, occurrence := range occurrences {
+			slot := valueobjects.RestoreTimeSlot(series.OccurrenceID(occurrence.StartTime()), occurrence, true, "")
+			participant.AddAvailability(slot)
+		}
+	}
+	return nil
+}
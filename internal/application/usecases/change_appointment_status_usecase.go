@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+// StatusAction moves an appointment through its lifecycle. Cancelling goes
+// through UpdateAppointmentUseCase.Cancel.
+type StatusAction string
+
+const (
+	ActionConfirm  StatusAction = "confirm"
+	ActionStart    StatusAction = "start"
+	ActionComplete StatusAction = "complete"
+	ActionNoShow   StatusAction = "no-show"
+	ActionReopen   StatusAction = "reopen"
+)
+
+type ChangeAppointmentStatusUseCase struct {
+	unitOfWork          UnitOfWork
+	notificationGateway NotificationGateway
+	conflictDetector    *services.ConflictDetectionService
+}
+
+func NewChangeAppointmentStatusUseCase(
+	unitOfWork UnitOfWork,
+	notificationGateway NotificationGateway,
+	conflictDetector *services.ConflictDetectionService,
+) *ChangeAppointmentStatusUseCase {
+	return &ChangeAppointmentStatusUseCase{
+		unitOfWork:          unitOfWork,
+		notificationGateway: notificationGateway,
+		conflictDetector:    conflictDetector,
+	}
+}
+
+// Execute applies action to the appointment, recording who did it and why.
+// When expectedVersion is set, it only goes through if the stored
+// appointment is still at that version. A change that takes up an
+// attendee's time again, such as confirming under a policy where only
+// confirmed appointments are busy or reopening a cancelled appointment,
+// fails if the attendee has since booked the time.
+func (uc *ChangeAppointmentStatusUseCase) Execute(appointmentID string, action StatusAction, request dto.ChangeAppointmentStatusRequest, expectedVersion *int) (*entities.Appointment, error) {
+	var (
+		appointment *entities.Appointment
+		changes     []AppointmentChange
+	)
+	err := uc.unitOfWork.Do(func(repos TxRepositories) error {
+		var err error
+		appointment, err = repos.Appointments.FindByID(appointmentID)
+		if err != nil {
+			return lookupError(err, ErrAppointmentNotFound, appointmentID)
+		}
+
+		if err := checkVersion(appointment, expectedVersion); err != nil {
+			return err
+		}
+
+		before := snapshotAppointment(appointment)
+		switch action {
+		case ActionConfirm:
+			err = appointment.Confirm(request.ChangedBy, request.Reason)
+		case ActionStart:
+			err = appointment.Start(request.ChangedBy, request.Reason)
+		case ActionComplete:
+			err = appointment.Complete(request.ChangedBy, request.Reason)
+		case ActionNoShow:
+			err = appointment.MarkNoShow(request.ChangedBy, request.Reason)
+		case ActionReopen:
+			// Cancelled occurrences are excluded from their series
+			if before.status == entities.StatusCancelled && appointment.SeriesID() != "" {
+				return fmt.Errorf("%w: change it through appointment series %s", ErrSeriesOccurrence, appointment.SeriesID())
+			}
+			err = appointment.Reopen(request.ChangedBy, request.Reason)
+		default:
+			err = domainerrors.Invalid("unknown status action: " + string(action))
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := repos.Appointments.Update(appointment); err != nil {
+			return internalError("failed to update appointment", err)
+		}
+
+		if err := uc.rebook(repos, appointment, before); err != nil {
+			return err
+		}
+
+		changes = changesSince(before, appointment)
+		return nil
+	})
+	if err != nil {
+		return nil, preconditionError(err, expectedVersion)
+	}
+
+	// Send notification
+	err = uc.notificationGateway.SendAppointmentUpdated(appointment, changes)
+	if err != nil {
+		// Log error but don't fail the operation
+	}
+
+	return appointment, nil
+}
+
+// rebook checks the schedule of every attendee whose time the new status
+// takes up, failing with every conflict, and puts a reopened appointment back
+// on the schedules. Schedules read the appointment's current status, so
+// other changes need no write.
+func (uc *ChangeAppointmentStatusUseCase) rebook(repos TxRepositories, appointment *entities.Appointment, before appointmentSnapshot) error {
+	// Cancelling took the appointment off the schedules
+	reopened := before.status == entities.StatusCancelled
+
+	var conflicts conflictCollector
+	for _, attendeeID := range lockOrder(appointment.AttendeeIDs()) {
+		schedule, err := findSchedule(repos.Schedules, attendeeID)
+		if err != nil {
+			return err
+		}
+		if schedule == nil {
+			continue // The participant has no schedule yet
+		}
+
+		// Take this appointment off the schedule so it doesn't conflict with itself
+		schedule.RemoveAppointment(appointment.ID())
+		previous, _ := before.attendee(attendeeID)
+		wasBusy := !reopened && schedule.ConflictPolicy().BlocksAttendee(before.status, previous)
+		if !wasBusy && schedule.Blocks(appointment) {
+			conflictResult := uc.conflictDetector.DetectConflicts(schedule, appointment.TimeRange())
+			if conflictResult.HasConflict {
+				conflicts.add(attendeeID, appointment.TimeRange(), conflictResult)
+				continue
+			}
+		}
+
+		if !reopened {
+			continue
+		}
+
+		if err := schedule.AddAppointment(appointment); err != nil {
+			return fmt.Errorf("failed to add appointment to schedule of participant %s: %w", attendeeID, err)
+		}
+
+		if err := repos.Schedules.Save(schedule); err != nil {
+			return internalError("failed to save schedule of participant "+attendeeID, err)
+		}
+	}
+	return conflicts.err()
+}
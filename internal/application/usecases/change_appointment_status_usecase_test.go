@@ -0,0 +1,165 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+type statusFixture struct {
+	scheduleFixture
+	book   *usecases.CreateAppointmentUseCase
+	update *usecases.UpdateAppointmentUseCase
+	change *usecases.ChangeAppointmentStatusUseCase
+}
+
+// newStatusFixture gives alice a UTC schedule on which only confirmed
+// appointments take up her time.
+func newStatusFixture(t *testing.T) statusFixture {
+	t.Helper()
+
+	f := statusFixture{scheduleFixture: newScheduleFixture(t)}
+	_, err := f.create.Execute(dto.CreateScheduleRequest{
+		OwnerID:        "alice",
+		Timezone:       "UTC",
+		ConflictPolicy: &dto.ConflictPolicyDTO{BusyStatuses: []string{"confirmed"}},
+	})
+	require.NoError(t, err)
+
+	detector := services.NewConflictDetectionService(nil)
+	f.book = usecases.NewCreateAppointmentUseCase(f.unitOfWork, noopNotificationGateway{}, detector, nil, 0)
+	f.update = usecases.NewUpdateAppointmentUseCase(f.unitOfWork, noopNotificationGateway{}, detector, nil, 0)
+	f.change = usecases.NewChangeAppointmentStatusUseCase(f.unitOfWork, noopNotificationGateway{}, detector)
+	return f
+}
+
+// bookAlice books alice from 10:00 to 11:00 on Monday 2030-03-04.
+func (f statusFixture) bookAlice(t *testing.T, title string, tentative bool) string {
+	t.Helper()
+
+	start := time.Date(2030, 3, 4, 10, 0, 0, 0, time.UTC)
+	response, err := f.book.Execute(dto.CreateAppointmentRequest{
+		Title:     title,
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Attendees: []dto.AttendeeRequest{{ParticipantID: "alice"}},
+		Tentative: tentative,
+	})
+	require.NoError(t, err)
+	return response.ID
+}
+
+func (f statusFixture) status(t *testing.T, appointmentID string) entities.AppointmentStatus {
+	t.Helper()
+
+	appointment, err := f.appointments.FindByID(appointmentID)
+	require.NoError(t, err)
+	return appointment.Status()
+}
+
+func TestChangeAppointmentStatusRechecksConflicts(t *testing.T) {
+	t.Run("confirm", func(t *testing.T) {
+		f := newStatusFixture(t)
+
+		// A tentative hold doesn't take up alice's time, so both are booked
+		held := f.bookAlice(t, "Held", true)
+		f.bookAlice(t, "Review", false)
+
+		_, err := f.change.Execute(held, usecases.ActionConfirm, dto.ChangeAppointmentStatusRequest{ChangedBy: "bob"}, nil)
+		var conflictErr *usecases.ConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		require.Len(t, conflictErr.Conflicts, 1)
+		assert.Equal(t, "alice", conflictErr.Conflicts[0].ParticipantID)
+		assert.Equal(t, entities.StatusTentative, f.status(t, held), "nothing was saved")
+	})
+
+	t.Run("confirm free time", func(t *testing.T) {
+		f := newStatusFixture(t)
+		held := f.bookAlice(t, "Held", true)
+
+		appointment, err := f.change.Execute(held, usecases.ActionConfirm, dto.ChangeAppointmentStatusRequest{ChangedBy: "bob", Reason: "approved"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, entities.StatusConfirmed, appointment.Status())
+
+		history := appointment.StatusHistory()
+		require.Len(t, history, 1)
+		assert.Equal(t, "bob", history[0].ChangedBy())
+		assert.Equal(t, "approved", history[0].Reason())
+
+		// Alice's time is now taken
+		start := time.Date(2030, 3, 4, 10, 30, 0, 0, time.UTC)
+		_, err = f.book.Execute(dto.CreateAppointmentRequest{
+			Title:     "Overlap",
+			StartTime: start,
+			EndTime:   start.Add(time.Hour),
+			Attendees: []dto.AttendeeRequest{{ParticipantID: "alice"}},
+		})
+		assert.ErrorAs(t, err, new(*usecases.ConflictError))
+	})
+
+	t.Run("busy status stays busy", func(t *testing.T) {
+		f := newStatusFixture(t)
+		review := f.bookAlice(t, "Review", false)
+
+		// Confirmed to in-progress frees the time under this policy, and
+		// must not conflict with the appointment itself on the way
+		_, err := f.change.Execute(review, usecases.ActionStart, dto.ChangeAppointmentStatusRequest{}, nil)
+		require.NoError(t, err)
+		_, err = f.change.Execute(review, usecases.ActionComplete, dto.ChangeAppointmentStatusRequest{}, nil)
+		require.NoError(t, err)
+		_, err = f.change.Execute(review, usecases.ActionReopen, dto.ChangeAppointmentStatusRequest{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, entities.StatusConfirmed, f.status(t, review))
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		f := newStatusFixture(t)
+		cancelled := f.bookAlice(t, "Cancelled", false)
+		require.NoError(t, f.update.Cancel(cancelled, dto.ChangeAppointmentStatusRequest{}, nil))
+
+		schedule, err := f.schedules.FindByOwnerID("alice")
+		require.NoError(t, err)
+		assert.Empty(t, schedule.Appointments(), "cancelling frees the time")
+
+		f.bookAlice(t, "Replacement", false)
+		_, err = f.change.Execute(cancelled, usecases.ActionReopen, dto.ChangeAppointmentStatusRequest{}, nil)
+		assert.ErrorAs(t, err, new(*usecases.ConflictError))
+		assert.Equal(t, entities.StatusCancelled, f.status(t, cancelled), "nothing was saved")
+	})
+
+	t.Run("reopen free time", func(t *testing.T) {
+		f := newStatusFixture(t)
+		cancelled := f.bookAlice(t, "Cancelled", false)
+		require.NoError(t, f.update.Cancel(cancelled, dto.ChangeAppointmentStatusRequest{}, nil))
+
+		_, err := f.change.Execute(cancelled, usecases.ActionReopen, dto.ChangeAppointmentStatusRequest{}, nil)
+		require.NoError(t, err)
+
+		schedule, err := f.schedules.FindByOwnerID("alice")
+		require.NoError(t, err)
+		require.Len(t, schedule.Appointments(), 1, "booked again")
+		assert.Equal(t, cancelled, schedule.Appointments()[0].ID())
+	})
+}
+
+func TestChangeAppointmentStatusRejectsInvalidRequests(t *testing.T) {
+	f := newStatusFixture(t)
+	review := f.bookAlice(t, "Review", false)
+
+	_, err := f.change.Execute(review, usecases.StatusAction("archive"), dto.ChangeAppointmentStatusRequest{}, nil)
+	kind, _ := domainerrors.KindOf(err)
+	assert.Equal(t, domainerrors.KindValidation, kind)
+
+	_, err = f.change.Execute(review, usecases.ActionConfirm, dto.ChangeAppointmentStatusRequest{}, nil)
+	assert.ErrorIs(t, err, entities.ErrInvalidStatusTransition)
+
+	_, err = f.change.Execute("missing", usecases.ActionStart, dto.ChangeAppointmentStatusRequest{}, nil)
+	assert.ErrorIs(t, err, usecases.ErrAppointmentNotFound)
+}
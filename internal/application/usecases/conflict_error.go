@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// ErrSchedulingConflict is wrapped by every ConflictError.
+var ErrSchedulingConflict = domainerrors.Conflict("appointment conflicts with existing schedule")
+
+// ConflictError is returned when an appointment can't be booked because it
+// clashes with attendees' schedules. It lists every clash for every attendee,
+// not only the first one found.
+type ConflictError struct {
+	Conflicts    []dto.ParticipantConflictResponse
+	Alternatives []dto.AlternativeSlotResponse // Nearest times that would fit, if searched for
+}
+
+func (e *ConflictError) Error() string {
+	parts := make([]string, len(e.Conflicts))
+	for i, conflict := range e.Conflicts {
+		types := make([]string, len(conflict.Conflicts))
+		for j, c := range conflict.Conflicts {
+			types[j] = c.Type
+		}
+		parts[i] = "participant " + conflict.ParticipantID + " at " + conflict.StartTime.Format(time.RFC3339) + " (" + strings.Join(types, ", ") + ")"
+	}
+	return ErrSchedulingConflict.Error() + " for " + strings.Join(parts, "; ")
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrSchedulingConflict
+}
+
+// conflictCollector gathers the conflicts found while booking, so they can be
+// reported together.
+type conflictCollector struct {
+	conflicts []dto.ParticipantConflictResponse
+}
+
+// add records the conflicts in result of participantID at timeRange, if any.
+func (c *conflictCollector) add(participantID string, timeRange valueobjects.TimeRange, result services.ConflictResult) {
+	if result.HasConflict {
+		c.conflicts = append(c.conflicts, participantConflictResponse(participantID, timeRange, result))
+	}
+}
+
+// err returns a *ConflictError with the collected conflicts, or nil if there
+// are none.
+func (c *conflictCollector) err() error {
+	if len(c.conflicts) == 0 {
+		return nil
+	}
+	return &ConflictError{Conflicts: c.conflicts}
+}
+
+// suggestAlternatives adds to a *ConflictError the nearest times at which
+// appointment would fit the schedules of every attendee whose time it takes
+// up. Other errors are returned as they are. It is called once the failed
+// booking has been rolled back: the search can take a while, so it runs on
+// schedules loaded in a unit of work of their own, without holding the
+// owners' locks. Alternatives are left out if the schedules can't be loaded.
+func suggestAlternatives(err error, unitOfWork UnitOfWork, timeFinder *services.OptimalTimeFinderService, appointment *entities.Appointment, count int) error {
+	var conflictErr *ConflictError
+	if timeFinder == nil || count <= 0 || !errors.As(err, &conflictErr) {
+		return err
+	}
+
+	schedules := make([]*entities.Schedule, 0)
+	loadErr := unitOfWork.Do(func(repos TxRepositories) error {
+		for _, attendeeID := range lockOrder(appointment.AttendeeIDs()) {
+			schedule, err := findSchedule(repos.Schedules, attendeeID)
+			if err != nil {
+				return err
+			}
+			if schedule == nil || !schedule.Blocks(appointment) {
+				continue // Participants without a schedule are free at any time
+			}
+
+			// An appointment being moved doesn't stand in its own way. The
+			// schedule is only read, never saved
+			schedule.RemoveAppointment(appointment.ID())
+			schedules = append(schedules, schedule)
+		}
+		return nil
+	})
+	if loadErr != nil {
+		return err
+	}
+
+	conflictErr.Alternatives = make([]dto.AlternativeSlotResponse, 0, count)
+	for _, timeRange := range timeFinder.FindNearestAvailableSlots(schedules, appointment.TimeRange(), count, time.Now()) {
+		conflictErr.Alternatives = append(conflictErr.Alternatives, dto.AlternativeSlotResponse{
+			StartTime: timeRange.StartTime(),
+			EndTime:   timeRange.EndTime(),
+		})
+	}
+	return err
+}
+
+func participantConflictResponse(participantID string, timeRange valueobjects.TimeRange, result services.ConflictResult) dto.ParticipantConflictResponse {
+	response := dto.ParticipantConflictResponse{
+		ParticipantID: participantID,
+		StartTime:     timeRange.StartTime(),
+		EndTime:       timeRange.EndTime(),
+		Severity:      string(result.Severity),
+		Conflicts:     make([]dto.ConflictResponse, 0, len(result.Conflicts)),
+	}
+
+	for _, conflict := range result.Conflicts {
+		response.Conflicts = append(response.Conflicts, dto.ConflictResponse{
+			Type:         string(conflict.Type),
+			SourceID:     conflict.SourceID,
+			StartTime:    conflict.TimeRange.StartTime(),
+			EndTime:      conflict.TimeRange.EndTime(),
+			OverlapStart: conflict.OverlapRange.StartTime(),
+			OverlapEnd:   conflict.OverlapRange.EndTime(),
+			Severity:     string(conflict.Severity),
+		})
+	}
+	return response
+}
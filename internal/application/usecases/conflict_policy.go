@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+// parseConflictPolicy fills the fields request leaves out from the default
+// policy.
+func parseConflictPolicy(request dto.ConflictPolicyDTO) (entities.ConflictPolicy, error) {
+	policy := entities.DefaultConflictPolicy()
+	policy.PreBuffer = time.Duration(request.PreBufferMinutes) * time.Minute
+	policy.PostBuffer = time.Duration(request.PostBufferMinutes) * time.Minute
+	policy.OverlapTolerance = time.Duration(request.OverlapToleranceMinutes) * time.Minute
+
+	if request.MaxConcurrent != 0 {
+		policy.MaxConcurrent = request.MaxConcurrent
+	}
+	if request.MajorThreshold != 0 {
+		policy.MajorThreshold = request.MajorThreshold
+	}
+	if request.CriticalThreshold != 0 {
+		policy.CriticalThreshold = request.CriticalThreshold
+	}
+
+	if len(request.BusyStatuses) > 0 {
+		policy.BusyStatuses = make([]entities.AppointmentStatus, 0, len(request.BusyStatuses))
+		for i, name := range request.BusyStatuses {
+			status, err := entities.ParseAppointmentStatus(name)
+			if err != nil {
+				return entities.ConflictPolicy{}, domainerrors.AtField(fmt.Sprintf("busy_statuses[%d]", i), "", err)
+			}
+			policy.BusyStatuses = append(policy.BusyStatuses, status)
+		}
+	}
+
+	if len(request.BusyResponses) > 0 {
+		policy.BusyResponses = make([]entities.ResponseStatus, 0, len(request.BusyResponses))
+		for i, name := range request.BusyResponses {
+			response, err := entities.ParseResponseStatus(name)
+			if err != nil {
+				return entities.ConflictPolicy{}, domainerrors.AtField(fmt.Sprintf("busy_responses[%d]", i), "", err)
+			}
+			policy.BusyResponses = append(policy.BusyResponses, response)
+		}
+	}
+
+	if err := policy.Validate(); err != nil {
+		return entities.ConflictPolicy{}, err
+	}
+	return policy, nil
+}
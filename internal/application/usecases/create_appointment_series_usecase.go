@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+var (
+	// ErrAppointmentSeriesNotFound is returned when no series has the given ID.
+	ErrAppointmentSeriesNotFound = domainerrors.NotFound("appointment series not found")
+	// ErrOccurrenceNotFound is returned when a series has no occurrence at
+	// the given recurrence ID.
+	ErrOccurrenceNotFound = domainerrors.NotFound("occurrence not found")
+	// ErrSeriesOccurrence is returned when an occurrence of a series is
+	// edited as if it were a one-off appointment.
+	ErrSeriesOccurrence = domainerrors.Conflict("appointment is an occurrence of a series")
+)
+
+type AppointmentSeriesRepository interface {
+	Save(series *entities.AppointmentSeries) error
+	FindByID(id string) (*entities.AppointmentSeries, error)
+
+	// FindIDsToExtend returns the IDs of the series that aren't cancelled
+	// and are materialized only up to before until.
+	FindIDsToExtend(until time.Time) ([]string, error)
+}
+
+type CreateAppointmentSeriesUseCase struct {
+	unitOfWork           UnitOfWork
+	notificationGateway  NotificationGateway
+	conflictDetector     *services.ConflictDetectionService
+	recurrenceCalculator *services.RecurrenceCalculatorService
+	timezoneResolver     TimezoneResolver
+	horizon              time.Duration
+}
+
+// NewCreateAppointmentSeriesUseCase books the occurrences of new series up
+// to horizon ahead.
+func NewCreateAppointmentSeriesUseCase(
+	unitOfWork UnitOfWork,
+	notificationGateway NotificationGateway,
+	conflictDetector *services.ConflictDetectionService,
+	recurrenceCalculator *services.RecurrenceCalculatorService,
+	timezoneResolver TimezoneResolver,
+	horizon time.Duration,
+) *CreateAppointmentSeriesUseCase {
+	return &CreateAppointmentSeriesUseCase{
+		unitOfWork:           unitOfWork,
+		notificationGateway:  notificationGateway,
+		conflictDetector:     conflictDetector,
+		recurrenceCalculator: recurrenceCalculator,
+		timezoneResolver:     timezoneResolver,
+		horizon:              horizon,
+	}
+}
+
+func (uc *CreateAppointmentSeriesUseCase) Execute(request dto.CreateAppointmentSeriesRequest) (*entities.AppointmentSeries, []*entities.Appointment, error) {
+	timeRange, err := valueobjects.NewTimeRange(request.StartTime, request.EndTime)
+	if err != nil {
+		return nil, nil, domainerrors.AtField("end_time", "invalid time range", err)
+	}
+
+	location, err := uc.timezoneResolver.GetLocation(request.Timezone)
+	if err != nil {
+		return nil, nil, domainerrors.AtField("timezone", "", err)
+	}
+
+	rule, _, err := parseSeriesRule(request.RRule, location)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	localTime, err := valueobjects.NewLocalTimePolicy(request.DSTGapPolicy, request.DSTAmbiguityPolicy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The policy is fixed when the series is created, so changing the
+	// server's default doesn't move existing occurrences
+	localTime = localTime.Or(uc.recurrenceCalculator.LocalTimePolicy())
+	attendees, err := parseAttendees(request.Attendees)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	series, err := entities.NewAppointmentSeries(request.Title, timeRange, attendees, request.Location, rule, location, localTime)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create appointment series: %w", err)
+	}
+	extendSeriesHorizon(series, uc.horizon)
+
+	occurrences, err := materializeAppointmentSeries(uc.recurrenceCalculator, series)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to expand appointment series: %w", err)
+	}
+
+	// Save the series and book every occurrence as one unit
+	err = uc.unitOfWork.Do(func(repos TxRepositories) error {
+		if err := repos.Series.Save(series); err != nil {
+			return internalError("failed to save appointment series", err)
+		}
+
+		_, err := bookSeriesOccurrences(repos, uc.conflictDetector, series.AttendeeIDs(), nil, occurrences, time.Time{})
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Send notification
+	err = uc.notificationGateway.SendAppointmentSeriesCreated(series, occurrences)
+	if err != nil {
+		// Log error but don't fail the operation
+	}
+
+	return series, occurrences, nil
+}
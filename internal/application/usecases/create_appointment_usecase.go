@@ -1,9 +1,10 @@
 package usecases
 
 import (
-	"errors"
+	"fmt"
 
 	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
 	"github.com/visiab/appointment-calculator/internal/domain/entities"
 	"github.com/visiab/appointment-calculator/internal/domain/services"
 	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
@@ -15,6 +16,9 @@ type AppointmentRepository interface {
 	FindByParticipant(participantID string) ([]*entities.Appointment, error)
 	Update(appointment *entities.Appointment) error
 	Delete(id string) error
+	// Query returns one page of appointments matching query, plus the number
+	// of matches across all pages.
+	Query(query AppointmentQuery) ([]*entities.Appointment, int, error)
 }
 
 type ScheduleRepository interface {
@@ -24,28 +28,42 @@ type ScheduleRepository interface {
 
 type NotificationGateway interface {
 	SendAppointmentCreated(appointment *entities.Appointment) error
-	SendAppointmentUpdated(appointment *entities.Appointment) error
+	// SendAppointmentUpdated tells the attendees what changed about the
+	// appointment.
+	SendAppointmentUpdated(appointment *entities.Appointment, changes []AppointmentChange) error
 	SendAppointmentCancelled(appointment *entities.Appointment) error
+	// SendAppointmentSeriesCreated tells the attendees about a new series
+	// once, listing the occurrences booked so far.
+	SendAppointmentSeriesCreated(series *entities.AppointmentSeries, occurrences []*entities.Appointment) error
+	// SendAppointmentSeriesUpdated tells the attendees once what changed
+	// about a series, listing the occurrences the change booked, moved or
+	// cancelled.
+	SendAppointmentSeriesUpdated(series *entities.AppointmentSeries, changes []AppointmentChange, occurrences []*entities.Appointment) error
 }
 
 type CreateAppointmentUseCase struct {
-	appointmentRepo     AppointmentRepository
-	scheduleRepo        ScheduleRepository
+	unitOfWork          UnitOfWork
 	notificationGateway NotificationGateway
 	conflictDetector    *services.ConflictDetectionService
+	timeFinder          *services.OptimalTimeFinderService
+	alternatives        int
 }
 
+// NewCreateAppointmentUseCase suggests up to alternatives conflict-free times, found
+// with timeFinder, when the appointment conflicts with attendees' schedules.
 func NewCreateAppointmentUseCase(
-	appointmentRepo AppointmentRepository,
-	scheduleRepo ScheduleRepository,
+	unitOfWork UnitOfWork,
 	notificationGateway NotificationGateway,
 	conflictDetector *services.ConflictDetectionService,
+	timeFinder *services.OptimalTimeFinderService,
+	alternatives int,
 ) *CreateAppointmentUseCase {
 	return &CreateAppointmentUseCase{
-		appointmentRepo:     appointmentRepo,
-		scheduleRepo:        scheduleRepo,
+		unitOfWork:          unitOfWork,
 		notificationGateway: notificationGateway,
 		conflictDetector:    conflictDetector,
+		timeFinder:          timeFinder,
+		alternatives:        alternatives,
 	}
 }
 
@@ -53,52 +71,91 @@ func (uc *CreateAppointmentUseCase) Execute(request dto.CreateAppointmentRequest
 	// Validate time range
 	timeRange, err := valueobjects.NewTimeRange(request.StartTime, request.EndTime)
 	if err != nil {
-		return nil, errors.New("invalid time range: " + err.Error())
+		return nil, domainerrors.AtField("end_time", "invalid time range", err)
+	}
+
+	attendees, err := parseAttendees(request.Attendees)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create appointment entity
-	appointment, err := entities.NewAppointment(request.Title, timeRange, request.Attendees, request.Location)
+	newAppointment := entities.NewAppointment
+	if request.Tentative {
+		newAppointment = entities.NewTentativeAppointment
+	}
+	appointment, err := newAppointment(request.Title, timeRange, attendees, request.Location)
 	if err != nil {
-		return nil, errors.New("failed to create appointment: " + err.Error())
+		return nil, fmt.Errorf("failed to create appointment: %w", err)
 	}
 
-	// Check for conflicts with each attendee's schedule
-	for _, attendeeID := range request.Attendees {
-		schedule, err := uc.scheduleRepo.FindByOwnerID(attendeeID)
-		if err != nil {
-			continue // Skip if schedule not found (participant might not have a schedule yet)
+	if request.OrganizerID != "" {
+		if err := appointment.SetOrganizer(request.OrganizerID); err != nil {
+			return nil, domainerrors.AtField("organizer_id", "invalid organizer", err)
 		}
+	}
 
-		conflictResult := uc.conflictDetector.DetectConflicts(schedule, timeRange)
-		if conflictResult.HasConflict {
-			return nil, errors.New("appointment conflicts with existing schedule for participant " + attendeeID)
-		}
+	if err := appointment.Describe(request.Description); err != nil {
+		return nil, domainerrors.AtField("description", "", err)
+	}
+
+	if err := appointment.SetTags(request.Tags); err != nil {
+		return nil, domainerrors.AtField("tags", "", err)
 	}
 
-	// Save appointment
-	err = uc.appointmentRepo.Save(appointment)
+	metadata, err := parseMetadata(request.Metadata)
 	if err != nil {
-		return nil, errors.New("failed to save appointment: " + err.Error())
+		return nil, err
+	}
+	if err := appointment.SetMetadata(metadata); err != nil {
+		return nil, err
 	}
 
-	// Add appointment to participants' schedules
-	for _, attendeeID := range request.Attendees {
-		schedule, err := uc.scheduleRepo.FindByOwnerID(attendeeID)
-		if err != nil {
-			continue // Skip if schedule not found
+	// Save the appointment and book it on every attendee's schedule as one unit
+	err = uc.unitOfWork.Do(func(repos TxRepositories) error {
+		// Check for conflicts with each attendee's schedule
+		var conflicts conflictCollector
+		schedules := make([]*entities.Schedule, 0, len(attendees))
+		for _, attendeeID := range lockOrder(appointment.AttendeeIDs()) {
+			schedule, err := findSchedule(repos.Schedules, attendeeID)
+			if err != nil {
+				return err
+			}
+			if schedule == nil {
+				continue // The participant has no schedule yet
+			}
+
+			// Optional attendees are invited whether or not they are free
+			if schedule.Blocks(appointment) {
+				conflicts.add(attendeeID, timeRange, uc.conflictDetector.DetectConflicts(schedule, timeRange))
+			}
+			schedules = append(schedules, schedule)
 		}
 
-		err = schedule.AddAppointment(appointment)
-		if err != nil {
-			// Log error but don't fail the entire operation
-			continue
+		if err := conflicts.err(); err != nil {
+			return err
 		}
 
-		err = uc.scheduleRepo.Save(schedule)
-		if err != nil {
-			// Log error but don't fail the entire operation
-			continue
+		// Save appointment
+		if err := repos.Appointments.Save(appointment); err != nil {
+			return internalError("failed to save appointment", err)
 		}
+
+		// Add appointment to participants' schedules
+		for _, schedule := range schedules {
+			if err := schedule.AddAppointment(appointment); err != nil {
+				return fmt.Errorf("failed to add appointment to schedule of participant %s: %w", schedule.OwnerID(), err)
+			}
+
+			if err := repos.Schedules.Save(schedule); err != nil {
+				return internalError("failed to save schedule of participant "+schedule.OwnerID(), err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, suggestAlternatives(err, uc.unitOfWork, uc.timeFinder, appointment, uc.alternatives)
 	}
 
 	// Send notification
@@ -108,13 +165,18 @@ func (uc *CreateAppointmentUseCase) Execute(request dto.CreateAppointmentRequest
 	}
 
 	return &dto.CreateAppointmentResponse{
-		ID:        appointment.ID(),
-		Title:     appointment.Title(),
-		StartTime: appointment.TimeRange().StartTime(),
-		EndTime:   appointment.TimeRange().EndTime(),
-		Attendees: appointment.Attendees(),
-		Location:  appointment.Location(),
-		Status:    appointment.Status(),
-		CreatedAt: appointment.CreatedAt(),
+		ID:          appointment.ID(),
+		Title:       appointment.Title(),
+		Description: appointment.Description(),
+		StartTime:   appointment.TimeRange().StartTime(),
+		EndTime:     appointment.TimeRange().EndTime(),
+		Attendees:   attendeeResponses(appointment.Attendees()),
+		OrganizerID: appointment.OrganizerID(),
+		Location:    appointment.Location(),
+		Tags:        appointment.Tags(),
+		Metadata:    metadataResponse(appointment.Metadata()),
+		Status:      appointment.Status(),
+		CreatedAt:   appointment.CreatedAt(),
+		Version:     appointment.Version(),
 	}, nil
 }
@@ -0,0 +1,284 @@
+package usecases_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/repositories"
+)
+
+type noopNotificationGateway struct{}
+
+func (noopNotificationGateway) SendAppointmentCreated(*entities.Appointment) error { return nil }
+func (noopNotificationGateway) SendAppointmentUpdated(*entities.Appointment, []usecases.AppointmentChange) error {
+	return nil
+}
+func (noopNotificationGateway) SendAppointmentCancelled(*entities.Appointment) error { return nil }
+func (noopNotificationGateway) SendAppointmentSeriesCreated(*entities.AppointmentSeries, []*entities.Appointment) error {
+	return nil
+}
+func (noopNotificationGateway) SendAppointmentSeriesUpdated(*entities.AppointmentSeries, []usecases.AppointmentChange, []*entities.Appointment) error {
+	return nil
+}
+
+// slowUnitOfWork widens the gap between the conflict check and the booking
+// so that unserialized bookings reliably interleave.
+type slowUnitOfWork struct {
+	usecases.UnitOfWork
+}
+
+func (u slowUnitOfWork) Do(fn func(repos usecases.TxRepositories) error) error {
+	return u.UnitOfWork.Do(func(repos usecases.TxRepositories) error {
+		repos.Schedules = slowScheduleRepository{repos.Schedules}
+		return fn(repos)
+	})
+}
+
+type slowScheduleRepository struct {
+	usecases.ScheduleRepository
+}
+
+func (r slowScheduleRepository) Save(schedule *entities.Schedule) error {
+	time.Sleep(time.Millisecond)
+	return r.ScheduleRepository.Save(schedule)
+}
+
+func TestCreateAppointmentConcurrentBookingsForSameSlot(t *testing.T) {
+	appointmentRepo := repositories.NewMemoryAppointmentRepository()
+	scheduleRepo := repositories.NewMemoryScheduleRepository(appointmentRepo)
+	unitOfWork := repositories.NewMemoryUnitOfWork(appointmentRepo, scheduleRepo, repositories.NewMemoryAppointmentSeriesRepository())
+
+	// A Monday, inside the default 09:00-17:00 working hours
+	day := time.Date(2030, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	for _, ownerID := range []string{"alice", "bob"} {
+		schedule, err := entities.NewSchedule(ownerID, time.UTC, valueobjects.DefaultWorkingHours())
+		require.NoError(t, err)
+		require.NoError(t, scheduleRepo.Save(schedule))
+	}
+
+	useCase := usecases.NewCreateAppointmentUseCase(slowUnitOfWork{unitOfWork}, noopNotificationGateway{}, services.NewConflictDetectionService(nil), nil, 0)
+
+	const bookings = 20
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		conflicts int
+	)
+
+	start := make(chan struct{})
+	for i := 0; i < bookings; i++ {
+		// Alternate the attendee order so bookings reach the schedules in
+		// different orders
+		attendees := []dto.AttendeeRequest{{ParticipantID: "alice"}, {ParticipantID: "bob"}}
+		if i%2 == 1 {
+			attendees = []dto.AttendeeRequest{{ParticipantID: "bob"}, {ParticipantID: "alice"}}
+		}
+
+		wg.Add(1)
+		go func(attendees []dto.AttendeeRequest) {
+			defer wg.Done()
+			<-start
+
+			_, err := useCase.Execute(dto.CreateAppointmentRequest{
+				Title:     "Planning",
+				StartTime: day.Add(10 * time.Hour),
+				EndTime:   day.Add(11 * time.Hour),
+				Attendees: attendees,
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				succeeded++
+			} else if strings.Contains(err.Error(), "conflicts with existing schedule") {
+				conflicts++
+			} else {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(attendees)
+	}
+
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, bookings-1, conflicts)
+
+	for _, ownerID := range []string{"alice", "bob"} {
+		schedule, err := scheduleRepo.FindByOwnerID(ownerID)
+		require.NoError(t, err)
+		assert.Len(t, schedule.Appointments(), 1, "schedule of %s", ownerID)
+	}
+
+	all, err := appointmentRepo.FindAll()
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+// failingUnitOfWork fails every appointment write, as an unavailable
+// database would.
+type failingUnitOfWork struct {
+	usecases.UnitOfWork
+}
+
+func (u failingUnitOfWork) Do(fn func(repos usecases.TxRepositories) error) error {
+	return u.UnitOfWork.Do(func(repos usecases.TxRepositories) error {
+		repos.Appointments = failingAppointmentRepository{repos.Appointments}
+		return fn(repos)
+	})
+}
+
+type failingAppointmentRepository struct {
+	usecases.AppointmentRepository
+}
+
+func (failingAppointmentRepository) Save(*entities.Appointment) error {
+	return errors.New("connection refused")
+}
+
+func TestCreateAppointmentClassifiesErrors(t *testing.T) {
+	appointmentRepo := repositories.NewMemoryAppointmentRepository()
+	scheduleRepo := repositories.NewMemoryScheduleRepository(appointmentRepo)
+	unitOfWork := repositories.NewMemoryUnitOfWork(appointmentRepo, scheduleRepo, repositories.NewMemoryAppointmentSeriesRepository())
+
+	schedule, err := entities.NewSchedule("alice", time.UTC, valueobjects.DefaultWorkingHours())
+	require.NoError(t, err)
+	require.NoError(t, scheduleRepo.Save(schedule))
+
+	// A Monday, inside the default 09:00-17:00 working hours
+	day := time.Date(2030, 3, 4, 0, 0, 0, 0, time.UTC)
+	request := func(attendees ...dto.AttendeeRequest) dto.CreateAppointmentRequest {
+		return dto.CreateAppointmentRequest{
+			Title:     "Planning",
+			StartTime: day.Add(10 * time.Hour),
+			EndTime:   day.Add(11 * time.Hour),
+			Attendees: attendees,
+		}
+	}
+	detector := services.NewConflictDetectionService(nil)
+	useCase := usecases.NewCreateAppointmentUseCase(unitOfWork, noopNotificationGateway{}, detector, nil, 0)
+
+	t.Run("validation error names the field", func(t *testing.T) {
+		_, err := useCase.Execute(request(dto.AttendeeRequest{ParticipantID: "alice"}, dto.AttendeeRequest{ParticipantID: "bob", Role: "boss"}))
+
+		var invalid *domainerrors.Error
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, domainerrors.KindValidation, invalid.Kind)
+		assert.Equal(t, "attendees[1].role", invalid.Field)
+	})
+
+	t.Run("double booking is a conflict", func(t *testing.T) {
+		_, err := useCase.Execute(request(dto.AttendeeRequest{ParticipantID: "alice"}))
+		require.NoError(t, err)
+
+		_, err = useCase.Execute(request(dto.AttendeeRequest{ParticipantID: "alice"}))
+		assert.ErrorIs(t, err, usecases.ErrSchedulingConflict)
+		kind, _ := domainerrors.KindOf(err)
+		assert.Equal(t, domainerrors.KindConflict, kind)
+	})
+
+	t.Run("storage failure is internal", func(t *testing.T) {
+		failing := usecases.NewCreateAppointmentUseCase(failingUnitOfWork{unitOfWork}, noopNotificationGateway{}, detector, nil, 0)
+		_, err := failing.Execute(request(dto.AttendeeRequest{ParticipantID: "bob"}))
+
+		var internal *usecases.InternalError
+		assert.ErrorAs(t, err, &internal)
+		_, classified := domainerrors.KindOf(err)
+		assert.False(t, classified)
+	})
+}
+
+// unreachableSchedulesUnitOfWork fails every schedule lookup, as an
+// unavailable database would.
+type unreachableSchedulesUnitOfWork struct {
+	usecases.UnitOfWork
+}
+
+func (u unreachableSchedulesUnitOfWork) Do(fn func(repos usecases.TxRepositories) error) error {
+	return u.UnitOfWork.Do(func(repos usecases.TxRepositories) error {
+		repos.Schedules = unreachableScheduleRepository{repos.Schedules}
+		return fn(repos)
+	})
+}
+
+type unreachableScheduleRepository struct {
+	usecases.ScheduleRepository
+}
+
+func (unreachableScheduleRepository) FindByOwnerID(string) (*entities.Schedule, error) {
+	return nil, errors.New("connection refused")
+}
+
+// TestCreateAppointmentRollsBackWhenSchedulesAreUnavailable checks that an
+// appointment isn't stored unless it could be booked on the schedules.
+func TestCreateAppointmentRollsBackWhenSchedulesAreUnavailable(t *testing.T) {
+	appointmentRepo := repositories.NewMemoryAppointmentRepository()
+	scheduleRepo := repositories.NewMemoryScheduleRepository(appointmentRepo)
+	unitOfWork := repositories.NewMemoryUnitOfWork(appointmentRepo, scheduleRepo, repositories.NewMemoryAppointmentSeriesRepository())
+
+	useCase := usecases.NewCreateAppointmentUseCase(unreachableSchedulesUnitOfWork{unitOfWork}, noopNotificationGateway{}, services.NewConflictDetectionService(nil), nil, 0)
+	start := time.Date(2030, 3, 4, 10, 0, 0, 0, time.UTC)
+	_, err := useCase.Execute(dto.CreateAppointmentRequest{
+		Title:     "Planning",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Attendees: []dto.AttendeeRequest{{ParticipantID: "alice"}},
+	})
+
+	var internal *usecases.InternalError
+	require.ErrorAs(t, err, &internal)
+	all, err := appointmentRepo.FindAll()
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestCreateAppointmentRejectsInvalidDetails(t *testing.T) {
+	appointmentRepo := repositories.NewMemoryAppointmentRepository()
+	scheduleRepo := repositories.NewMemoryScheduleRepository(appointmentRepo)
+	unitOfWork := repositories.NewMemoryUnitOfWork(appointmentRepo, scheduleRepo, repositories.NewMemoryAppointmentSeriesRepository())
+	useCase := usecases.NewCreateAppointmentUseCase(unitOfWork, noopNotificationGateway{}, services.NewConflictDetectionService(nil), nil, 0)
+
+	start := time.Date(2030, 3, 4, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		tags     []string
+		metadata map[string]interface{}
+		field    string
+	}{
+		{name: "tag with a comma", tags: []string{"finance,q1"}, field: "tags"},
+		{name: "object value", metadata: map[string]interface{}{"ticket": map[string]interface{}{"id": 1}}, field: "metadata.ticket"},
+		{name: "value too long", metadata: map[string]interface{}{"notes": strings.Repeat("n", 1025)}, field: "metadata.notes"},
+		{name: "invalid key", metadata: map[string]interface{}{"ticket id": "OPS-1"}, field: "metadata"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.Execute(dto.CreateAppointmentRequest{
+				Title:     "Review",
+				StartTime: start,
+				EndTime:   start.Add(time.Hour),
+				Attendees: []dto.AttendeeRequest{{ParticipantID: "alice"}},
+				Tags:      tt.tags,
+				Metadata:  tt.metadata,
+			})
+			assertInvalidField(t, err, tt.field)
+		})
+	}
+
+	all, err := appointmentRepo.FindAll()
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+// ErrDuplicateEmail is returned when another participant already uses the
+// requested email address.
+var ErrDuplicateEmail = domainerrors.Conflict("a participant with this email already exists")
+
+type TimezoneResolver interface {
+	GetLocation(timezone string) (*time.Location, error)
+}
+
+type CreateParticipantUseCase struct {
+	participantRepo  ParticipantRepository
+	timezoneResolver TimezoneResolver
+	holidays         *services.HolidayCalendarService
+}
+
+func NewCreateParticipantUseCase(
+	participantRepo ParticipantRepository,
+	timezoneResolver TimezoneResolver,
+	holidays *services.HolidayCalendarService,
+) *CreateParticipantUseCase {
+	return &CreateParticipantUseCase{
+		participantRepo:  participantRepo,
+		timezoneResolver: timezoneResolver,
+		holidays:         holidays,
+	}
+}
+
+func (uc *CreateParticipantUseCase) Execute(request dto.CreateParticipantRequest) (*dto.ParticipantResponse, error) {
+	location, err := uc.timezoneResolver.GetLocation(request.Timezone)
+	if err != nil {
+		return nil, domainerrors.AtField("timezone", "", err)
+	}
+
+	if err := checkHolidayCalendars(uc.holidays, request.HolidayCalendars); err != nil {
+		return nil, domainerrors.AtField("holiday_calendars", "", err)
+	}
+
+	if existing, err := uc.participantRepo.FindByEmail(request.Email); err == nil && existing != nil {
+		return nil, ErrDuplicateEmail
+	} else if err != nil && !errors.Is(err, ErrParticipantNotFound) {
+		return nil, internalError("failed to look up email", err)
+	}
+
+	participant, err := entities.NewParticipant(request.Name, request.Email, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create participant: %w", err)
+	}
+	participant.SetHolidayCalendars(request.HolidayCalendars)
+
+	if err := uc.participantRepo.Save(participant); err != nil {
+		return nil, internalError("failed to save participant", err)
+	}
+
+	response := newParticipantResponse(participant)
+	return &response, nil
+}
+
+func newParticipantResponse(participant *entities.Participant) dto.ParticipantResponse {
+	return dto.ParticipantResponse{
+		ID:               participant.ID(),
+		Name:             participant.Name(),
+		Email:            participant.Email(),
+		Timezone:         participant.Timezone().String(),
+		HolidayCalendars: participant.HolidayCalendars(),
+	}
+}
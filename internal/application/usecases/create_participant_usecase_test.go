@@ -0,0 +1,136 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/repositories"
+	infraServices "github.com/visiab/appointment-calculator/internal/infrastructure/services"
+)
+
+func newTestHolidayCalendars(t *testing.T) *services.HolidayCalendarService {
+	t.Helper()
+
+	newYear, err := entities.NewFixedHolidayRule("New Year's Day", time.January, 1)
+	require.NoError(t, err)
+	calendar, err := entities.NewHolidayCalendar("US", "United States", []entities.HolidayRule{newYear})
+	require.NoError(t, err)
+	return services.NewHolidayCalendarService([]*entities.HolidayCalendar{calendar})
+}
+
+func assertInvalidField(t *testing.T, err error, field string) {
+	t.Helper()
+
+	require.Error(t, err)
+	var invalid *domainerrors.Error
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, domainerrors.KindValidation, invalid.Kind)
+	assert.Equal(t, field, invalid.Field)
+}
+
+func TestParticipantLifecycle(t *testing.T) {
+	repo := repositories.NewMemoryParticipantRepository()
+	timezones := infraServices.NewTimezoneService()
+	holidays := newTestHolidayCalendars(t)
+
+	create := usecases.NewCreateParticipantUseCase(repo, timezones, holidays)
+	get := usecases.NewGetParticipantUseCase(repo)
+	update := usecases.NewUpdateParticipantUseCase(repo, timezones, holidays)
+	remove := usecases.NewDeleteParticipantUseCase(repo)
+
+	alice, err := create.Execute(dto.CreateParticipantRequest{
+		Name:             "Alice",
+		Email:            "alice@example.com",
+		Timezone:         "Europe/Berlin",
+		HolidayCalendars: []string{"US"},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, alice.ID)
+	assert.Equal(t, "Europe/Berlin", alice.Timezone)
+	assert.Equal(t, []string{"US"}, alice.HolidayCalendars)
+
+	bob, err := create.Execute(dto.CreateParticipantRequest{Name: "Bob", Email: "bob@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", bob.Timezone, "the timezone defaults to UTC")
+
+	got, err := get.Execute(alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alice, got)
+
+	// Emails are unique on create and update
+	_, err = create.Execute(dto.CreateParticipantRequest{Name: "Alice again", Email: "alice@example.com"})
+	assert.ErrorIs(t, err, usecases.ErrDuplicateEmail)
+
+	email := "alice@example.com"
+	_, err = update.Execute(bob.ID, dto.UpdateParticipantRequest{Email: &email})
+	assert.ErrorIs(t, err, usecases.ErrDuplicateEmail)
+
+	// Keeping one's own email isn't a duplicate
+	name := "Alice Smith"
+	updated, err := update.Execute(alice.ID, dto.UpdateParticipantRequest{Name: &name, Email: &email})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Smith", updated.Name)
+
+	timezone := "America/New_York"
+	calendars := []string{}
+	updated, err = update.Execute(alice.ID, dto.UpdateParticipantRequest{Timezone: &timezone, HolidayCalendars: &calendars})
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", updated.Timezone)
+	assert.Empty(t, updated.HolidayCalendars)
+	assert.Equal(t, "Alice Smith", updated.Name, "fields left out are kept")
+
+	require.NoError(t, remove.Execute(alice.ID))
+	_, err = get.Execute(alice.ID)
+	assert.ErrorIs(t, err, usecases.ErrParticipantNotFound)
+	assert.ErrorIs(t, remove.Execute(alice.ID), usecases.ErrParticipantNotFound)
+	_, err = update.Execute(alice.ID, dto.UpdateParticipantRequest{Name: &name})
+	assert.ErrorIs(t, err, usecases.ErrParticipantNotFound)
+
+	// Alice's email is free again
+	_, err = create.Execute(dto.CreateParticipantRequest{Name: "Alice", Email: "alice@example.com"})
+	assert.NoError(t, err)
+}
+
+func TestParticipantValidation(t *testing.T) {
+	repo := repositories.NewMemoryParticipantRepository()
+	timezones := infraServices.NewTimezoneService()
+	holidays := newTestHolidayCalendars(t)
+
+	create := usecases.NewCreateParticipantUseCase(repo, timezones, holidays)
+	update := usecases.NewUpdateParticipantUseCase(repo, timezones, holidays)
+
+	_, err := create.Execute(dto.CreateParticipantRequest{Name: "Alice", Email: "alice@example.com", Timezone: "Mars/Olympus_Mons"})
+	assertInvalidField(t, err, "timezone")
+
+	_, err = create.Execute(dto.CreateParticipantRequest{Name: "Alice", Email: "alice@example.com", HolidayCalendars: []string{"XX"}})
+	assertInvalidField(t, err, "holiday_calendars")
+
+	alice, err := create.Execute(dto.CreateParticipantRequest{Name: "Alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+
+	timezone := "Nowhere/Special"
+	_, err = update.Execute(alice.ID, dto.UpdateParticipantRequest{Timezone: &timezone})
+	assertInvalidField(t, err, "timezone")
+
+	empty := ""
+	_, err = update.Execute(alice.ID, dto.UpdateParticipantRequest{Name: &empty})
+	assertInvalidField(t, err, "name")
+
+	calendars := []string{"US", "XX"}
+	_, err = update.Execute(alice.ID, dto.UpdateParticipantRequest{HolidayCalendars: &calendars})
+	assertInvalidField(t, err, "holiday_calendars")
+
+	// Nothing was changed by the failed updates
+	got, err := usecases.NewGetParticipantUseCase(repo).Execute(alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", got.Name)
+	assert.Equal(t, "UTC", got.Timezone)
+	assert.Empty(t, got.HolidayCalendars)
+}
@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+var (
+	// ErrScheduleExists is returned when the owner already has a schedule.
+	ErrScheduleExists = domainerrors.Conflict("schedule already exists for owner")
+	// ErrScheduleNotFound is returned when the owner has no schedule.
+	ErrScheduleNotFound = domainerrors.NotFound("schedule not found")
+	// ErrWorkingHoursOverrideNotFound is returned when removing an override
+	// for a date that has none.
+	ErrWorkingHoursOverrideNotFound = domainerrors.NotFound("no working hours override for date")
+)
+
+type CreateScheduleUseCase struct {
+	unitOfWork       UnitOfWork
+	timezoneResolver TimezoneResolver
+	holidays         *services.HolidayCalendarService
+}
+
+func NewCreateScheduleUseCase(unitOfWork UnitOfWork, timezoneResolver TimezoneResolver, holidays *services.HolidayCalendarService) *CreateScheduleUseCase {
+	return &CreateScheduleUseCase{
+		unitOfWork:       unitOfWork,
+		timezoneResolver: timezoneResolver,
+		holidays:         holidays,
+	}
+}
+
+func (uc *CreateScheduleUseCase) Execute(request dto.CreateScheduleRequest) (*entities.Schedule, error) {
+	location, err := uc.timezoneResolver.GetLocation(request.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	workingHours := valueobjects.DefaultWorkingHours()
+	if request.WorkingHours != nil {
+		workingHours, err = parseWorkingHours(*request.WorkingHours)
+		if err != nil {
+			return nil, domainerrors.AtField("working_hours", "invalid working hours", err)
+		}
+	}
+
+	if err := checkHolidayCalendars(uc.holidays, request.HolidayCalendars); err != nil {
+		return nil, domainerrors.AtField("holiday_calendars", "", err)
+	}
+
+	policy := entities.DefaultConflictPolicy()
+	if request.ConflictPolicy != nil {
+		policy, err = parseConflictPolicy(*request.ConflictPolicy)
+		if err != nil {
+			return nil, domainerrors.AtField("conflict_policy", "invalid conflict policy", err)
+		}
+	}
+
+	schedule, err := entities.NewSchedule(request.OwnerID, location, workingHours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schedule: %w", err)
+	}
+	schedule.SetHolidayCalendars(request.HolidayCalendars)
+	if err := schedule.SetConflictPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	err = uc.unitOfWork.Do(func(repos TxRepositories) error {
+		if _, err := repos.Schedules.FindByOwnerID(request.OwnerID); err == nil {
+			return ErrScheduleExists
+		} else if !errors.Is(err, ErrScheduleNotFound) {
+			return internalError("failed to look up schedule of "+request.OwnerID, err)
+		}
+		if err := repos.Schedules.Save(schedule); err != nil {
+			return internalError("failed to save schedule", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,170 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/repositories"
+	infraServices "github.com/visiab/appointment-calculator/internal/infrastructure/services"
+)
+
+type scheduleFixture struct {
+	appointments *repositories.MemoryAppointmentRepository
+	schedules    *repositories.MemoryScheduleRepository
+	unitOfWork   usecases.UnitOfWork
+	create       *usecases.CreateScheduleUseCase
+}
+
+func newScheduleFixture(t *testing.T) scheduleFixture {
+	t.Helper()
+
+	f := scheduleFixture{appointments: repositories.NewMemoryAppointmentRepository()}
+	f.schedules = repositories.NewMemoryScheduleRepository(f.appointments)
+	f.unitOfWork = repositories.NewMemoryUnitOfWork(f.appointments, f.schedules, repositories.NewMemoryAppointmentSeriesRepository())
+	f.create = usecases.NewCreateScheduleUseCase(f.unitOfWork, infraServices.NewTimezoneService(), newTestHolidayCalendars(t))
+	return f
+}
+
+func TestCreateSchedule(t *testing.T) {
+	f := newScheduleFixture(t)
+
+	schedule, err := f.create.Execute(dto.CreateScheduleRequest{
+		OwnerID:          "alice",
+		Timezone:         "Europe/Berlin",
+		HolidayCalendars: []string{"US"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", schedule.OwnerID())
+	assert.Equal(t, "Europe/Berlin", schedule.Timezone().String())
+	assert.Equal(t, []string{"US"}, schedule.HolidayCalendars())
+	assert.Equal(t, valueobjects.DefaultWorkingHours().Weekly(), schedule.WorkingHours().Weekly())
+
+	stored, err := usecases.NewGetScheduleUseCase(f.schedules).Execute("alice", dto.ScheduleWindowRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, schedule.ID(), stored.ID())
+
+	_, err = f.create.Execute(dto.CreateScheduleRequest{OwnerID: "alice"})
+	assert.ErrorIs(t, err, usecases.ErrScheduleExists)
+
+	_, err = usecases.NewGetScheduleUseCase(f.schedules).Execute("bob", dto.ScheduleWindowRequest{})
+	assert.ErrorIs(t, err, usecases.ErrScheduleNotFound)
+}
+
+func TestCreateScheduleRejectsInvalidRequests(t *testing.T) {
+	f := newScheduleFixture(t)
+
+	tests := []struct {
+		name    string
+		request dto.CreateScheduleRequest
+		field   string
+	}{
+		{
+			name:    "unknown holiday calendar",
+			request: dto.CreateScheduleRequest{OwnerID: "alice", HolidayCalendars: []string{"XX"}},
+			field:   "holiday_calendars",
+		},
+		{
+			name: "unknown weekday",
+			request: dto.CreateScheduleRequest{OwnerID: "alice", WorkingHours: &dto.WorkingHoursDTO{
+				Weekly: map[string][]dto.WorkingIntervalDTO{"someday": {{Start: "09:00", End: "17:00"}}},
+			}},
+			field: "working_hours.weekly.someday",
+		},
+		{
+			name: "negative buffer",
+			request: dto.CreateScheduleRequest{OwnerID: "alice", ConflictPolicy: &dto.ConflictPolicyDTO{
+				PreBufferMinutes: -5,
+			}},
+			field: "conflict_policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.create.Execute(tt.request)
+			assertInvalidField(t, err, tt.field)
+		})
+	}
+
+	_, err := f.schedules.FindByOwnerID("alice")
+	assert.ErrorIs(t, err, usecases.ErrScheduleNotFound, "nothing was saved")
+}
+
+func TestScheduleWindowAndBlockedTimes(t *testing.T) {
+	f := newScheduleFixture(t)
+	_, err := f.create.Execute(dto.CreateScheduleRequest{OwnerID: "alice", Timezone: "UTC"})
+	require.NoError(t, err)
+
+	day := time.Date(2030, 3, 4, 9, 0, 0, 0, time.UTC)
+	schedule, err := f.schedules.FindByOwnerID("alice")
+	require.NoError(t, err)
+	for i, title := range []string{"Monday", "Tuesday", "Wednesday"} {
+		appointment := saveAppointment(t, f.appointments, title, "alice", day.AddDate(0, 0, i))
+		require.NoError(t, schedule.AddAppointment(appointment))
+	}
+	require.NoError(t, f.schedules.Save(schedule))
+
+	add := usecases.NewAddBlockedTimeUseCase(f.unitOfWork)
+	list := usecases.NewListBlockedTimesUseCase(f.schedules)
+	remove := usecases.NewRemoveBlockedTimeUseCase(f.unitOfWork)
+
+	lunch, err := add.Execute("alice", dto.AddBlockedTimeRequest{
+		StartTime: day.Add(3 * time.Hour),
+		EndTime:   day.Add(4 * time.Hour),
+		Reason:    "Lunch",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Lunch", lunch.Reason())
+
+	dentist, err := add.Execute("alice", dto.AddBlockedTimeRequest{
+		StartTime: day.AddDate(0, 0, 2),
+		EndTime:   day.AddDate(0, 0, 2).Add(time.Hour),
+		Reason:    "Dentist",
+	})
+	require.NoError(t, err)
+
+	t.Run("window", func(t *testing.T) {
+		// From Monday noon to Wednesday morning
+		window := dto.ScheduleWindowRequest{StartDate: day.Add(3 * time.Hour), EndDate: day.AddDate(0, 0, 2)}
+		schedule, err := usecases.NewGetScheduleUseCase(f.schedules).Execute("alice", window)
+		require.NoError(t, err)
+
+		require.Len(t, schedule.Appointments(), 1)
+		assert.Equal(t, "Tuesday", schedule.Appointments()[0].Title())
+		require.Len(t, schedule.BlockedTimes(), 1)
+		assert.Equal(t, lunch.ID(), schedule.BlockedTimes()[0].ID())
+
+		// An open end reaches the last blocked time
+		blocked, err := list.Execute("alice", dto.ScheduleWindowRequest{StartDate: day.AddDate(0, 0, 1)})
+		require.NoError(t, err)
+		require.Len(t, blocked, 1)
+		assert.Equal(t, "Dentist", blocked[0].Reason())
+
+		_, err = list.Execute("alice", dto.ScheduleWindowRequest{StartDate: day, EndDate: day})
+		assertInvalidField(t, err, "end_date")
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		require.NoError(t, remove.Execute("alice", dentist.ID()))
+		assert.ErrorIs(t, remove.Execute("alice", dentist.ID()), usecases.ErrBlockedTimeNotFound)
+
+		blocked, err := list.Execute("alice", dto.ScheduleWindowRequest{})
+		require.NoError(t, err)
+		require.Len(t, blocked, 1)
+		assert.Equal(t, lunch.ID(), blocked[0].ID())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := add.Execute("alice", dto.AddBlockedTimeRequest{StartTime: day, EndTime: day.Add(-time.Hour)})
+		assertInvalidField(t, err, "end_time")
+
+		_, err = add.Execute("bob", dto.AddBlockedTimeRequest{StartTime: day, EndTime: day.Add(time.Hour)})
+		assert.ErrorIs(t, err, usecases.ErrScheduleNotFound)
+		assert.ErrorIs(t, remove.Execute("bob", lunch.ID()), usecases.ErrScheduleNotFound)
+	})
+}
@@ -0,0 +1,22 @@
+package usecases
+
+type DeleteParticipantUseCase struct {
+	participantRepo ParticipantRepository
+}
+
+func NewDeleteParticipantUseCase(participantRepo ParticipantRepository) *DeleteParticipantUseCase {
+	return &DeleteParticipantUseCase{
+		participantRepo: participantRepo,
+	}
+}
+
+func (uc *DeleteParticipantUseCase) Execute(participantID string) error {
+	if _, err := uc.participantRepo.FindByID(participantID); err != nil {
+		return lookupError(err, ErrParticipantNotFound, participantID)
+	}
+
+	if err := uc.participantRepo.Delete(participantID); err != nil {
+		return internalError("failed to delete participant", err)
+	}
+	return nil
+}
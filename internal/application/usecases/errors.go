@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+)
+
+// InternalError is a failure the caller can't do anything about, such as the
+// storage being unavailable.
+type InternalError struct {
+	Message string
+	Err     error
+}
+
+func (e *InternalError) Error() string {
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *InternalError) Unwrap() error {
+	return e.Err
+}
+
+// internalError wraps a failure of a repository or another dependency. A
+// failure that is already classified, like a lost concurrent write, keeps its
+// kind.
+func internalError(message string, err error) error {
+	if _, ok := domainerrors.KindOf(err); ok {
+		return fmt.Errorf("%s: %w", message, err)
+	}
+	return &InternalError{Message: message, Err: err}
+}
+
+// lookupError classifies a failed repository lookup of id: notFound, naming
+// id, when there is no such entity, and an internal error otherwise, so an
+// unavailable storage isn't reported as a missing entity.
+func lookupError(err, notFound error, id string) error {
+	if errors.Is(err, notFound) {
+		return fmt.Errorf("%w: %s", notFound, id)
+	}
+	return internalError("failed to look up "+id, err)
+}
+
+// preconditionError reports a write lost to a concurrent one as a failed
+// precondition when the caller named the version they expected: the check
+// passed, but the version they saw is no longer current.
+func preconditionError(err error, expectedVersion *int) error {
+	if expectedVersion != nil && errors.Is(err, ErrVersionConflict) {
+		return fmt.Errorf("%w: %v", ErrPreconditionFailed, err)
+	}
+	return err
+}
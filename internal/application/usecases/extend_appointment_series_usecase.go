@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+// ExtendAppointmentSeriesUseCase moves the horizon of every series forward,
+// booking the occurrences that come into it. Series are only materialized
+// up to their horizon when they are created or edited, so this runs
+// periodically to keep them booked ahead.
+type ExtendAppointmentSeriesUseCase struct {
+	unitOfWork           UnitOfWork
+	notificationGateway  NotificationGateway
+	conflictDetector     *services.ConflictDetectionService
+	recurrenceCalculator *services.RecurrenceCalculatorService
+	horizon              time.Duration
+}
+
+func NewExtendAppointmentSeriesUseCase(
+	unitOfWork UnitOfWork,
+	notificationGateway NotificationGateway,
+	conflictDetector *services.ConflictDetectionService,
+	recurrenceCalculator *services.RecurrenceCalculatorService,
+	horizon time.Duration,
+) *ExtendAppointmentSeriesUseCase {
+	return &ExtendAppointmentSeriesUseCase{
+		unitOfWork:           unitOfWork,
+		notificationGateway:  notificationGateway,
+		conflictDetector:     conflictDetector,
+		recurrenceCalculator: recurrenceCalculator,
+		horizon:              horizon,
+	}
+}
+
+// Execute extends each series whose horizon is behind in its own unit of
+// work, so a series whose new occurrences conflict is left as it was without
+// holding up the others. It returns how many occurrences were booked, and
+// the errors of the series it couldn't extend.
+func (uc *ExtendAppointmentSeriesUseCase) Execute() (int, error) {
+	var ids []string
+	err := uc.unitOfWork.Do(func(repos TxRepositories) error {
+		var err error
+		ids, err = repos.Series.FindIDsToExtend(time.Now().Add(uc.horizon))
+		if err != nil {
+			return internalError("failed to look up appointment series to extend", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	booked := 0
+	var failures []error
+	for _, id := range ids {
+		series, horizon, created, err := uc.extend(id)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("appointment series %s: %w", id, err))
+			continue
+		}
+		if len(created) == 0 {
+			continue
+		}
+		booked += len(created)
+
+		// Send notification
+		changes := []AppointmentChange{{Field: "horizon", From: horizon.Format(time.RFC3339), To: series.Horizon().Format(time.RFC3339)}}
+		err = uc.notificationGateway.SendAppointmentSeriesUpdated(series, changes, created)
+		if err != nil {
+			// Log error but don't fail the operation
+		}
+	}
+
+	return booked, errors.Join(failures...)
+}
+
+// extend books the occurrences of the series up to its new horizon. It
+// returns the series, its previous horizon and the occurrences it created.
+func (uc *ExtendAppointmentSeriesUseCase) extend(seriesID string) (*entities.AppointmentSeries, time.Time, []*entities.Appointment, error) {
+	var (
+		series  *entities.AppointmentSeries
+		horizon time.Time
+		created []*entities.Appointment
+	)
+	err := uc.unitOfWork.Do(func(repos TxRepositories) error {
+		var err error
+		series, err = repos.Series.FindByID(seriesID)
+		if err != nil {
+			return lookupError(err, ErrAppointmentSeriesNotFound, seriesID)
+		}
+
+		// Cancelled since it was listed
+		if series.Status() == entities.StatusCancelled {
+			return nil
+		}
+
+		previous, err := loadSeriesOccurrences(repos.Appointments, uc.recurrenceCalculator, series)
+		if err != nil {
+			return err
+		}
+
+		horizon = series.Horizon()
+		extendSeriesHorizon(series, uc.horizon)
+		desired, err := materializeAppointmentSeries(uc.recurrenceCalculator, series)
+		if err != nil {
+			return fmt.Errorf("failed to expand appointment series: %w", err)
+		}
+
+		changes, err := bookSeriesOccurrences(repos, uc.conflictDetector, series.AttendeeIDs(), previous, desired, time.Now())
+		if err != nil {
+			return err
+		}
+		created = changes.created
+
+		if err := repos.Series.Save(series); err != nil {
+			return internalError("failed to save appointment series", err)
+		}
+		return nil
+	})
+	return series, horizon, created, err
+}
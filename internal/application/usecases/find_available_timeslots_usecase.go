@@ -1,10 +1,12 @@
 package usecases
 
 import (
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
 	"github.com/visiab/appointment-calculator/internal/domain/services"
 	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 )
@@ -14,47 +16,72 @@ type ParticipantRepository interface {
 	FindByIDs(ids []string) ([]*entities.Participant, error)
 	Save(participant *entities.Participant) error
 	FindByEmail(email string) (*entities.Participant, error)
+	Update(participant *entities.Participant) error
+	Delete(id string) error
 }
 
 type FindAvailableTimeSlotsUseCase struct {
 	participantRepo      ParticipantRepository
 	optimalTimeFinder    *services.OptimalTimeFinderService
+	recurrenceCalculator *services.RecurrenceCalculatorService
 }
 
 func NewFindAvailableTimeSlotsUseCase(
 	participantRepo ParticipantRepository,
 	optimalTimeFinder *services.OptimalTimeFinderService,
+	recurrenceCalculator *services.RecurrenceCalculatorService,
 ) *FindAvailableTimeSlotsUseCase {
 	return &FindAvailableTimeSlotsUseCase{
-		participantRepo:   participantRepo,
-		optimalTimeFinder: optimalTimeFinder,
+		participantRepo:      participantRepo,
+		optimalTimeFinder:    optimalTimeFinder,
+		recurrenceCalculator: recurrenceCalculator,
 	}
 }
 
 func (uc *FindAvailableTimeSlotsUseCase) Execute(query dto.AvailabilityQuery) (*dto.AvailabilityResult, error) {
 	// Validate input
 	if query.StartDate.After(query.EndDate) {
-		return nil, errors.New("start date cannot be after end date")
+		return nil, domainerrors.InvalidField("end_date", "start date cannot be after end date")
 	}
 
 	if query.Duration <= 0 {
-		return nil, errors.New("duration must be positive")
+		return nil, domainerrors.InvalidField("duration_minutes", "duration must be positive")
 	}
 
 	// Get participants
 	participants, err := uc.participantRepo.FindByIDs(query.ParticipantIDs)
 	if err != nil {
-		return nil, errors.New("failed to find participants: " + err.Error())
+		return nil, internalError("failed to find participants", err)
 	}
 
 	if len(participants) == 0 {
-		return nil, errors.New("no participants found")
+		return nil, domainerrors.NotFound("no participants found")
+	}
+
+	// Participants listed as both required and optional are required
+	required := make(map[string]bool, len(query.ParticipantIDs))
+	for _, id := range query.ParticipantIDs {
+		required[id] = true
+	}
+	optionalIDs := make([]string, 0, len(query.OptionalParticipantIDs))
+	for _, id := range query.OptionalParticipantIDs {
+		if !required[id] {
+			optionalIDs = append(optionalIDs, id)
+		}
+	}
+
+	optionalParticipants := make([]*entities.Participant, 0)
+	if len(optionalIDs) > 0 {
+		optionalParticipants, err = uc.participantRepo.FindByIDs(optionalIDs)
+		if err != nil {
+			return nil, internalError("failed to find optional participants", err)
+		}
 	}
 
 	// Create duration value object
 	duration, err := valueobjects.NewDuration(time.Duration(query.Duration) * time.Minute)
 	if err != nil {
-		return nil, errors.New("invalid duration: " + err.Error())
+		return nil, domainerrors.AtField("duration_minutes", "invalid duration", err)
 	}
 
 	// Parse timezone
@@ -70,14 +97,25 @@ func (uc *FindAvailableTimeSlotsUseCase) Execute(query dto.AvailabilityQuery) (*
 	startTime := query.StartDate.In(timezone)
 	endTime := query.EndDate.In(timezone)
 
+	// Expand recurring availability for the searched period
+	everyone := append(append([]*entities.Participant(nil), participants...), optionalParticipants...)
+	if window, err := valueobjects.NewTimeRange(startTime, endTime); err == nil {
+		for _, participant := range everyone {
+			if err := materializeAvailability(uc.recurrenceCalculator, participant, window); err != nil {
+				return nil, fmt.Errorf("failed to expand recurring availability: %w", err)
+			}
+		}
+	}
+
 	// Create request for optimal time finder
 	request := services.FindOptimalTimeRequest{
-		Participants:     participants,
-		Duration:         duration,
-		EarliestStart:    startTime,
-		LatestEnd:        endTime,
-		TimeSlotInterval: 15 * time.Minute, // 15-minute intervals
-		MaxOptions:       50,               // Limit to 50 options
+		Participants:         participants,
+		OptionalParticipants: optionalParticipants,
+		Duration:             duration,
+		EarliestStart:        startTime,
+		LatestEnd:            endTime,
+		TimeSlotInterval:     15 * time.Minute, // 15-minute intervals
+		MaxOptions:           50,               // Limit to 50 options
 	}
 
 	// Find optimal times
@@ -91,9 +129,10 @@ func (uc *FindAvailableTimeSlotsUseCase) Execute(query dto.AvailabilityQuery) (*
 			EndTime:              option.TimeRange.EndTime(),
 			Score:                option.Score,
 			AvailableParticipants: len(option.Participants),
-			TotalParticipants:    len(participants),
+			TotalParticipants:    len(participants) + len(optionalParticipants),
 			Reason:               option.Reason,
 			Conflicts:            option.Conflicts,
+			OptionalConflicts:    option.OptionalConflicts,
 		}
 	}
 
@@ -114,7 +153,7 @@ func (uc *FindAvailableTimeSlotsUseCase) Execute(query dto.AvailabilityQuery) (*
 
 	// Set summary
 	result.Summary.TotalSlotsFound = len(availableSlots)
-	result.Summary.Participants = len(participants)
+	result.Summary.Participants = len(participants) + len(optionalParticipants)
 
 	return result, nil
 }
@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+type GetAppointmentSeriesUseCase struct {
+	seriesRepo           AppointmentSeriesRepository
+	appointmentRepo      AppointmentRepository
+	recurrenceCalculator *services.RecurrenceCalculatorService
+}
+
+func NewGetAppointmentSeriesUseCase(
+	seriesRepo AppointmentSeriesRepository,
+	appointmentRepo AppointmentRepository,
+	recurrenceCalculator *services.RecurrenceCalculatorService,
+) *GetAppointmentSeriesUseCase {
+	return &GetAppointmentSeriesUseCase{
+		seriesRepo:           seriesRepo,
+		appointmentRepo:      appointmentRepo,
+		recurrenceCalculator: recurrenceCalculator,
+	}
+}
+
+// Execute returns the series with its materialized occurrences.
+func (uc *GetAppointmentSeriesUseCase) Execute(seriesID string) (*entities.AppointmentSeries, []*entities.Appointment, error) {
+	series, err := uc.seriesRepo.FindByID(seriesID)
+	if err != nil {
+		return nil, nil, lookupError(err, ErrAppointmentSeriesNotFound, seriesID)
+	}
+
+	occurrences, err := loadSeriesOccurrences(uc.appointmentRepo, uc.recurrenceCalculator, series)
+	if err != nil {
+		return nil, nil, err
+	}
+	return series, occurrences, nil
+}
@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+type GetAppointmentUseCase struct {
+	appointmentRepo AppointmentRepository
+}
+
+func NewGetAppointmentUseCase(appointmentRepo AppointmentRepository) *GetAppointmentUseCase {
+	return &GetAppointmentUseCase{
+		appointmentRepo: appointmentRepo,
+	}
+}
+
+func (uc *GetAppointmentUseCase) Execute(appointmentID string) (*entities.Appointment, error) {
+	appointment, err := uc.appointmentRepo.FindByID(appointmentID)
+	if err != nil {
+		return nil, lookupError(err, ErrAppointmentNotFound, appointmentID)
+	}
+	return appointment, nil
+}
@@ -0,0 +1,42 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/repositories"
+)
+
+// unavailableAppointmentRepository fails every lookup, as an unreachable
+// database would.
+type unavailableAppointmentRepository struct {
+	usecases.AppointmentRepository
+}
+
+func (unavailableAppointmentRepository) FindByID(string) (*entities.Appointment, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestGetAppointmentTellsMissingFromUnavailable(t *testing.T) {
+	t.Run("missing appointment is not found", func(t *testing.T) {
+		useCase := usecases.NewGetAppointmentUseCase(repositories.NewMemoryAppointmentRepository())
+		_, err := useCase.Execute("missing")
+
+		assert.ErrorIs(t, err, usecases.ErrAppointmentNotFound)
+		kind, _ := domainerrors.KindOf(err)
+		assert.Equal(t, domainerrors.KindNotFound, kind)
+	})
+
+	t.Run("unavailable storage is internal", func(t *testing.T) {
+		useCase := usecases.NewGetAppointmentUseCase(unavailableAppointmentRepository{})
+		_, err := useCase.Execute("any")
+
+		assert.NotErrorIs(t, err, usecases.ErrAppointmentNotFound)
+		var internal *usecases.InternalError
+		assert.ErrorAs(t, err, &internal)
+	})
+}
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+type GetAvailabilityUseCase struct {
+	participantRepo      ParticipantRepository
+	recurrenceCalculator *services.RecurrenceCalculatorService
+}
+
+func NewGetAvailabilityUseCase(
+	participantRepo ParticipantRepository,
+	recurrenceCalculator *services.RecurrenceCalculatorService,
+) *GetAvailabilityUseCase {
+	return &GetAvailabilityUseCase{
+		participantRepo:      participantRepo,
+		recurrenceCalculator: recurrenceCalculator,
+	}
+}
+
+// Execute lists the participant's availability overlapping the requested
+// window, with recurring series expanded into their occurrences.
+func (uc *GetAvailabilityUseCase) Execute(participantID string, request dto.GetAvailabilityRequest) (*dto.AvailabilityListResponse, error) {
+	window, err := valueobjects.NewTimeRange(request.StartDate, request.EndDate)
+	if err != nil {
+		return nil, domainerrors.AtField("end_date", "invalid date range", err)
+	}
+
+	if window.Duration() > maxAvailabilityWindow {
+		return nil, domainerrors.InvalidField("end_date", "date range cannot exceed 366 days")
+	}
+
+	participant, err := uc.participantRepo.FindByID(participantID)
+	if err != nil {
+		return nil, lookupError(err, ErrParticipantNotFound, participantID)
+	}
+
+	availability := make([]dto.AvailabilityResponse, 0)
+	for _, slot := range participant.Availability() {
+		if slot.IsAvailable() && slot.TimeRange().OverlapsWith(window) {
+			availability = append(availability, newSlotAvailabilityResponse(slot))
+		}
+	}
+
+	for _, series := range participant.AvailabilitySeries() {
+		occurrences, err := seriesOccurrences(uc.recurrenceCalculator, series, participant.Timezone(), window)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand recurring availability: %w", err)
+		}
+
+		for _, occurrence := range occurrences {
+			availability = append(availability, newSeriesAvailabilityResponse(series, series.OccurrenceID(occurrence.StartTime()), occurrence))
+		}
+	}
+
+	sort.SliceStable(availability, func(i, j int) bool {
+		return availability[i].StartTime.Before(availability[j].StartTime)
+	})
+
+	return &dto.AvailabilityListResponse{
+		ParticipantID: participant.ID(),
+		StartDate:     request.StartDate,
+		EndDate:       request.EndDate,
+		Availability:  availability,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+)
+
+// ErrParticipantNotFound is returned when no participant has the requested ID.
+var ErrParticipantNotFound = domainerrors.NotFound("participant not found")
+
+type GetParticipantUseCase struct {
+	participantRepo ParticipantRepository
+}
+
+func NewGetParticipantUseCase(participantRepo ParticipantRepository) *GetParticipantUseCase {
+	return &GetParticipantUseCase{
+		participantRepo: participantRepo,
+	}
+}
+
+func (uc *GetParticipantUseCase) Execute(participantID string) (*dto.ParticipantResponse, error) {
+	participant, err := uc.participantRepo.FindByID(participantID)
+	if err != nil {
+		return nil, lookupError(err, ErrParticipantNotFound, participantID)
+	}
+
+	response := newParticipantResponse(participant)
+	return &response, nil
+}
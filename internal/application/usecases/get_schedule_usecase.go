@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+type GetScheduleUseCase struct {
+	scheduleRepo ScheduleRepository
+}
+
+func NewGetScheduleUseCase(scheduleRepo ScheduleRepository) *GetScheduleUseCase {
+	return &GetScheduleUseCase{
+		scheduleRepo: scheduleRepo,
+	}
+}
+
+// Execute returns the owner's schedule holding only the appointments and
+// blocked times that overlap the requested window.
+func (uc *GetScheduleUseCase) Execute(ownerID string, request dto.ScheduleWindowRequest) (*entities.Schedule, error) {
+	if !request.StartDate.IsZero() && !request.EndDate.IsZero() && !request.StartDate.Before(request.EndDate) {
+		return nil, domainerrors.InvalidField("end_date", "start date must be before end date")
+	}
+
+	schedule, err := uc.scheduleRepo.FindByOwnerID(ownerID)
+	if err != nil {
+		return nil, lookupError(err, ErrScheduleNotFound, ownerID)
+	}
+
+	if request.StartDate.IsZero() && request.EndDate.IsZero() {
+		return schedule, nil
+	}
+
+	appointments := make([]*entities.Appointment, 0)
+	for _, appointment := range schedule.Appointments() {
+		if inWindow(appointment.TimeRange(), request) {
+			appointments = append(appointments, appointment)
+		}
+	}
+
+	return entities.RestoreSchedule(
+		schedule.ID(),
+		schedule.OwnerID(),
+		schedule.Timezone(),
+		schedule.WorkingHours(),
+		schedule.HolidayCalendars(),
+		appointments,
+		blockedTimesInWindow(schedule.BlockedTimes(), request),
+		schedule.ConflictPolicy(),
+		schedule.Version(),
+	), nil
+}
+
+func blockedTimesInWindow(blockedTimes []valueobjects.BlockedTime, window dto.ScheduleWindowRequest) []valueobjects.BlockedTime {
+	result := make([]valueobjects.BlockedTime, 0, len(blockedTimes))
+	for _, blocked := range blockedTimes {
+		if inWindow(blocked.TimeRange(), window) {
+			result = append(result, blocked)
+		}
+	}
+	return result
+}
+
+// inWindow reports whether timeRange overlaps the window; a zero bound leaves
+// that side open.
+func inWindow(timeRange valueobjects.TimeRange, window dto.ScheduleWindowRequest) bool {
+	if !window.StartDate.IsZero() && !timeRange.EndTime().After(window.StartDate) {
+		return false
+	}
+	if !window.EndDate.IsZero() && !timeRange.StartTime().Before(window.EndDate) {
+		return false
+	}
+	return true
+}
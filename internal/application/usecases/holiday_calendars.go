@@ -0,0 +1,18 @@
+package usecases
+
+import (
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+// checkHolidayCalendars rejects subscriptions to regions without a loaded
+// calendar. Naming a missing calendar is invalid input, not a missing
+// resource.
+func checkHolidayCalendars(holidays *services.HolidayCalendarService, regions []string) error {
+	for _, region := range regions {
+		if _, exists := holidays.Calendar(region); !exists {
+			return domainerrors.Invalid("unknown holiday calendar: " + region)
+		}
+	}
+	return nil
+}
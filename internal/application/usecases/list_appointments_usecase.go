@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+const (
+	defaultListLimit = 10
+	maxListLimit     = 100
+)
+
+type ListAppointmentsResult struct {
+	Appointments []*entities.Appointment
+	Total        int
+	Page         int
+	Limit        int
+	NextCursor   string
+}
+
+type ListAppointmentsUseCase struct {
+	appointmentRepo AppointmentRepository
+}
+
+func NewListAppointmentsUseCase(appointmentRepo AppointmentRepository) *ListAppointmentsUseCase {
+	return &ListAppointmentsUseCase{
+		appointmentRepo: appointmentRepo,
+	}
+}
+
+func (uc *ListAppointmentsUseCase) Execute(request dto.ListAppointmentsRequest) (*ListAppointmentsResult, error) {
+	query, page, err := uc.buildQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra appointment to know whether another page follows
+	limit := query.Limit
+	query.Limit = limit + 1
+
+	appointments, total, err := uc.appointmentRepo.Query(query)
+	if err != nil {
+		return nil, internalError("failed to list appointments", err)
+	}
+
+	result := &ListAppointmentsResult{
+		Appointments: appointments,
+		Total:        total,
+		Page:         page,
+		Limit:        limit,
+	}
+
+	if len(appointments) > limit {
+		result.Appointments = appointments[:limit]
+		last := result.Appointments[limit-1]
+		result.NextCursor = encodeCursor(listCursor{
+			Key:        query.SortBy.Key(last),
+			ID:         last.ID(),
+			SortBy:     query.SortBy,
+			Descending: query.Descending,
+		})
+	}
+
+	return result, nil
+}
+
+func (uc *ListAppointmentsUseCase) buildQuery(request dto.ListAppointmentsRequest) (AppointmentQuery, int, error) {
+	query := AppointmentQuery{
+		ParticipantID: request.ParticipantID,
+		From:          request.From,
+		To:            request.To,
+		Location:      request.Location,
+		TitleSearch:   request.Search,
+		SortBy:        SortByStartTime,
+		Limit:         request.Limit,
+	}
+
+	if query.Limit == 0 {
+		query.Limit = defaultListLimit
+	}
+	if query.Limit < 0 || query.Limit > maxListLimit {
+		return query, 0, domainerrors.InvalidField("limit", "limit must be between 1 and 100")
+	}
+
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return query, 0, domainerrors.InvalidField("to", "from must be before to")
+	}
+
+	// Statuses may be repeated or comma separated
+	for _, value := range request.Status {
+		for _, status := range strings.Split(value, ",") {
+			status = strings.TrimSpace(status)
+			if status == "" {
+				continue
+			}
+			parsed, err := entities.ParseAppointmentStatus(status)
+			if err != nil {
+				return query, 0, domainerrors.InvalidField("status", "unknown status: "+status)
+			}
+			query.Statuses = append(query.Statuses, parsed)
+		}
+	}
+
+	// Tags and metadata keys may be repeated or comma separated too; tags
+	// match however they are capitalized
+	if tags := splitListValues(request.Tags); len(tags) > 0 {
+		normalized, err := entities.NormalizeTags(tags)
+		if err != nil {
+			return query, 0, domainerrors.AtField("tag", "", err)
+		}
+		query.Tags = normalized
+	}
+	query.MetadataKeys = splitListValues(request.MetadataKeys)
+
+	if request.Sort != "" {
+		field := strings.TrimPrefix(request.Sort, "-")
+		query.Descending = strings.HasPrefix(request.Sort, "-")
+		query.SortBy = AppointmentSortField(field)
+		if !query.SortBy.IsValid() {
+			return query, 0, domainerrors.InvalidField("sort", "unsupported sort field: "+field)
+		}
+	}
+
+	// A cursor continues from the previous page; page numbers only apply
+	// without one
+	page := request.Page
+	if request.Cursor != "" {
+		cursor, err := decodeCursor(request.Cursor)
+		if err != nil {
+			return query, 0, err
+		}
+		if cursor.SortBy != query.SortBy || cursor.Descending != query.Descending {
+			return query, 0, domainerrors.InvalidField("cursor", "cursor was issued for a different sort order")
+		}
+
+		query.After = &AppointmentCursor{Key: cursor.Key, ID: cursor.ID}
+		page = 0
+	} else {
+		if page == 0 {
+			page = 1
+		}
+		if page < 0 {
+			return query, 0, domainerrors.InvalidField("page", "page must be positive")
+		}
+		query.Offset = (page - 1) * query.Limit
+	}
+
+	return query, page, nil
+}
+
+// splitListValues splits comma separated query values, dropping empty ones.
+func splitListValues(values []string) []string {
+	result := make([]string, 0)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+// listCursor is what the opaque cursor string handed to clients encodes.
+type listCursor struct {
+	Key        string               `json:"k"`
+	ID         string               `json:"id"`
+	SortBy     AppointmentSortField `json:"s"`
+	Descending bool                 `json:"d,omitempty"`
+}
+
+func encodeCursor(cursor listCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (listCursor, error) {
+	var cursor listCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, domainerrors.InvalidField("cursor", "invalid cursor")
+	}
+
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return cursor, domainerrors.InvalidField("cursor", "invalid cursor")
+	}
+	return cursor, nil
+}
@@ -0,0 +1,219 @@
+package usecases_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/repositories"
+)
+
+// saveAppointment stores a one-hour appointment for participantID starting
+// at start.
+func saveAppointment(t *testing.T, repo usecases.AppointmentRepository, title, participantID string, start time.Time) *entities.Appointment {
+	t.Helper()
+
+	attendee, err := entities.NewAttendee(participantID, entities.RoleRequired)
+	require.NoError(t, err)
+	timeRange, err := valueobjects.NewTimeRange(start, start.Add(time.Hour))
+	require.NoError(t, err)
+	appointment, err := entities.NewAppointment(title, timeRange, []entities.Attendee{attendee}, "")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(appointment))
+	return appointment
+}
+
+// listAll follows the cursors from the first page and returns the titles in
+// the order they were listed.
+func listAll(t *testing.T, useCase *usecases.ListAppointmentsUseCase, request dto.ListAppointmentsRequest) []string {
+	t.Helper()
+
+	titles := make([]string, 0)
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 20, "cursors don't end")
+
+		result, err := useCase.Execute(request)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(result.Appointments), result.Limit)
+		for _, appointment := range result.Appointments {
+			titles = append(titles, appointment.Title())
+		}
+
+		if result.NextCursor == "" {
+			return titles
+		}
+		request.Cursor = result.NextCursor
+	}
+}
+
+func TestListAppointmentsCursorPagination(t *testing.T) {
+	repo := repositories.NewMemoryAppointmentRepository()
+	day := time.Date(2030, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	// Pairs share a start time, so pages break between equal sort keys
+	want := make([]string, 0)
+	for i := 0; i < 7; i++ {
+		title := fmt.Sprintf("Meeting %d", i)
+		saveAppointment(t, repo, title, "alice", day.Add(time.Duration(i/2)*time.Hour))
+		want = append(want, title)
+	}
+
+	useCase := usecases.NewListAppointmentsUseCase(repo)
+
+	t.Run("by start time", func(t *testing.T) {
+		got := listAll(t, useCase, dto.ListAppointmentsRequest{Limit: 2})
+		require.Len(t, got, len(want))
+		for i := 0; i < len(want); i += 2 {
+			// Appointments starting together may come in either order
+			end := i + 2
+			if end > len(want) {
+				end = len(want)
+			}
+			assert.ElementsMatch(t, want[i:end], got[i:end])
+		}
+	})
+
+	t.Run("by title descending", func(t *testing.T) {
+		got := listAll(t, useCase, dto.ListAppointmentsRequest{Limit: 3, Sort: "-title"})
+		reversed := make([]string, 0, len(want))
+		for i := len(want) - 1; i >= 0; i-- {
+			reversed = append(reversed, want[i])
+		}
+		assert.Equal(t, reversed, got)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		saveAppointment(t, repo, "Lunch", "bob", day)
+		got := listAll(t, useCase, dto.ListAppointmentsRequest{Limit: 1, ParticipantID: "bob"})
+		assert.Equal(t, []string{"Lunch"}, got)
+
+		got = listAll(t, useCase, dto.ListAppointmentsRequest{Limit: 2, Search: "meeting 1", Sort: "title"})
+		assert.Equal(t, []string{"Meeting 1"}, got)
+
+		got = listAll(t, useCase, dto.ListAppointmentsRequest{
+			Limit:         2,
+			ParticipantID: "alice",
+			From:          day.Add(90 * time.Minute),
+			To:            day.Add(3 * time.Hour),
+			Sort:          "title",
+		})
+		assert.Equal(t, []string{"Meeting 2", "Meeting 3", "Meeting 4", "Meeting 5"}, got)
+	})
+
+	t.Run("pages don't shift when earlier appointments are added", func(t *testing.T) {
+		request := dto.ListAppointmentsRequest{Limit: 3, ParticipantID: "alice", Sort: "title"}
+		first, err := useCase.Execute(request)
+		require.NoError(t, err)
+		require.NotEmpty(t, first.NextCursor)
+
+		saveAppointment(t, repo, "Meeting 0a", "alice", day)
+
+		request.Cursor = first.NextCursor
+		second, err := useCase.Execute(request)
+		require.NoError(t, err)
+		require.Len(t, second.Appointments, 3)
+		assert.Equal(t, "Meeting 3", second.Appointments[0].Title())
+		assert.Equal(t, 0, second.Page)
+	})
+
+	t.Run("page numbers", func(t *testing.T) {
+		result, err := useCase.Execute(dto.ListAppointmentsRequest{Page: 2, Limit: 3, ParticipantID: "alice", Sort: "title"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Page)
+		assert.Equal(t, 8, result.Total)
+		require.Len(t, result.Appointments, 3)
+		assert.Equal(t, "Meeting 2", result.Appointments[0].Title())
+	})
+}
+
+func TestListAppointmentsRejectsInvalidRequests(t *testing.T) {
+	repo := repositories.NewMemoryAppointmentRepository()
+	day := time.Date(2030, 3, 4, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		saveAppointment(t, repo, fmt.Sprintf("Meeting %d", i), "alice", day)
+	}
+
+	useCase := usecases.NewListAppointmentsUseCase(repo)
+	first, err := useCase.Execute(dto.ListAppointmentsRequest{Limit: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, first.NextCursor)
+
+	tests := []struct {
+		name    string
+		request dto.ListAppointmentsRequest
+		field   string
+	}{
+		{name: "cursor from another sort order", request: dto.ListAppointmentsRequest{Cursor: first.NextCursor, Sort: "title"}, field: "cursor"},
+		{name: "cursor from the other direction", request: dto.ListAppointmentsRequest{Cursor: first.NextCursor, Sort: "-start_time"}, field: "cursor"},
+		{name: "cursor that isn't base64", request: dto.ListAppointmentsRequest{Cursor: "not a cursor!"}, field: "cursor"},
+		{name: "cursor without an ID", request: dto.ListAppointmentsRequest{Cursor: "e30"}, field: "cursor"},
+		{name: "limit too large", request: dto.ListAppointmentsRequest{Limit: 101}, field: "limit"},
+		{name: "negative page", request: dto.ListAppointmentsRequest{Page: -1}, field: "page"},
+		{name: "unknown sort field", request: dto.ListAppointmentsRequest{Sort: "location"}, field: "sort"},
+		{name: "unknown status", request: dto.ListAppointmentsRequest{Status: []string{"confirmed,done"}}, field: "status"},
+		{name: "empty time window", request: dto.ListAppointmentsRequest{From: day, To: day}, field: "to"},
+		{name: "tag too long", request: dto.ListAppointmentsRequest{Tags: []string{strings.Repeat("t", 51)}}, field: "tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.Execute(tt.request)
+			require.Error(t, err)
+
+			kind, _ := domainerrors.KindOf(err)
+			assert.Equal(t, domainerrors.KindValidation, kind)
+
+			var fieldErr *domainerrors.Error
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tt.field, fieldErr.Field)
+		})
+	}
+}
+
+func TestListAppointmentsByTagsAndMetadata(t *testing.T) {
+	repo := repositories.NewMemoryAppointmentRepository()
+	day := time.Date(2030, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	ticket, err := valueobjects.NewStringMetadata("OPS-1")
+	require.NoError(t, err)
+	tagged := func(title string, tags []string, metadata valueobjects.Metadata) {
+		appointment := saveAppointment(t, repo, title, "alice", day)
+		require.NoError(t, appointment.SetTags(tags))
+		require.NoError(t, appointment.SetMetadata(metadata))
+		require.NoError(t, repo.Update(appointment))
+	}
+	tagged("Budget", []string{"finance", "q1"}, valueobjects.Metadata{"ticket": ticket})
+	tagged("Forecast", []string{"finance"}, valueobjects.Metadata{"ticket": ticket, "priority": valueobjects.NewNumberMetadata(1)})
+	tagged("Standup", []string{"team"}, valueobjects.Metadata{})
+
+	useCase := usecases.NewListAppointmentsUseCase(repo)
+	tests := []struct {
+		name    string
+		request dto.ListAppointmentsRequest
+		want    []string
+	}{
+		{name: "one tag", request: dto.ListAppointmentsRequest{Tags: []string{"finance"}}, want: []string{"Budget", "Forecast"}},
+		{name: "every tag", request: dto.ListAppointmentsRequest{Tags: []string{"finance", "q1"}}, want: []string{"Budget"}},
+		{name: "comma separated tags", request: dto.ListAppointmentsRequest{Tags: []string{"finance,q1"}}, want: []string{"Budget"}},
+		{name: "tag in other case", request: dto.ListAppointmentsRequest{Tags: []string{" Team "}}, want: []string{"Standup"}},
+		{name: "unused tag", request: dto.ListAppointmentsRequest{Tags: []string{"sales"}}, want: []string{}},
+		{name: "metadata key", request: dto.ListAppointmentsRequest{MetadataKeys: []string{"ticket"}}, want: []string{"Budget", "Forecast"}},
+		{name: "every metadata key", request: dto.ListAppointmentsRequest{MetadataKeys: []string{"ticket,priority"}}, want: []string{"Forecast"}},
+		{name: "tag and metadata key", request: dto.ListAppointmentsRequest{Tags: []string{"q1"}, MetadataKeys: []string{"priority"}}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.Sort = "title"
+			assert.Equal(t, tt.want, listAll(t, useCase, tt.request))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+type ListBlockedTimesUseCase struct {
+	scheduleRepo ScheduleRepository
+}
+
+func NewListBlockedTimesUseCase(scheduleRepo ScheduleRepository) *ListBlockedTimesUseCase {
+	return &ListBlockedTimesUseCase{
+		scheduleRepo: scheduleRepo,
+	}
+}
+
+func (uc *ListBlockedTimesUseCase) Execute(ownerID string, request dto.ScheduleWindowRequest) ([]valueobjects.BlockedTime, error) {
+	if !request.StartDate.IsZero() && !request.EndDate.IsZero() && !request.StartDate.Before(request.EndDate) {
+		return nil, domainerrors.InvalidField("end_date", "start date must be before end date")
+	}
+
+	schedule, err := uc.scheduleRepo.FindByOwnerID(ownerID)
+	if err != nil {
+		return nil, lookupError(err, ErrScheduleNotFound, ownerID)
+	}
+
+	return blockedTimesInWindow(schedule.BlockedTimes(), request), nil
+}
@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+// ErrHolidayCalendarNotFound is returned for region codes without a loaded
+// holiday calendar.
+var ErrHolidayCalendarNotFound = domainerrors.NotFound("holiday calendar not found")
+
+type ListHolidayCalendarsUseCase struct {
+	holidays *services.HolidayCalendarService
+}
+
+func NewListHolidayCalendarsUseCase(holidays *services.HolidayCalendarService) *ListHolidayCalendarsUseCase {
+	return &ListHolidayCalendarsUseCase{
+		holidays: holidays,
+	}
+}
+
+func (uc *ListHolidayCalendarsUseCase) Execute() *dto.HolidayCalendarListResponse {
+	calendars := uc.holidays.Calendars()
+
+	response := &dto.HolidayCalendarListResponse{
+		Calendars: make([]dto.HolidayCalendarResponse, 0, len(calendars)),
+	}
+	for _, calendar := range calendars {
+		response.Calendars = append(response.Calendars, dto.HolidayCalendarResponse{
+			Region: calendar.Region(),
+			Name:   calendar.Name(),
+		})
+	}
+	return response
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+type ListHolidaysUseCase struct {
+	holidays *services.HolidayCalendarService
+}
+
+func NewListHolidaysUseCase(holidays *services.HolidayCalendarService) *ListHolidaysUseCase {
+	return &ListHolidaysUseCase{
+		holidays: holidays,
+	}
+}
+
+// Execute lists a calendar's holidays in the requested year, defaulting to
+// the current one.
+func (uc *ListHolidaysUseCase) Execute(region string, request dto.ListHolidaysRequest) (*dto.HolidayListResponse, error) {
+	calendar, exists := uc.holidays.Calendar(region)
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrHolidayCalendarNotFound, region)
+	}
+
+	year := request.Year
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	holidays := calendar.HolidaysIn(year)
+	response := &dto.HolidayListResponse{
+		Region:   calendar.Region(),
+		Name:     calendar.Name(),
+		Year:     year,
+		Holidays: make([]dto.HolidayResponse, 0, len(holidays)),
+	}
+	for _, holiday := range holidays {
+		response.Holidays = append(response.Holidays, dto.HolidayResponse{
+			Date: holiday.Date(),
+			Name: holiday.Name(),
+		})
+	}
+	return response, nil
+}
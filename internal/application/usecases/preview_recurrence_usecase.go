@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+const (
+	defaultPreviewLimit = 100
+	maxPreviewLimit     = 1000
+)
+
+// PreviewRecurrenceUseCase expands a recurrence rule and checks every
+// occurrence against the attendees' schedules, without booking anything.
+type PreviewRecurrenceUseCase struct {
+	scheduleRepo         ScheduleRepository
+	conflictDetector     *services.ConflictDetectionService
+	recurrenceCalculator *services.RecurrenceCalculatorService
+	timezoneResolver     TimezoneResolver
+}
+
+func NewPreviewRecurrenceUseCase(
+	scheduleRepo ScheduleRepository,
+	conflictDetector *services.ConflictDetectionService,
+	recurrenceCalculator *services.RecurrenceCalculatorService,
+	timezoneResolver TimezoneResolver,
+) *PreviewRecurrenceUseCase {
+	return &PreviewRecurrenceUseCase{
+		scheduleRepo:         scheduleRepo,
+		conflictDetector:     conflictDetector,
+		recurrenceCalculator: recurrenceCalculator,
+		timezoneResolver:     timezoneResolver,
+	}
+}
+
+func (uc *PreviewRecurrenceUseCase) Execute(request dto.PreviewRecurrenceRequest) (*dto.RecurrencePreviewResponse, error) {
+	location, err := uc.timezoneResolver.GetLocation(request.Timezone)
+	if err != nil {
+		return nil, domainerrors.AtField("timezone", "", err)
+	}
+
+	// Expand in the timezone so occurrences keep their wall-clock time
+	first, err := valueobjects.NewTimeRange(request.StartTime.In(location), request.EndTime.In(location))
+	if err != nil {
+		return nil, domainerrors.AtField("end_time", "invalid time range", err)
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultPreviewLimit
+	}
+	if limit < 0 || limit > maxPreviewLimit {
+		return nil, domainerrors.InvalidField("limit", "limit must be between 1 and "+strconv.Itoa(maxPreviewLimit))
+	}
+
+	rule, err := uc.previewRule(request, first.StartTime(), location)
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := services.FormatRRule(rule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+
+	localTime, err := valueobjects.NewLocalTimePolicy(request.DSTGapPolicy, request.DSTAmbiguityPolicy)
+	if err != nil {
+		return nil, err
+	}
+	rule.Timezone = location
+	rule.LocalTimePolicy = localTime.Or(uc.recurrenceCalculator.LocalTimePolicy())
+
+	// Stop at the limit when the rule's own count doesn't stop it first
+	truncated := false
+	if rule.MaxCount == 0 || rule.MaxCount > limit {
+		rule.MaxCount = limit
+		truncated = true
+	}
+
+	expanded, err := uc.recurrenceCalculator.CalculateRecurrences(first, rule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+
+	// Attendees without a schedule have nothing to conflict with
+	schedules := make([]*entities.Schedule, 0, len(request.Attendees))
+	for _, attendeeID := range request.Attendees {
+		schedule, err := findSchedule(uc.scheduleRepo, attendeeID)
+		if err != nil {
+			return nil, err
+		}
+		if schedule != nil {
+			schedules = append(schedules, schedule)
+		}
+	}
+
+	response := &dto.RecurrencePreviewResponse{
+		RRule:       formatted,
+		Timezone:    location.String(),
+		Occurrences: make([]dto.RecurrenceOccurrenceResponse, 0, len(expanded.TimeRanges)),
+		Count:       expanded.Count,
+		NextDate:    expanded.NextDate,
+		Truncated:   truncated && expanded.NextDate != nil,
+	}
+
+	for _, timeRange := range expanded.TimeRanges {
+		occurrence := dto.RecurrenceOccurrenceResponse{
+			StartTime: timeRange.StartTime(),
+			EndTime:   timeRange.EndTime(),
+			Conflicts: make([]dto.ParticipantConflictResponse, 0),
+		}
+
+		for _, schedule := range schedules {
+			result := uc.conflictDetector.DetectConflicts(schedule, timeRange)
+			if !result.HasConflict {
+				continue
+			}
+			occurrence.Conflicts = append(occurrence.Conflicts, participantConflictResponse(schedule.OwnerID(), timeRange, result))
+		}
+
+		if len(occurrence.Conflicts) > 0 {
+			occurrence.HasConflict = true
+			response.ConflictCount++
+		}
+		response.Occurrences = append(response.Occurrences, occurrence)
+	}
+
+	return response, nil
+}
+
+// previewRule reads the request's rule, which is given either as an RRULE or
+// field by field. Fields left out of a built-in rule are taken from start.
+func (uc *PreviewRecurrenceUseCase) previewRule(request dto.PreviewRecurrenceRequest, start time.Time, location *time.Location) (services.RecurrenceRule, error) {
+	switch {
+	case request.Rule != nil && request.RRule != "":
+		return services.RecurrenceRule{}, domainerrors.InvalidField("rrule", "give either rule or rrule, not both")
+	case request.RRule != "":
+		_, rule, err := parseSeriesRule(request.RRule, location)
+		return rule, err
+	case request.Rule == nil:
+		return services.RecurrenceRule{}, domainerrors.InvalidField("rule", "a rule or rrule is required")
+	}
+
+	pattern, err := parseAvailabilityPattern(request.Rule.Pattern)
+	if err != nil {
+		return services.RecurrenceRule{}, domainerrors.AtField("rule.pattern", "", err)
+	}
+
+	rule := services.RecurrenceRule{
+		Pattern:    pattern,
+		Interval:   request.Rule.Interval,
+		DayOfMonth: request.Rule.DayOfMonth,
+		EndDate:    request.Rule.EndDate,
+		MaxCount:   request.Rule.MaxCount,
+	}
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+
+	for _, name := range request.Rule.DaysOfWeek {
+		weekday, err := valueobjects.ParseWeekday(name)
+		if err != nil {
+			return services.RecurrenceRule{}, domainerrors.AtField("rule.days_of_week", "", err)
+		}
+		rule.DaysOfWeek = append(rule.DaysOfWeek, weekday)
+	}
+
+	if request.Rule.WeekStart != "" {
+		weekStart, err := valueobjects.ParseWeekday(request.Rule.WeekStart)
+		if err != nil {
+			return services.RecurrenceRule{}, domainerrors.AtField("rule.week_start", "", err)
+		}
+		rule.WeekStart = &weekStart
+	}
+
+	switch {
+	case pattern == services.PatternWeekly && len(rule.DaysOfWeek) == 0:
+		rule.DaysOfWeek = []time.Weekday{start.Weekday()}
+	case pattern == services.PatternMonthly && rule.DayOfMonth == 0:
+		rule.DayOfMonth = start.Day()
+	}
+
+	if err := uc.recurrenceCalculator.ValidateRecurrenceRule(rule); err != nil {
+		return services.RecurrenceRule{}, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+	return rule, nil
+}
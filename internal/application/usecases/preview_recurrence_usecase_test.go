@@ -0,0 +1,193 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/repositories"
+	infraServices "github.com/visiab/appointment-calculator/internal/infrastructure/services"
+)
+
+// newPreviewUseCase gives alice a UTC schedule with a review on Monday
+// 2030-03-11 at 10:30; bob has no schedule.
+func newPreviewUseCase(t *testing.T, localTime valueobjects.LocalTimePolicy) *usecases.PreviewRecurrenceUseCase {
+	t.Helper()
+
+	appointments := repositories.NewMemoryAppointmentRepository()
+	schedules := repositories.NewMemoryScheduleRepository(appointments)
+
+	schedule, err := entities.NewSchedule("alice", time.UTC, valueobjects.DefaultWorkingHours())
+	require.NoError(t, err)
+	review := saveAppointment(t, appointments, "Review", "alice", time.Date(2030, 3, 11, 10, 30, 0, 0, time.UTC))
+	require.NoError(t, schedule.AddAppointment(review))
+	require.NoError(t, schedules.Save(schedule))
+
+	return usecases.NewPreviewRecurrenceUseCase(
+		schedules,
+		services.NewConflictDetectionService(nil),
+		services.NewRecurrenceCalculatorService(localTime),
+		infraServices.NewTimezoneService(),
+	)
+}
+
+func previewStarts(response *dto.RecurrencePreviewResponse) []time.Time {
+	starts := make([]time.Time, len(response.Occurrences))
+	for i, occurrence := range response.Occurrences {
+		starts[i] = occurrence.StartTime.UTC()
+	}
+	return starts
+}
+
+func TestPreviewRecurrence(t *testing.T) {
+	preview := newPreviewUseCase(t, valueobjects.DefaultLocalTimePolicy())
+	monday := time.Date(2030, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	t.Run("conflicts", func(t *testing.T) {
+		response, err := preview.Execute(dto.PreviewRecurrenceRequest{
+			StartTime: monday,
+			EndTime:   monday.Add(time.Hour),
+			Rule:      &dto.RecurrenceRuleDTO{Pattern: "weekly", MaxCount: 3},
+			Attendees: []string{"alice", "bob"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "FREQ=WEEKLY;COUNT=3;BYDAY=MO", response.RRule)
+		assert.Equal(t, "UTC", response.Timezone)
+		assert.Equal(t, []time.Time{monday, monday.AddDate(0, 0, 7), monday.AddDate(0, 0, 14)}, previewStarts(response))
+		assert.Equal(t, 3, response.Count)
+		assert.False(t, response.Truncated)
+		require.NotNil(t, response.NextDate)
+		assert.True(t, monday.AddDate(0, 0, 21).Equal(*response.NextDate))
+
+		assert.Equal(t, 1, response.ConflictCount)
+		assert.False(t, response.Occurrences[0].HasConflict)
+		assert.Empty(t, response.Occurrences[0].Conflicts)
+
+		conflicted := response.Occurrences[1]
+		require.True(t, conflicted.HasConflict)
+		require.Len(t, conflicted.Conflicts, 1)
+		assert.Equal(t, "alice", conflicted.Conflicts[0].ParticipantID)
+		require.Len(t, conflicted.Conflicts[0].Conflicts, 1)
+		assert.Equal(t, string(services.ConflictTypeAppointment), conflicted.Conflicts[0].Conflicts[0].Type)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		response, err := preview.Execute(dto.PreviewRecurrenceRequest{
+			StartTime: monday,
+			EndTime:   monday.Add(time.Hour),
+			RRule:     "RRULE:FREQ=DAILY",
+			Limit:     3,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "FREQ=DAILY", response.RRule)
+		assert.Len(t, response.Occurrences, 3)
+		assert.True(t, response.Truncated)
+		require.NotNil(t, response.NextDate)
+		assert.True(t, monday.AddDate(0, 0, 3).Equal(*response.NextDate))
+
+		// A count within the limit isn't truncated, though it leaves a next date
+		response, err = preview.Execute(dto.PreviewRecurrenceRequest{
+			StartTime: monday,
+			EndTime:   monday.Add(time.Hour),
+			RRule:     "FREQ=DAILY;COUNT=2",
+			Limit:     3,
+		})
+		require.NoError(t, err)
+		assert.Len(t, response.Occurrences, 2)
+		assert.False(t, response.Truncated)
+	})
+
+	t.Run("timezone", func(t *testing.T) {
+		// 09:00 in New York stays 09:00 across the DST change on 2030-03-10
+		response, err := preview.Execute(dto.PreviewRecurrenceRequest{
+			StartTime: time.Date(2030, 3, 9, 14, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2030, 3, 9, 15, 0, 0, 0, time.UTC),
+			RRule:     "FREQ=DAILY;COUNT=2",
+			Timezone:  "America/New_York",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "America/New_York", response.Timezone)
+		assert.Equal(t, []time.Time{
+			time.Date(2030, 3, 9, 14, 0, 0, 0, time.UTC),
+			time.Date(2030, 3, 10, 13, 0, 0, 0, time.UTC),
+		}, previewStarts(response))
+	})
+}
+
+// TestPreviewRecurrenceLocalTimePolicy expands 02:30 in New York, which
+// doesn't exist on 2030-03-10, under the configured policy and a policy given
+// in the request.
+func TestPreviewRecurrenceLocalTimePolicy(t *testing.T) {
+	preview := newPreviewUseCase(t, valueobjects.LocalTimePolicy{Gap: valueobjects.GapSkip})
+	request := dto.PreviewRecurrenceRequest{
+		StartTime: time.Date(2030, 3, 9, 7, 30, 0, 0, time.UTC),
+		EndTime:   time.Date(2030, 3, 9, 8, 0, 0, 0, time.UTC),
+		RRule:     "FREQ=DAILY;COUNT=3",
+		Timezone:  "America/New_York",
+	}
+
+	response, err := preview.Execute(request)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2030, 3, 9, 7, 30, 0, 0, time.UTC),
+		time.Date(2030, 3, 11, 6, 30, 0, 0, time.UTC),
+		time.Date(2030, 3, 12, 6, 30, 0, 0, time.UTC),
+	}, previewStarts(response), "the configured policy skips the missing time, which doesn't count")
+
+	request.DSTGapPolicy = string(valueobjects.GapShiftForward)
+	response, err = preview.Execute(request)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2030, 3, 9, 7, 30, 0, 0, time.UTC),
+		time.Date(2030, 3, 10, 7, 30, 0, 0, time.UTC),
+		time.Date(2030, 3, 11, 6, 30, 0, 0, time.UTC),
+	}, previewStarts(response), "03:30 stands in for 02:30")
+}
+
+func TestPreviewRecurrenceRejectsInvalidRequests(t *testing.T) {
+	preview := newPreviewUseCase(t, valueobjects.DefaultLocalTimePolicy())
+	start := time.Date(2030, 3, 4, 10, 0, 0, 0, time.UTC)
+	weekly := &dto.RecurrenceRuleDTO{Pattern: "weekly"}
+
+	tests := []struct {
+		name    string
+		request dto.PreviewRecurrenceRequest
+		field   string
+	}{
+		{name: "both rule and rrule", request: dto.PreviewRecurrenceRequest{Rule: weekly, RRule: "FREQ=DAILY"}, field: "rrule"},
+		{name: "no rule", request: dto.PreviewRecurrenceRequest{}, field: "rule"},
+		{name: "unknown pattern", request: dto.PreviewRecurrenceRequest{Rule: &dto.RecurrenceRuleDTO{Pattern: "hourly"}}, field: "rule.pattern"},
+		{name: "unknown weekday", request: dto.PreviewRecurrenceRequest{Rule: &dto.RecurrenceRuleDTO{Pattern: "weekly", DaysOfWeek: []string{"someday"}}}, field: "rule.days_of_week"},
+		{name: "unknown timezone", request: dto.PreviewRecurrenceRequest{Rule: weekly, Timezone: "Mars/Olympus_Mons"}, field: "timezone"},
+		{name: "limit too large", request: dto.PreviewRecurrenceRequest{Rule: weekly, Limit: 1001}, field: "limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.StartTime = start
+			tt.request.EndTime = start.Add(time.Hour)
+			_, err := preview.Execute(tt.request)
+			assertInvalidField(t, err, tt.field)
+		})
+	}
+
+	for _, request := range []dto.PreviewRecurrenceRequest{
+		{StartTime: start, EndTime: start.Add(time.Hour), RRule: "FREQ=HOURLY"},
+		{StartTime: start, EndTime: start.Add(time.Hour), Rule: weekly, DSTGapPolicy: "later"},
+		{StartTime: start, EndTime: start, Rule: weekly},
+	} {
+		_, err := preview.Execute(request)
+		require.Error(t, err)
+		kind, _ := domainerrors.KindOf(err)
+		assert.Equal(t, domainerrors.KindValidation, kind)
+	}
+}
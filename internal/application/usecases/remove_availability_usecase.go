@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// ErrAvailabilityNotFound is returned when the slot, series or occurrence to
+// remove does not exist.
+var ErrAvailabilityNotFound = domainerrors.NotFound("availability not found")
+
+type RemoveAvailabilityUseCase struct {
+	participantRepo      ParticipantRepository
+	recurrenceCalculator *services.RecurrenceCalculatorService
+}
+
+func NewRemoveAvailabilityUseCase(
+	participantRepo ParticipantRepository,
+	recurrenceCalculator *services.RecurrenceCalculatorService,
+) *RemoveAvailabilityUseCase {
+	return &RemoveAvailabilityUseCase{
+		participantRepo:      participantRepo,
+		recurrenceCalculator: recurrenceCalculator,
+	}
+}
+
+// Execute removes a single slot or a whole series by ID. An occurrence ID, as
+// returned when listing availability, removes just that occurrence.
+func (uc *RemoveAvailabilityUseCase) Execute(participantID, availabilityID string) error {
+	participant, err := uc.participantRepo.FindByID(participantID)
+	if err != nil {
+		return lookupError(err, ErrParticipantNotFound, participantID)
+	}
+
+	if seriesID, start, ok := entities.ParseOccurrenceID(availabilityID); ok {
+		if !uc.isOccurrence(participant, seriesID, start) {
+			return ErrAvailabilityNotFound
+		}
+	}
+
+	if !participant.RemoveAvailability(availabilityID) {
+		return ErrAvailabilityNotFound
+	}
+
+	if err := uc.participantRepo.Update(participant); err != nil {
+		return internalError("failed to save availability", err)
+	}
+	return nil
+}
+
+func (uc *RemoveAvailabilityUseCase) isOccurrence(participant *entities.Participant, seriesID string, start time.Time) bool {
+	for _, series := range participant.AvailabilitySeries() {
+		if series.ID() != seriesID {
+			continue
+		}
+
+		window, err := valueobjects.NewTimeRange(start, start.Add(series.TimeRange().Duration()))
+		if err != nil {
+			return false
+		}
+
+		occurrences, err := seriesOccurrences(uc.recurrenceCalculator, series, participant.Timezone(), window)
+		if err != nil {
+			return false
+		}
+
+		for _, occurrence := range occurrences {
+			if occurrence.StartTime().Equal(start) {
+				return true
+			}
+		}
+	}
+	return false
+}
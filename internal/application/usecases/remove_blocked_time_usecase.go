@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+)
+
+// ErrBlockedTimeNotFound is returned when the schedule has no blocked time
+// with the given ID.
+var ErrBlockedTimeNotFound = domainerrors.NotFound("blocked time not found")
+
+type RemoveBlockedTimeUseCase struct {
+	unitOfWork UnitOfWork
+}
+
+func NewRemoveBlockedTimeUseCase(unitOfWork UnitOfWork) *RemoveBlockedTimeUseCase {
+	return &RemoveBlockedTimeUseCase{
+		unitOfWork: unitOfWork,
+	}
+}
+
+func (uc *RemoveBlockedTimeUseCase) Execute(ownerID, blockedTimeID string) error {
+	return uc.unitOfWork.Do(func(repos TxRepositories) error {
+		schedule, err := repos.Schedules.FindByOwnerID(ownerID)
+		if err != nil {
+			return lookupError(err, ErrScheduleNotFound, ownerID)
+		}
+
+		if err := schedule.RemoveBlockedTime(blockedTimeID); err != nil {
+			return ErrBlockedTimeNotFound
+		}
+		return repos.Schedules.Save(schedule)
+	})
+}
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+var (
+	// ErrAppointmentNotFound is returned when no appointment has the given ID.
+	ErrAppointmentNotFound = domainerrors.NotFound("appointment not found")
+
+	// ErrAttendeeNotFound is returned when a participant isn't invited to the
+	// appointment.
+	ErrAttendeeNotFound = domainerrors.NotFound("participant is not an attendee")
+)
+
+// RespondToAppointmentUseCase records an attendee's response to an
+// appointment and frees or books their time to match.
+type RespondToAppointmentUseCase struct {
+	unitOfWork          UnitOfWork
+	notificationGateway NotificationGateway
+	conflictDetector    *services.ConflictDetectionService
+}
+
+func NewRespondToAppointmentUseCase(
+	unitOfWork UnitOfWork,
+	notificationGateway NotificationGateway,
+	conflictDetector *services.ConflictDetectionService,
+) *RespondToAppointmentUseCase {
+	return &RespondToAppointmentUseCase{
+		unitOfWork:          unitOfWork,
+		notificationGateway: notificationGateway,
+		conflictDetector:    conflictDetector,
+	}
+}
+
+// Execute records participantID's response. When expectedVersion is set, the
+// response only goes through if the stored appointment is still at that
+// version. Taking back a decline fails if the attendee has since booked
+// something else at that time.
+func (uc *RespondToAppointmentUseCase) Execute(appointmentID, participantID string, request dto.RespondToAppointmentRequest, expectedVersion *int) (*entities.Appointment, error) {
+	response, err := entities.ParseResponseStatus(request.Response)
+	if err != nil {
+		return nil, domainerrors.AtField("response", "", err)
+	}
+
+	var (
+		appointment *entities.Appointment
+		changes     []AppointmentChange
+	)
+	err = uc.unitOfWork.Do(func(repos TxRepositories) error {
+		var err error
+		appointment, err = repos.Appointments.FindByID(appointmentID)
+		if err != nil {
+			return lookupError(err, ErrAppointmentNotFound, appointmentID)
+		}
+
+		if err := checkVersion(appointment, expectedVersion); err != nil {
+			return err
+		}
+
+		if !appointment.Status().IsActive() {
+			return domainerrors.Conflict("cannot respond to " + string(appointment.Status()) + " appointment")
+		}
+
+		if _, ok := appointment.Attendee(participantID); !ok {
+			return fmt.Errorf("%w: %s", ErrAttendeeNotFound, participantID)
+		}
+
+		before := snapshotAppointment(appointment)
+		if err := appointment.Respond(participantID, response); err != nil {
+			return err
+		}
+		changes = changesSince(before, appointment)
+
+		if err := repos.Appointments.Update(appointment); err != nil {
+			return internalError("failed to update appointment", err)
+		}
+
+		// Participants without a schedule have no time to free or book
+		schedule, err := findSchedule(repos.Schedules, participantID)
+		if err != nil || schedule == nil {
+			return err
+		}
+
+		// Take this appointment off the schedule so it doesn't conflict with itself
+		schedule.RemoveAppointment(appointment.ID())
+		previous, _ := before.attendee(participantID)
+		wasBusy := schedule.ConflictPolicy().BlocksAttendee(appointment.Status(), previous)
+		if !wasBusy && schedule.Blocks(appointment) {
+			var conflicts conflictCollector
+			conflicts.add(participantID, appointment.TimeRange(), uc.conflictDetector.DetectConflicts(schedule, appointment.TimeRange()))
+			if err := conflicts.err(); err != nil {
+				return err
+			}
+		}
+
+		if err := schedule.AddAppointment(appointment); err != nil {
+			return fmt.Errorf("failed to update schedule of participant %s: %w", participantID, err)
+		}
+
+		if err := repos.Schedules.Save(schedule); err != nil {
+			return internalError("failed to save schedule of participant "+participantID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, preconditionError(err, expectedVersion)
+	}
+
+	// Send notification
+	if len(changes) > 0 {
+		err = uc.notificationGateway.SendAppointmentUpdated(appointment, changes)
+		if err != nil {
+			// Log error but don't fail the operation
+		}
+	}
+
+	return appointment, nil
+}
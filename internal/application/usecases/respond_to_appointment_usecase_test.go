@@ -0,0 +1,95 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+func TestRespondToAppointment(t *testing.T) {
+	start := time.Date(2030, 3, 4, 10, 0, 0, 0, time.UTC)
+	newRespond := func(f updateFixture) *usecases.RespondToAppointmentUseCase {
+		return usecases.NewRespondToAppointmentUseCase(f.unitOfWork, f.notifications, services.NewConflictDetectionService(nil))
+	}
+	bookBob := func(t *testing.T, f updateFixture) (*dto.CreateAppointmentResponse, error) {
+		t.Helper()
+		return f.book.Execute(dto.CreateAppointmentRequest{
+			Title:     "Lunch",
+			StartTime: start.Add(30 * time.Minute),
+			EndTime:   start.Add(90 * time.Minute),
+			Attendees: []dto.AttendeeRequest{{ParticipantID: "bob"}},
+		})
+	}
+
+	t.Run("declining frees the attendee's time", func(t *testing.T) {
+		f, id := newUpdateFixture(t)
+		respond := newRespond(f)
+
+		_, err := bookBob(t, f)
+		var conflictErr *usecases.ConflictError
+		require.ErrorAs(t, err, &conflictErr, "bob is still expected at the review")
+
+		appointment, err := respond.Execute(id, "bob", dto.RespondToAppointmentRequest{Response: "declined"}, nil)
+		require.NoError(t, err)
+		attendee, ok := appointment.Attendee("bob")
+		require.True(t, ok)
+		assert.Equal(t, entities.ResponseDeclined, attendee.Response())
+		require.Len(t, f.notifications.updates, 1)
+
+		_, err = bookBob(t, f)
+		assert.NoError(t, err)
+	})
+
+	t.Run("withdrawing a decline with a conflict", func(t *testing.T) {
+		f, id := newUpdateFixture(t)
+		respond := newRespond(f)
+
+		_, err := respond.Execute(id, "bob", dto.RespondToAppointmentRequest{Response: "declined"}, nil)
+		require.NoError(t, err)
+		_, err = bookBob(t, f)
+		require.NoError(t, err)
+
+		_, err = respond.Execute(id, "bob", dto.RespondToAppointmentRequest{Response: "accepted"}, nil)
+		var conflictErr *usecases.ConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		require.Len(t, conflictErr.Conflicts, 1)
+		assert.Equal(t, "bob", conflictErr.Conflicts[0].ParticipantID)
+
+		// Nothing was saved
+		stored, err := f.appointments.FindByID(id)
+		require.NoError(t, err)
+		attendee, _ := stored.Attendee("bob")
+		assert.Equal(t, entities.ResponseDeclined, attendee.Response())
+		assert.Len(t, f.notifications.updates, 1)
+	})
+
+	t.Run("participant who isn't an attendee", func(t *testing.T) {
+		f, id := newUpdateFixture(t)
+
+		_, err := newRespond(f).Execute(id, "carol", dto.RespondToAppointmentRequest{Response: "accepted"}, nil)
+		assert.ErrorIs(t, err, usecases.ErrAttendeeNotFound)
+		kind, _ := domainerrors.KindOf(err)
+		assert.Equal(t, domainerrors.KindNotFound, kind)
+	})
+
+	t.Run("cancelled appointment", func(t *testing.T) {
+		f, id := newUpdateFixture(t)
+		require.NoError(t, f.update.Cancel(id, dto.ChangeAppointmentStatusRequest{}, nil))
+
+		_, err := newRespond(f).Execute(id, "bob", dto.RespondToAppointmentRequest{Response: "accepted"}, nil)
+		kind, _ := domainerrors.KindOf(err)
+		assert.Equal(t, domainerrors.KindConflict, kind)
+
+		stored, err := f.appointments.FindByID(id)
+		require.NoError(t, err)
+		attendee, _ := stored.Attendee("bob")
+		assert.Equal(t, entities.ResponseNeedsAction, attendee.Response())
+	})
+}
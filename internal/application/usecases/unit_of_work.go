@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+// ErrVersionConflict is returned by repositories when the entity being
+// written was changed by someone else since it was loaded.
+var ErrVersionConflict = domainerrors.Conflict("entity was modified concurrently")
+
+// ErrPreconditionFailed is returned when a caller's expected version does
+// not match the stored entity.
+var ErrPreconditionFailed = domainerrors.PreconditionFailed("version precondition failed")
+
+// TxRepositories are the repositories handed to a unit of work. Writes made
+// through them are committed together when the unit of work succeeds.
+type TxRepositories struct {
+	Appointments AppointmentRepository
+	Schedules    ScheduleRepository
+	Series       AppointmentSeriesRepository
+}
+
+// UnitOfWork runs fn as a single all-or-nothing operation. If fn returns an
+// error, every write made through repos is rolled back.
+type UnitOfWork interface {
+	Do(fn func(repos TxRepositories) error) error
+}
+
+// lockOrder returns the distinct owner IDs in a stable order. Visiting
+// schedules in this order inside a unit of work keeps two concurrent
+// bookings from locking the same owners in opposite orders.
+func lockOrder(ownerIDs []string) []string {
+	seen := make(map[string]bool, len(ownerIDs))
+	result := make([]string, 0, len(ownerIDs))
+	for _, id := range ownerIDs {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// findSchedule returns ownerID's schedule, or nil when they have none yet.
+// Any other failure is returned, so a booking isn't committed without the
+// schedules it should be on.
+func findSchedule(schedules ScheduleRepository, ownerID string) (*entities.Schedule, error) {
+	schedule, err := schedules.FindByOwnerID(ownerID)
+	if errors.Is(err, ErrScheduleNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, internalError("failed to load schedule of participant "+ownerID, err)
+	}
+	return schedule, nil
+}
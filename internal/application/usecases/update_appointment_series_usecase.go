@@ -0,0 +1,481 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// SeriesEditScope is which occurrences of a series an edit applies to.
+type SeriesEditScope string
+
+const (
+	ScopeThisOccurrence   SeriesEditScope = "this"
+	ScopeThisAndFollowing SeriesEditScope = "following"
+	ScopeAllOccurrences   SeriesEditScope = "all"
+)
+
+func ParseSeriesEditScope(scope string) (SeriesEditScope, error) {
+	switch SeriesEditScope(scope) {
+	case ScopeThisOccurrence, ScopeThisAndFollowing, ScopeAllOccurrences:
+		return SeriesEditScope(scope), nil
+	}
+	return "", domainerrors.InvalidField("scope", "invalid scope: "+scope+" (expected this, following or all)")
+}
+
+type UpdateAppointmentSeriesUseCase struct {
+	unitOfWork           UnitOfWork
+	notificationGateway  NotificationGateway
+	conflictDetector     *services.ConflictDetectionService
+	recurrenceCalculator *services.RecurrenceCalculatorService
+	horizon              time.Duration
+}
+
+func NewUpdateAppointmentSeriesUseCase(
+	unitOfWork UnitOfWork,
+	notificationGateway NotificationGateway,
+	conflictDetector *services.ConflictDetectionService,
+	recurrenceCalculator *services.RecurrenceCalculatorService,
+	horizon time.Duration,
+) *UpdateAppointmentSeriesUseCase {
+	return &UpdateAppointmentSeriesUseCase{
+		unitOfWork:           unitOfWork,
+		notificationGateway:  notificationGateway,
+		conflictDetector:     conflictDetector,
+		recurrenceCalculator: recurrenceCalculator,
+		horizon:              horizon,
+	}
+}
+
+// Execute edits the occurrence at recurrenceID within scope. Editing "this
+// and following" from any but the first occurrence splits the series; the
+// returned series is the one now holding the edited occurrence. Occurrences
+// that have ended are kept as they took place, so editing all occurrences of
+// a series that has some splits it before the first one that hasn't, and
+// the edit is applied from there.
+func (uc *UpdateAppointmentSeriesUseCase) Execute(seriesID string, recurrenceID time.Time, request dto.UpdateAppointmentSeriesRequest, expectedVersion *int) (*entities.AppointmentSeries, []*entities.Appointment, error) {
+	scope, err := ParseSeriesEditScope(request.Scope)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if scope == ScopeThisOccurrence && request.RRule != nil {
+		return nil, nil, domainerrors.InvalidField("rrule", "the recurrence rule can only be changed for following or all occurrences")
+	}
+
+	var (
+		edited      *entities.AppointmentSeries
+		occurrences []*entities.Appointment
+		changes     seriesChanges
+		before      seriesSnapshot
+	)
+	err = uc.unitOfWork.Do(func(repos TxRepositories) error {
+		series, err := repos.Series.FindByID(seriesID)
+		if err != nil {
+			return lookupError(err, ErrAppointmentSeriesNotFound, seriesID)
+		}
+
+		if err := checkSeriesVersion(series, expectedVersion); err != nil {
+			return err
+		}
+
+		if series.Status() == entities.StatusCancelled {
+			return domainerrors.Conflict("cannot update cancelled appointment series")
+		}
+		before = snapshotSeries(series)
+
+		previous, err := loadSeriesOccurrences(repos.Appointments, uc.recurrenceCalculator, series)
+		if err != nil {
+			return err
+		}
+
+		occurrence, index, err := findSeriesOccurrence(uc.recurrenceCalculator, series, recurrenceID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if scope != ScopeAllOccurrences && occurrenceHasEnded(series, occurrence, now) {
+			return domainerrors.Conflict("cannot update an occurrence that has ended")
+		}
+
+		// The first occurrence has nothing before it to keep
+		if scope == ScopeThisAndFollowing && index == 0 {
+			scope = ScopeAllOccurrences
+		}
+
+		if scope == ScopeAllOccurrences {
+			current, currentIndex, err := findCurrentSeriesOccurrence(uc.recurrenceCalculator, series, now, now.Add(uc.horizon))
+			if err != nil {
+				return err
+			}
+
+			if currentIndex > 0 {
+				if request.StartTime != nil || request.EndTime != nil {
+					moved, err := shiftTimeChanges(current.timeRange, occurrence, request)
+					if err != nil {
+						return err
+					}
+					startTime, endTime := moved.StartTime(), moved.EndTime()
+					request.StartTime, request.EndTime = &startTime, &endTime
+				}
+				occurrence, index, scope = current, currentIndex, ScopeThisAndFollowing
+			}
+		}
+
+		edited = series
+		switch scope {
+		case ScopeThisOccurrence:
+			err = uc.editOccurrence(series, occurrence, request)
+		case ScopeAllOccurrences:
+			err = uc.editSeries(series, occurrence, request)
+		case ScopeThisAndFollowing:
+			edited, err = uc.splitSeries(series, occurrence, index, request)
+		}
+		if err != nil {
+			return err
+		}
+
+		desired, err := uc.materialize(series)
+		if err != nil {
+			return err
+		}
+
+		if edited != series {
+			tail, err := uc.materialize(edited)
+			if err != nil {
+				return err
+			}
+			desired = append(desired, tail...)
+		}
+
+		changes, err = bookSeriesOccurrences(repos, uc.conflictDetector, series.AttendeeIDs(), previous, desired, now)
+		if err != nil {
+			return err
+		}
+
+		// Occurrences moved to the new half of a split are replaced, not cancelled
+		for _, appointment := range changes.removed {
+			if err := repos.Appointments.Delete(appointment.ID()); err != nil {
+				return internalError("failed to delete occurrence", err)
+			}
+		}
+
+		if err := repos.Series.Save(series); err != nil {
+			return internalError("failed to save appointment series", err)
+		}
+		if edited != series {
+			if err := repos.Series.Save(edited); err != nil {
+				return internalError("failed to save appointment series", err)
+			}
+		}
+
+		// Occurrences that have ended may differ from what the series now says
+		occurrences, err = loadSeriesOccurrences(repos.Appointments, uc.recurrenceCalculator, edited)
+		return err
+	})
+	if err != nil {
+		return nil, nil, preconditionError(err, expectedVersion)
+	}
+
+	// Send notification: an edit of the series as one notification, an edit
+	// of one occurrence as an update of that appointment
+	if scope == ScopeThisOccurrence {
+		for _, appointment := range changes.updated {
+			err = uc.notificationGateway.SendAppointmentUpdated(appointment, changesSince(changes.before[appointment.ID()], appointment))
+			if err != nil {
+				// Log error but don't fail the operation
+			}
+		}
+		return edited, occurrences, nil
+	}
+
+	seriesChanges := seriesChangesSince(before, edited)
+	booked := append(changes.created, changes.updated...)
+	if len(seriesChanges) > 0 || len(booked) > 0 {
+		err = uc.notificationGateway.SendAppointmentSeriesUpdated(edited, seriesChanges, booked)
+		if err != nil {
+			// Log error but don't fail the operation
+		}
+	}
+
+	return edited, occurrences, nil
+}
+
+// Cancel cancels the occurrence at recurrenceID, or it and the following
+// ones, or the whole series. Cancelled occurrences stay stored with the
+// cancelled status.
+func (uc *UpdateAppointmentSeriesUseCase) Cancel(seriesID string, recurrenceID time.Time, scope SeriesEditScope, expectedVersion *int) error {
+	var (
+		series    *entities.AppointmentSeries
+		cancelled []*entities.Appointment
+		before    seriesSnapshot
+	)
+	err := uc.unitOfWork.Do(func(repos TxRepositories) error {
+		var err error
+		series, err = repos.Series.FindByID(seriesID)
+		if err != nil {
+			return lookupError(err, ErrAppointmentSeriesNotFound, seriesID)
+		}
+
+		if err := checkSeriesVersion(series, expectedVersion); err != nil {
+			return err
+		}
+
+		if series.Status() == entities.StatusCancelled {
+			return domainerrors.Conflict("appointment series is already cancelled")
+		}
+		before = snapshotSeries(series)
+
+		previous, err := loadSeriesOccurrences(repos.Appointments, uc.recurrenceCalculator, series)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		index := 0
+		if scope != ScopeAllOccurrences {
+			var occurrence seriesOccurrence
+			occurrence, index, err = findSeriesOccurrence(uc.recurrenceCalculator, series, recurrenceID)
+			if err != nil {
+				return err
+			}
+
+			if occurrenceHasEnded(series, occurrence, now) {
+				return domainerrors.Conflict("cannot cancel an occurrence that has ended")
+			}
+		}
+
+		switch {
+		case scope == ScopeThisOccurrence:
+			series.Exclude(recurrenceID)
+		case scope == ScopeAllOccurrences || index == 0:
+			series.Cancel()
+		default:
+			rule, err := endRuleBefore(series, recurrenceID)
+			if err != nil {
+				return err
+			}
+			series.SetRule(rule)
+		}
+
+		desired, err := materializeAppointmentSeries(uc.recurrenceCalculator, series)
+		if err != nil {
+			return err
+		}
+
+		// Occurrences that have ended aren't removed, so they are kept as
+		// they took place
+		changes, err := bookSeriesOccurrences(repos, uc.conflictDetector, series.AttendeeIDs(), previous, desired, now)
+		if err != nil {
+			return err
+		}
+
+		for _, appointment := range changes.removed {
+			// Occurrences in progress, or closed on their own, keep their status
+			if err := appointment.Cancel("", "cancelled through appointment series "+series.ID()); err != nil {
+				continue
+			}
+			if err := repos.Appointments.Update(appointment); err != nil {
+				return internalError("failed to cancel occurrence", err)
+			}
+			cancelled = append(cancelled, appointment)
+		}
+
+		if err := repos.Series.Save(series); err != nil {
+			return internalError("failed to save appointment series", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return preconditionError(err, expectedVersion)
+	}
+
+	// Send notification: cancelling one occurrence cancels that appointment,
+	// anything more changes the series
+	if scope == ScopeThisOccurrence {
+		for _, appointment := range cancelled {
+			err = uc.notificationGateway.SendAppointmentCancelled(appointment)
+			if err != nil {
+				// Log error but don't fail the operation
+			}
+		}
+		return nil
+	}
+
+	err = uc.notificationGateway.SendAppointmentSeriesUpdated(series, seriesChangesSince(before, series), cancelled)
+	if err != nil {
+		// Log error but don't fail the operation
+	}
+
+	return nil
+}
+
+func (uc *UpdateAppointmentSeriesUseCase) materialize(series *entities.AppointmentSeries) ([]*entities.Appointment, error) {
+	extendSeriesHorizon(series, uc.horizon)
+
+	occurrences, err := materializeAppointmentSeries(uc.recurrenceCalculator, series)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand appointment series: %w", err)
+	}
+	return occurrences, nil
+}
+
+// editOccurrence records the changes to a single occurrence as an override.
+func (uc *UpdateAppointmentSeriesUseCase) editOccurrence(series *entities.AppointmentSeries, occurrence seriesOccurrence, request dto.UpdateAppointmentSeriesRequest) error {
+	if request.Title != nil && *request.Title == "" {
+		return domainerrors.InvalidField("title", "appointment title cannot be empty")
+	}
+
+	var timeRange *valueobjects.TimeRange
+	if request.StartTime != nil || request.EndTime != nil {
+		// Times not given are kept from where the occurrence is now
+		moved, err := applyTimeChanges(occurrenceTimeRange(series, occurrence), request)
+		if err != nil {
+			return err
+		}
+		timeRange = &moved
+	}
+
+	series.SetOverride(entities.NewOccurrenceOverride(occurrence.recurrenceID, timeRange, request.Title, request.Location))
+	return nil
+}
+
+// editSeries applies the changes to the whole series. A time change is
+// worked out on the edited occurrence and applied to the first one, which
+// moves them all.
+func (uc *UpdateAppointmentSeriesUseCase) editSeries(series *entities.AppointmentSeries, occurrence seriesOccurrence, request dto.UpdateAppointmentSeriesRequest) error {
+	if request.Title != nil {
+		if err := series.Retitle(*request.Title); err != nil {
+			return err
+		}
+	}
+
+	if request.Location != nil {
+		series.Relocate(*request.Location)
+	}
+
+	if request.StartTime != nil || request.EndTime != nil {
+		first, err := shiftTimeChanges(series.TimeRange(), occurrence, request)
+		if err != nil {
+			return err
+		}
+		series.Reschedule(first)
+	}
+
+	if request.RRule != nil {
+		rule, _, err := parseSeriesRule(*request.RRule, series.Timezone())
+		if err != nil {
+			return err
+		}
+		series.SetRule(rule)
+	}
+
+	return nil
+}
+
+// splitSeries ends series before occurrence and starts a new series there
+// with the changes applied. If the rule has a COUNT, the new series gets the
+// occurrences that were left.
+func (uc *UpdateAppointmentSeriesUseCase) splitSeries(series *entities.AppointmentSeries, occurrence seriesOccurrence, index int, request dto.UpdateAppointmentSeriesRequest) (*entities.AppointmentSeries, error) {
+	headRule, err := endRuleBefore(series, occurrence.recurrenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tailRule string
+	if request.RRule != nil {
+		tailRule, _, err = parseSeriesRule(*request.RRule, series.Timezone())
+	} else {
+		tailRule, err = remainingRule(series, index)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := applyTimeChanges(occurrence.timeRange, request)
+	if err != nil {
+		return nil, err
+	}
+
+	tail := series.SplitAt(occurrence.recurrenceID, headRule, tailRule, first)
+	if request.Title != nil {
+		if err := tail.Retitle(*request.Title); err != nil {
+			return nil, err
+		}
+	}
+
+	if request.Location != nil {
+		tail.Relocate(*request.Location)
+	}
+
+	return tail, nil
+}
+
+// endRuleBefore is the series' rule with an UNTIL just before recurrenceID.
+func endRuleBefore(series *entities.AppointmentSeries, recurrenceID time.Time) (string, error) {
+	rule, err := services.ParseRRule(series.Rule(), series.Timezone())
+	if err != nil {
+		return "", err
+	}
+
+	until := recurrenceID.Add(-time.Second)
+	rule.MaxCount = 0
+	rule.EndDate = &until
+	return services.FormatRRule(rule)
+}
+
+// remainingRule is the series' rule for a series starting at the occurrence
+// with the given index, so a COUNT only covers the occurrences left.
+func remainingRule(series *entities.AppointmentSeries, index int) (string, error) {
+	rule, err := services.ParseRRule(series.Rule(), series.Timezone())
+	if err != nil {
+		return "", err
+	}
+
+	if rule.MaxCount > 0 {
+		rule.MaxCount -= index
+	}
+	return services.FormatRRule(rule)
+}
+
+// shiftTimeChanges works out how request moves occurrence and returns
+// timeRange moved the same way.
+func shiftTimeChanges(timeRange valueobjects.TimeRange, occurrence seriesOccurrence, request dto.UpdateAppointmentSeriesRequest) (valueobjects.TimeRange, error) {
+	moved, err := applyTimeChanges(occurrence.timeRange, request)
+	if err != nil {
+		return valueobjects.TimeRange{}, err
+	}
+
+	start := timeRange.StartTime().Add(moved.StartTime().Sub(occurrence.timeRange.StartTime()))
+	result, err := valueobjects.NewTimeRange(start, start.Add(moved.Duration()))
+	if err != nil {
+		return valueobjects.TimeRange{}, domainerrors.AtField("end_time", "invalid time range", err)
+	}
+	return result, nil
+}
+
+// applyTimeChanges replaces the start and end times given in request, as an
+// appointment update does.
+func applyTimeChanges(timeRange valueobjects.TimeRange, request dto.UpdateAppointmentSeriesRequest) (valueobjects.TimeRange, error) {
+	startTime := timeRange.StartTime()
+	endTime := timeRange.EndTime()
+
+	if request.StartTime != nil {
+		startTime = *request.StartTime
+	}
+	if request.EndTime != nil {
+		endTime = *request.EndTime
+	}
+
+	result, err := valueobjects.NewTimeRange(startTime, endTime)
+	if err != nil {
+		return valueobjects.TimeRange{}, domainerrors.AtField("end_time", "invalid time range", err)
+	}
+	return result, nil
+}
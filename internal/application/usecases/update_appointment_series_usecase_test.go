@@ -0,0 +1,401 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/repositories"
+)
+
+type locationResolver struct{}
+
+func (locationResolver) GetLocation(timezone string) (*time.Location, error) {
+	return time.LoadLocation(timezone)
+}
+
+// seriesFixture books a daily one-hour series that started three days ago,
+// on the hour, so its first three occurrences have ended and the fourth is
+// in progress.
+type seriesFixture struct {
+	appointments  *repositories.MemoryAppointmentRepository
+	seriesRepo    *repositories.MemoryAppointmentSeriesRepository
+	unitOfWork    usecases.UnitOfWork
+	calculator    *services.RecurrenceCalculatorService
+	notifications *recordingNotificationGateway
+	update        *usecases.UpdateAppointmentSeriesUseCase
+	series        *entities.AppointmentSeries
+	start         time.Time // Of the occurrence in progress
+}
+
+func newSeriesFixture(t *testing.T, horizon time.Duration) seriesFixture {
+	t.Helper()
+
+	f := seriesFixture{
+		appointments:  repositories.NewMemoryAppointmentRepository(),
+		seriesRepo:    repositories.NewMemoryAppointmentSeriesRepository(),
+		calculator:    services.NewRecurrenceCalculatorService(valueobjects.DefaultLocalTimePolicy()),
+		notifications: &recordingNotificationGateway{},
+		start:         time.Now().UTC().Truncate(time.Hour),
+	}
+	f.unitOfWork = repositories.NewMemoryUnitOfWork(f.appointments, repositories.NewMemoryScheduleRepository(f.appointments), f.seriesRepo)
+	conflictDetector := services.NewConflictDetectionService(nil)
+	f.update = usecases.NewUpdateAppointmentSeriesUseCase(f.unitOfWork, f.notifications, conflictDetector, f.calculator, horizon)
+
+	create := usecases.NewCreateAppointmentSeriesUseCase(f.unitOfWork, f.notifications, conflictDetector, f.calculator, locationResolver{}, horizon)
+	series, _, err := create.Execute(dto.CreateAppointmentSeriesRequest{
+		Title:     "Standup",
+		StartTime: f.at(-3),
+		EndTime:   f.at(-3).Add(time.Hour),
+		Attendees: []dto.AttendeeRequest{{ParticipantID: "alice"}},
+		RRule:     "FREQ=DAILY",
+		Timezone:  "UTC",
+	})
+	require.NoError(t, err)
+	f.series = series
+	return f
+}
+
+// at is the start of the occurrence days after the one in progress.
+func (f seriesFixture) at(days int) time.Time {
+	return f.start.AddDate(0, 0, days)
+}
+
+// occurrence returns the stored occurrence of series scheduled on day, or
+// nil when there is none.
+func (f seriesFixture) occurrence(t *testing.T, series *entities.AppointmentSeries, days int) *entities.Appointment {
+	t.Helper()
+
+	appointment, err := f.appointments.FindByID(series.OccurrenceID(f.at(days)))
+	if errors.Is(err, usecases.ErrAppointmentNotFound) {
+		return nil
+	}
+	require.NoError(t, err)
+	return appointment
+}
+
+func (f seriesFixture) reload(t *testing.T, series *entities.AppointmentSeries) *entities.AppointmentSeries {
+	t.Helper()
+
+	reloaded, err := f.seriesRepo.FindByID(series.ID())
+	require.NoError(t, err)
+	return reloaded
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func TestUpdateAppointmentSeriesThisOccurrence(t *testing.T) {
+	f := newSeriesFixture(t, 7*24*time.Hour)
+
+	moved := f.at(1).Add(2 * time.Hour)
+	movedEnd := moved.Add(30 * time.Minute)
+	edited, _, err := f.update.Execute(f.series.ID(), f.at(1), dto.UpdateAppointmentSeriesRequest{
+		Scope:     string(usecases.ScopeThisOccurrence),
+		Title:     stringPtr("Retro"),
+		StartTime: &moved,
+		EndTime:   &movedEnd,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, f.series.ID(), edited.ID())
+
+	// The override is recorded under the original start, which keeps the ID
+	series := f.reload(t, f.series)
+	require.Len(t, series.Overrides(), 1)
+	override := series.Overrides()[0]
+	assert.True(t, override.RecurrenceID().Equal(f.at(1)))
+	require.NotNil(t, override.Title())
+	assert.Equal(t, "Retro", *override.Title())
+	assert.Nil(t, override.Location())
+
+	appointment := f.occurrence(t, series, 1)
+	require.NotNil(t, appointment)
+	assert.Equal(t, "Retro", appointment.Title())
+	assert.True(t, appointment.TimeRange().StartTime().Equal(moved))
+	assert.True(t, appointment.TimeRange().EndTime().Equal(movedEnd))
+	assert.Equal(t, "Standup", f.occurrence(t, series, 2).Title())
+
+	// A second edit is merged with the first
+	_, _, err = f.update.Execute(series.ID(), f.at(1), dto.UpdateAppointmentSeriesRequest{
+		Scope:    string(usecases.ScopeThisOccurrence),
+		Location: stringPtr("Room 2"),
+	}, nil)
+	require.NoError(t, err)
+
+	series = f.reload(t, series)
+	require.Len(t, series.Overrides(), 1)
+	assert.Equal(t, "Retro", *series.Overrides()[0].Title())
+	assert.Equal(t, "Room 2", *series.Overrides()[0].Location())
+	assert.True(t, f.occurrence(t, series, 1).TimeRange().StartTime().Equal(moved))
+
+	// Cancelling adds an EXDATE and drops the override
+	require.NoError(t, f.update.Cancel(series.ID(), f.at(1), usecases.ScopeThisOccurrence, nil))
+	require.NoError(t, f.update.Cancel(series.ID(), f.at(2), usecases.ScopeThisOccurrence, nil))
+
+	series = f.reload(t, series)
+	assert.Empty(t, series.Overrides())
+	require.Len(t, series.Exceptions(), 2)
+	assert.True(t, series.Exceptions()[0].Equal(f.at(1)))
+	assert.True(t, series.Exceptions()[1].Equal(f.at(2)))
+	assert.Equal(t, entities.StatusCancelled, f.occurrence(t, series, 1).Status())
+	assert.Equal(t, entities.StatusCancelled, f.occurrence(t, series, 2).Status())
+	assert.Equal(t, entities.StatusConfirmed, f.occurrence(t, series, 3).Status())
+
+	// An excluded occurrence is gone from the series
+	_, _, err = f.update.Execute(series.ID(), f.at(2), dto.UpdateAppointmentSeriesRequest{
+		Scope: string(usecases.ScopeThisOccurrence),
+		Title: stringPtr("Planning"),
+	}, nil)
+	assert.ErrorIs(t, err, usecases.ErrOccurrenceNotFound)
+}
+
+func TestUpdateAppointmentSeriesFollowingOccurrences(t *testing.T) {
+	f := newSeriesFixture(t, 7*24*time.Hour)
+
+	// An exception and an override after the split move to the new series
+	require.NoError(t, f.update.Cancel(f.series.ID(), f.at(4), usecases.ScopeThisOccurrence, nil))
+	_, _, err := f.update.Execute(f.series.ID(), f.at(5), dto.UpdateAppointmentSeriesRequest{
+		Scope:    string(usecases.ScopeThisOccurrence),
+		Location: stringPtr("Room 2"),
+	}, nil)
+	require.NoError(t, err)
+
+	start := f.at(2).Add(time.Hour)
+	end := start.Add(time.Hour)
+	tail, occurrences, err := f.update.Execute(f.series.ID(), f.at(2), dto.UpdateAppointmentSeriesRequest{
+		Scope:     string(usecases.ScopeThisAndFollowing),
+		Title:     stringPtr("Planning"),
+		StartTime: &start,
+		EndTime:   &end,
+	}, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, f.series.ID(), tail.ID())
+	assert.Equal(t, "Planning", tail.Title())
+	assert.True(t, tail.TimeRange().StartTime().Equal(start))
+	assert.Equal(t, time.Hour, tail.TimeRange().Duration())
+
+	// The original series ends before the split and keeps its occurrences
+	head := f.reload(t, f.series)
+	assert.Contains(t, head.Rule(), "UNTIL=")
+	assert.Empty(t, head.Exceptions())
+	assert.Empty(t, head.Overrides())
+	for days := -3; days < 2; days++ {
+		appointment := f.occurrence(t, head, days)
+		require.NotNil(t, appointment, "day %d", days)
+		assert.Equal(t, "Standup", appointment.Title())
+	}
+	for days := 2; days <= 7; days++ {
+		if days == 4 {
+			assert.Equal(t, entities.StatusCancelled, f.occurrence(t, head, days).Status())
+			continue
+		}
+		assert.Nil(t, f.occurrence(t, head, days), "day %d", days)
+	}
+
+	// The following occurrences are rebooked in the new series an hour later
+	require.Len(t, tail.Exceptions(), 1)
+	assert.True(t, tail.Exceptions()[0].Equal(f.at(4).Add(time.Hour)))
+	require.Len(t, tail.Overrides(), 1)
+	assert.True(t, tail.Overrides()[0].RecurrenceID().Equal(f.at(5).Add(time.Hour)))
+
+	require.NotEmpty(t, occurrences)
+	for _, occurrence := range occurrences {
+		assert.Equal(t, "Planning", occurrence.Title())
+		assert.Equal(t, f.start.Add(time.Hour).Format("15:04"), occurrence.TimeRange().StartTime().Format("15:04"))
+		assert.NotEqual(t, tail.OccurrenceID(f.at(4).Add(time.Hour)), occurrence.ID())
+	}
+	overridden, err := f.appointments.FindByID(tail.OccurrenceID(f.at(5).Add(time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, "Room 2", overridden.Location())
+}
+
+func TestUpdateAppointmentSeriesAllOccurrences(t *testing.T) {
+	f := newSeriesFixture(t, 7*24*time.Hour)
+
+	// Edited from a future occurrence, everything that hasn't ended moves
+	start := f.at(2).Add(30 * time.Minute)
+	end := start.Add(time.Hour)
+	edited, occurrences, err := f.update.Execute(f.series.ID(), f.at(2), dto.UpdateAppointmentSeriesRequest{
+		Scope:     string(usecases.ScopeAllOccurrences),
+		Title:     stringPtr("Planning"),
+		StartTime: &start,
+		EndTime:   &end,
+	}, nil)
+	require.NoError(t, err)
+
+	// Occurrences that have ended stay in the original series as they were
+	head := f.reload(t, f.series)
+	for days := -3; days < 0; days++ {
+		appointment := f.occurrence(t, head, days)
+		require.NotNil(t, appointment, "day %d", days)
+		assert.Equal(t, "Standup", appointment.Title())
+		assert.True(t, appointment.TimeRange().StartTime().Equal(f.at(days)))
+	}
+	assert.Nil(t, f.occurrence(t, head, 0))
+
+	// The rest, from the one in progress, are in the new series
+	require.NotEqual(t, f.series.ID(), edited.ID())
+	assert.True(t, edited.TimeRange().StartTime().Equal(f.at(0).Add(30*time.Minute)))
+	require.NotEmpty(t, occurrences)
+	for _, occurrence := range occurrences {
+		assert.Equal(t, "Planning", occurrence.Title())
+		assert.Equal(t, 30, occurrence.TimeRange().StartTime().Minute())
+		assert.True(t, occurrence.TimeRange().EndTime().After(time.Now()))
+	}
+}
+
+func TestUpdateAppointmentSeriesAllOccurrencesWithoutHistory(t *testing.T) {
+	f := newSeriesFixture(t, 7*24*time.Hour)
+
+	// Split off the upcoming occurrences, which then have no history
+	upcoming, _, err := f.update.Execute(f.series.ID(), f.at(1), dto.UpdateAppointmentSeriesRequest{
+		Scope: string(usecases.ScopeThisAndFollowing),
+		Title: stringPtr("Planning"),
+	}, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, f.series.ID(), upcoming.ID())
+
+	// Editing all occurrences of a series with none that ended edits it in place
+	edited, occurrences, err := f.update.Execute(upcoming.ID(), f.at(3), dto.UpdateAppointmentSeriesRequest{
+		Scope:    string(usecases.ScopeAllOccurrences),
+		Location: stringPtr("Room 2"),
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, upcoming.ID(), edited.ID())
+	assert.Equal(t, "Room 2", edited.Location())
+	require.NotEmpty(t, occurrences)
+	for _, occurrence := range occurrences {
+		assert.Equal(t, "Room 2", occurrence.Location())
+	}
+}
+
+func TestUpdateAppointmentSeriesKeepsEndedOccurrences(t *testing.T) {
+	f := newSeriesFixture(t, 7*24*time.Hour)
+
+	_, _, err := f.update.Execute(f.series.ID(), f.at(-2), dto.UpdateAppointmentSeriesRequest{
+		Scope: string(usecases.ScopeThisOccurrence),
+		Title: stringPtr("Planning"),
+	}, nil)
+	kind, _ := domainerrors.KindOf(err)
+	assert.Equal(t, domainerrors.KindConflict, kind)
+
+	_, _, err = f.update.Execute(f.series.ID(), f.at(-2), dto.UpdateAppointmentSeriesRequest{
+		Scope: string(usecases.ScopeThisAndFollowing),
+		Title: stringPtr("Planning"),
+	}, nil)
+	kind, _ = domainerrors.KindOf(err)
+	assert.Equal(t, domainerrors.KindConflict, kind)
+
+	err = f.update.Cancel(f.series.ID(), f.at(-2), usecases.ScopeThisOccurrence, nil)
+	kind, _ = domainerrors.KindOf(err)
+	assert.Equal(t, domainerrors.KindConflict, kind)
+
+	// Cancelling the series cancels the occurrences that haven't ended
+	require.NoError(t, f.update.Cancel(f.series.ID(), time.Time{}, usecases.ScopeAllOccurrences, nil))
+
+	series := f.reload(t, f.series)
+	assert.Equal(t, entities.StatusCancelled, series.Status())
+	for days := -3; days <= 7; days++ {
+		appointment := f.occurrence(t, series, days)
+		require.NotNil(t, appointment, "day %d", days)
+
+		want := entities.StatusCancelled
+		if days < 0 {
+			want = entities.StatusConfirmed
+		}
+		assert.Equal(t, want, appointment.Status(), "day %d", days)
+	}
+}
+
+func TestExtendAppointmentSeries(t *testing.T) {
+	f := newSeriesFixture(t, 2*24*time.Hour)
+	assert.Nil(t, f.occurrence(t, f.series, 3))
+
+	extend := usecases.NewExtendAppointmentSeriesUseCase(f.unitOfWork, f.notifications, services.NewConflictDetectionService(nil), f.calculator, 5*24*time.Hour)
+
+	booked, err := extend.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, 3, booked)
+
+	// One notification for the series, not one per occurrence
+	require.Len(t, f.notifications.seriesUpdates, 1)
+	update := f.notifications.seriesUpdates[0]
+	assert.Len(t, update.occurrences, 3)
+	require.Len(t, update.changes, 1)
+	assert.Equal(t, "horizon", update.changes[0].Field)
+	assert.Empty(t, f.notifications.created)
+
+	series := f.reload(t, f.series)
+	for days := -3; days <= 5; days++ {
+		assert.NotNil(t, f.occurrence(t, series, days), "day %d", days)
+	}
+	assert.Nil(t, f.occurrence(t, series, 6))
+
+	// Nothing new has come within the horizon
+	booked, err = extend.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, 0, booked)
+	assert.Len(t, f.notifications.seriesUpdates, 1)
+
+	// Cancelled series aren't extended
+	require.NoError(t, f.update.Cancel(series.ID(), time.Time{}, usecases.ScopeAllOccurrences, nil))
+	ids, err := f.seriesRepo.FindIDsToExtend(time.Now().AddDate(1, 0, 0))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestAppointmentSeriesNotifications(t *testing.T) {
+	f := newSeriesFixture(t, 7*24*time.Hour)
+
+	// One notification for the new series, not one per occurrence
+	require.Len(t, f.notifications.seriesCreated, 1)
+	assert.Len(t, f.notifications.seriesCreated[0], 11)
+	assert.Empty(t, f.notifications.created)
+
+	// An edit of one occurrence updates that appointment
+	_, _, err := f.update.Execute(f.series.ID(), f.at(1), dto.UpdateAppointmentSeriesRequest{
+		Scope: string(usecases.ScopeThisOccurrence),
+		Title: stringPtr("Retro"),
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, f.notifications.updates, 1)
+	assert.Equal(t, []usecases.AppointmentChange{{Field: "title", From: "Standup", To: "Retro"}}, f.notifications.updates[0])
+	assert.Empty(t, f.notifications.seriesUpdates)
+
+	// An edit of the following occurrences changes the series once
+	_, _, err = f.update.Execute(f.series.ID(), f.at(2), dto.UpdateAppointmentSeriesRequest{
+		Scope:    string(usecases.ScopeThisAndFollowing),
+		Location: stringPtr("Room 2"),
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, f.notifications.seriesUpdates, 1)
+	assert.Contains(t, f.notifications.seriesUpdates[0].changes, usecases.AppointmentChange{Field: "location", From: "", To: "Room 2"})
+	assert.NotEmpty(t, f.notifications.seriesUpdates[0].occurrences)
+	assert.Len(t, f.notifications.updates, 1)
+
+	// Cancelling one occurrence cancels that appointment
+	series := f.reload(t, f.series)
+	require.NoError(t, f.update.Cancel(series.ID(), f.at(1), usecases.ScopeThisOccurrence, nil))
+	assert.Len(t, f.notifications.cancelled, 1)
+	assert.Len(t, f.notifications.seriesUpdates, 1)
+
+	// Cancelling the series changes it once, listing the cancelled occurrences
+	require.NoError(t, f.update.Cancel(series.ID(), time.Time{}, usecases.ScopeAllOccurrences, nil))
+	require.Len(t, f.notifications.seriesUpdates, 2)
+	cancelled := f.notifications.seriesUpdates[1]
+	assert.Equal(t, []usecases.AppointmentChange{{Field: "status", From: "confirmed", To: "cancelled"}}, cancelled.changes)
+	require.NotEmpty(t, cancelled.occurrences)
+	for _, occurrence := range cancelled.occurrences {
+		assert.Equal(t, entities.StatusCancelled, occurrence.Status())
+	}
+	assert.Len(t, f.notifications.cancelled, 1)
+}
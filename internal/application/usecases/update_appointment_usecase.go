@@ -1,168 +1,309 @@
 package usecases
 
 import (
-	"errors"
+	"fmt"
 
 	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
 	"github.com/visiab/appointment-calculator/internal/domain/entities"
 	"github.com/visiab/appointment-calculator/internal/domain/services"
 	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 )
 
 type UpdateAppointmentUseCase struct {
-	appointmentRepo     AppointmentRepository
-	scheduleRepo        ScheduleRepository
+	unitOfWork          UnitOfWork
 	notificationGateway NotificationGateway
 	conflictDetector    *services.ConflictDetectionService
+	timeFinder          *services.OptimalTimeFinderService
+	alternatives        int
 }
 
+// NewUpdateAppointmentUseCase suggests up to alternatives conflict-free times, found
+// with timeFinder, when the appointment conflicts with attendees' schedules.
 func NewUpdateAppointmentUseCase(
-	appointmentRepo AppointmentRepository,
-	scheduleRepo ScheduleRepository,
+	unitOfWork UnitOfWork,
 	notificationGateway NotificationGateway,
 	conflictDetector *services.ConflictDetectionService,
+	timeFinder *services.OptimalTimeFinderService,
+	alternatives int,
 ) *UpdateAppointmentUseCase {
 	return &UpdateAppointmentUseCase{
-		appointmentRepo:     appointmentRepo,
-		scheduleRepo:        scheduleRepo,
+		unitOfWork:          unitOfWork,
 		notificationGateway: notificationGateway,
 		conflictDetector:    conflictDetector,
+		timeFinder:          timeFinder,
+		alternatives:        alternatives,
 	}
 }
 
-func (uc *UpdateAppointmentUseCase) Execute(appointmentID string, request dto.UpdateAppointmentRequest) (*dto.AppointmentResponse, error) {
-	// Find existing appointment
-	appointment, err := uc.appointmentRepo.FindByID(appointmentID)
-	if err != nil {
-		return nil, errors.New("appointment not found: " + err.Error())
-	}
+// Execute applies the update. When expectedVersion is set, the update only
+// proceeds if the stored appointment is still at that version.
+func (uc *UpdateAppointmentUseCase) Execute(appointmentID string, request dto.UpdateAppointmentRequest, expectedVersion *int) (*dto.AppointmentResponse, error) {
+	var (
+		appointment *entities.Appointment
+		changes     []AppointmentChange
+	)
+	err := uc.unitOfWork.Do(func(repos TxRepositories) error {
+		// Find existing appointment
+		var err error
+		appointment, err = repos.Appointments.FindByID(appointmentID)
+		if err != nil {
+			return lookupError(err, ErrAppointmentNotFound, appointmentID)
+		}
 
-	// Check if appointment can be updated
-	if appointment.Status() == entities.StatusCompleted {
-		return nil, errors.New("cannot update completed appointment")
-	}
+		if err := checkVersion(appointment, expectedVersion); err != nil {
+			return err
+		}
 
-	if appointment.Status() == entities.StatusCancelled {
-		return nil, errors.New("cannot update cancelled appointment")
-	}
+		if appointment.SeriesID() != "" {
+			return fmt.Errorf("%w: change it through appointment series %s", ErrSeriesOccurrence, appointment.SeriesID())
+		}
+
+		// Check if appointment can be updated
+		if !appointment.Status().IsActive() {
+			return domainerrors.Conflict("cannot update " + string(appointment.Status()) + " appointment")
+		}
+
+		before := snapshotAppointment(appointment)
 
-	// Check if time is being updated
-	if request.StartTime != nil || request.EndTime != nil {
-		startTime := appointment.TimeRange().StartTime()
-		endTime := appointment.TimeRange().EndTime()
+		if request.Title != nil {
+			if err := appointment.Retitle(*request.Title); err != nil {
+				return domainerrors.AtField("title", "", err)
+			}
+		}
 
-		if request.StartTime != nil {
-			startTime = *request.StartTime
+		if request.Description != nil {
+			if err := appointment.Describe(*request.Description); err != nil {
+				return domainerrors.AtField("description", "", err)
+			}
 		}
-		if request.EndTime != nil {
-			endTime = *request.EndTime
+
+		if request.Location != nil {
+			appointment.Relocate(*request.Location)
 		}
 
-		// Validate new time range
-		newTimeRange, err := valueobjects.NewTimeRange(startTime, endTime)
-		if err != nil {
-			return nil, errors.New("invalid time range: " + err.Error())
+		if request.Tags != nil {
+			if err := appointment.SetTags(request.Tags); err != nil {
+				return domainerrors.AtField("tags", "", err)
+			}
 		}
 
-		// Check for conflicts with new time
-		for _, attendeeID := range appointment.Attendees() {
-			schedule, err := uc.scheduleRepo.FindByOwnerID(attendeeID)
+		if request.Metadata != nil {
+			metadata, err := parseMetadata(request.Metadata)
 			if err != nil {
-				continue
+				return err
 			}
+			if err := appointment.SetMetadata(metadata); err != nil {
+				return err
+			}
+		}
 
-			// Temporarily remove this appointment from schedule to check conflicts
-			schedule.RemoveAppointment(appointmentID)
-			conflictResult := uc.conflictDetector.DetectConflicts(schedule, newTimeRange)
-			// Add it back
-			schedule.AddAppointment(appointment)
+		// Check if time is being updated
+		if request.StartTime != nil || request.EndTime != nil {
+			startTime := appointment.TimeRange().StartTime()
+			endTime := appointment.TimeRange().EndTime()
 
-			if conflictResult.HasConflict {
-				return nil, errors.New("updated time conflicts with existing schedule for participant " + attendeeID)
+			if request.StartTime != nil {
+				startTime = *request.StartTime
 			}
+			if request.EndTime != nil {
+				endTime = *request.EndTime
+			}
+
+			// Validate new time range
+			newTimeRange, err := valueobjects.NewTimeRange(startTime, endTime)
+			if err != nil {
+				return domainerrors.AtField("end_time", "invalid time range", err)
+			}
+			appointment.Reschedule(newTimeRange)
 		}
 
-		// Update the appointment's time
-		appointment.Reschedule(newTimeRange)
-	}
+		if request.Attendees != nil {
+			attendees, err := parseAttendees(request.Attendees)
+			if err != nil {
+				return err
+			}
+			if err := appointment.ReplaceAttendees(attendees); err != nil {
+				return domainerrors.AtField("attendees", "invalid attendees", err)
+			}
+		}
 
-	// Update other fields (this would require updating the appointment entity)
-	// For now, we'll assume the appointment entity has update methods
+		// After the attendees, so a new organizer can be one of them
+		if request.OrganizerID != nil {
+			if err := appointment.SetOrganizer(*request.OrganizerID); err != nil {
+				return domainerrors.AtField("organizer_id", "invalid organizer", err)
+			}
+		}
+
+		if err := uc.rebook(repos, appointment, before); err != nil {
+			return err
+		}
+
+		// Save updated appointment
+		if err := repos.Appointments.Update(appointment); err != nil {
+			return internalError("failed to update appointment", err)
+		}
 
-	// Save updated appointment
-	err = uc.appointmentRepo.Update(appointment)
+		changes = changesSince(before, appointment)
+		return nil
+	})
 	if err != nil {
-		return nil, errors.New("failed to update appointment: " + err.Error())
+		return nil, suggestAlternatives(preconditionError(err, expectedVersion), uc.unitOfWork, uc.timeFinder, appointment, uc.alternatives)
 	}
 
 	// Send notification
-	err = uc.notificationGateway.SendAppointmentUpdated(appointment)
-	if err != nil {
-		// Log error but don't fail the operation
+	if len(changes) > 0 {
+		err = uc.notificationGateway.SendAppointmentUpdated(appointment, changes)
+		if err != nil {
+			// Log error but don't fail the operation
+		}
 	}
 
 	return &dto.AppointmentResponse{
-		ID:        appointment.ID(),
-		Title:     appointment.Title(),
-		StartTime: appointment.TimeRange().StartTime(),
-		EndTime:   appointment.TimeRange().EndTime(),
-		Duration:  appointment.TimeRange().Duration().String(),
-		Attendees: appointment.Attendees(),
-		Location:  appointment.Location(),
-		Status:    appointment.Status(),
-		CreatedAt: appointment.CreatedAt(),
-		UpdatedAt: appointment.UpdatedAt(),
+		ID:          appointment.ID(),
+		Title:       appointment.Title(),
+		Description: appointment.Description(),
+		StartTime:   appointment.TimeRange().StartTime(),
+		EndTime:     appointment.TimeRange().EndTime(),
+		Duration:    appointment.TimeRange().Duration().String(),
+		Attendees:   attendeeResponses(appointment.Attendees()),
+		OrganizerID: appointment.OrganizerID(),
+		Location:    appointment.Location(),
+		Tags:        appointment.Tags(),
+		Metadata:    metadataResponse(appointment.Metadata()),
+		Status:      appointment.Status(),
+		CreatedAt:   appointment.CreatedAt(),
+		UpdatedAt:   appointment.UpdatedAt(),
+		Version:     appointment.Version(),
+
+		StatusHistory: statusTransitionResponses(appointment.StatusHistory()),
 	}, nil
 }
 
-func (uc *UpdateAppointmentUseCase) Cancel(appointmentID string) error {
-	// Find existing appointment
-	appointment, err := uc.appointmentRepo.FindByID(appointmentID)
-	if err != nil {
-		return errors.New("appointment not found: " + err.Error())
-	}
+// Cancel cancels the appointment and frees the attendees' time. request
+// records who cancelled it and why.
+func (uc *UpdateAppointmentUseCase) Cancel(appointmentID string, request dto.ChangeAppointmentStatusRequest, expectedVersion *int) error {
+	var appointment *entities.Appointment
+	err := uc.unitOfWork.Do(func(repos TxRepositories) error {
+		// Find existing appointment
+		var err error
+		appointment, err = repos.Appointments.FindByID(appointmentID)
+		if err != nil {
+			return lookupError(err, ErrAppointmentNotFound, appointmentID)
+		}
 
-	// Check if appointment can be cancelled
-	if appointment.Status() == entities.StatusCompleted {
-		return errors.New("cannot cancel completed appointment")
-	}
+		if err := checkVersion(appointment, expectedVersion); err != nil {
+			return err
+		}
 
-	if appointment.Status() == entities.StatusCancelled {
-		return errors.New("appointment is already cancelled")
-	}
+		if appointment.SeriesID() != "" {
+			return fmt.Errorf("%w: change it through appointment series %s", ErrSeriesOccurrence, appointment.SeriesID())
+		}
+
+		// Cancel the appointment
+		if err := appointment.Cancel(request.ChangedBy, request.Reason); err != nil {
+			return err
+		}
+
+		// Save updated appointment
+		if err := repos.Appointments.Update(appointment); err != nil {
+			return internalError("failed to cancel appointment", err)
+		}
+
+		// Remove from participants' schedules
+		for _, attendeeID := range lockOrder(appointment.AttendeeIDs()) {
+			schedule, err := findSchedule(repos.Schedules, attendeeID)
+			if err != nil {
+				return err
+			}
+			if schedule == nil {
+				continue
+			}
+
+			if err := schedule.RemoveAppointment(appointmentID); err != nil {
+				continue // Not on this schedule
+			}
+
+			if err := repos.Schedules.Save(schedule); err != nil {
+				return internalError("failed to save schedule of participant "+attendeeID, err)
+			}
+		}
 
-	// Cancel the appointment
-	appointment.Cancel()
+		return nil
+	})
+	if err != nil {
+		return preconditionError(err, expectedVersion)
+	}
 
-	// Save updated appointment
-	err = uc.appointmentRepo.Update(appointment)
+	// Send notification
+	err = uc.notificationGateway.SendAppointmentCancelled(appointment)
 	if err != nil {
-		return errors.New("failed to cancel appointment: " + err.Error())
+		// Log error but don't fail the operation
 	}
 
-	// Remove from participants' schedules
-	for _, attendeeID := range appointment.Attendees() {
-		schedule, err := uc.scheduleRepo.FindByOwnerID(attendeeID)
+	return nil
+}
+
+// rebook brings the attendees' schedules in line with the updated
+// appointment. Removed attendees have it taken off their schedule. Added
+// attendees, and attendees whose time it takes up at a new time or now takes
+// up when it didn't before, fail the update with every conflict they have.
+func (uc *UpdateAppointmentUseCase) rebook(repos TxRepositories, appointment *entities.Appointment, before appointmentSnapshot) error {
+	var conflicts conflictCollector
+	moved := !before.timeRange.StartTime().Equal(appointment.TimeRange().StartTime()) ||
+		!before.timeRange.EndTime().Equal(appointment.TimeRange().EndTime())
+
+	participantIDs := append(before.attendeeIDs(), appointment.AttendeeIDs()...)
+	for _, participantID := range lockOrder(participantIDs) {
+		schedule, err := findSchedule(repos.Schedules, participantID)
 		if err != nil {
-			continue
+			return err
+		}
+		if schedule == nil {
+			continue // The participant has no schedule yet
 		}
 
-		err = schedule.RemoveAppointment(appointmentID)
-		if err != nil {
+		previous, wasAttendee := before.attendee(participantID)
+		if _, isAttendee := appointment.Attendee(participantID); !isAttendee {
+			if schedule.RemoveAppointment(appointment.ID()) != nil {
+				continue // Not on this schedule
+			}
+			if err := repos.Schedules.Save(schedule); err != nil {
+				return internalError("failed to save schedule of participant "+participantID, err)
+			}
 			continue
 		}
 
-		err = uc.scheduleRepo.Save(schedule)
-		if err != nil {
+		busy := schedule.Blocks(appointment)
+		if wasAttendee && !moved && schedule.ConflictPolicy().BlocksAttendee(appointment.Status(), previous) == busy {
 			continue
 		}
-	}
 
-	// Send notification
-	err = uc.notificationGateway.SendAppointmentCancelled(appointment)
-	if err != nil {
-		// Log error but don't fail the operation
+		// Take this appointment off the schedule so it doesn't conflict with itself
+		schedule.RemoveAppointment(appointment.ID())
+		if busy {
+			conflictResult := uc.conflictDetector.DetectConflicts(schedule, appointment.TimeRange())
+			if conflictResult.HasConflict {
+				conflicts.add(participantID, appointment.TimeRange(), conflictResult)
+				continue
+			}
+		}
+
+		if err := schedule.AddAppointment(appointment); err != nil {
+			return fmt.Errorf("failed to update schedule of participant %s: %w", participantID, err)
+		}
+
+		if err := repos.Schedules.Save(schedule); err != nil {
+			return internalError("failed to save schedule of participant "+participantID, err)
+		}
 	}
 
+	return conflicts.err()
+}
+
+func checkVersion(appointment *entities.Appointment, expectedVersion *int) error {
+	if expectedVersion != nil && *expectedVersion != appointment.Version() {
+		return fmt.Errorf("%w: appointment is at version %d, expected %d", ErrPreconditionFailed, appointment.Version(), *expectedVersion)
+	}
 	return nil
 }
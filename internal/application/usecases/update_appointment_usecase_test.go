@@ -0,0 +1,220 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+// recordingNotificationGateway keeps the notifications it is asked to send.
+type recordingNotificationGateway struct {
+	created   []*entities.Appointment
+	updates   [][]usecases.AppointmentChange
+	cancelled []*entities.Appointment
+
+	seriesCreated [][]*entities.Appointment // The occurrences of each
+	seriesUpdates []recordedSeriesUpdate
+}
+
+type recordedSeriesUpdate struct {
+	changes     []usecases.AppointmentChange
+	occurrences []*entities.Appointment
+}
+
+func (g *recordingNotificationGateway) SendAppointmentCreated(appointment *entities.Appointment) error {
+	g.created = append(g.created, appointment)
+	return nil
+}
+
+func (g *recordingNotificationGateway) SendAppointmentUpdated(_ *entities.Appointment, changes []usecases.AppointmentChange) error {
+	g.updates = append(g.updates, changes)
+	return nil
+}
+
+func (g *recordingNotificationGateway) SendAppointmentCancelled(appointment *entities.Appointment) error {
+	g.cancelled = append(g.cancelled, appointment)
+	return nil
+}
+
+func (g *recordingNotificationGateway) SendAppointmentSeriesCreated(_ *entities.AppointmentSeries, occurrences []*entities.Appointment) error {
+	g.seriesCreated = append(g.seriesCreated, occurrences)
+	return nil
+}
+
+func (g *recordingNotificationGateway) SendAppointmentSeriesUpdated(_ *entities.AppointmentSeries, changes []usecases.AppointmentChange, occurrences []*entities.Appointment) error {
+	g.seriesUpdates = append(g.seriesUpdates, recordedSeriesUpdate{changes: changes, occurrences: occurrences})
+	return nil
+}
+
+type updateFixture struct {
+	scheduleFixture
+	notifications *recordingNotificationGateway
+	book          *usecases.CreateAppointmentUseCase
+	update        *usecases.UpdateAppointmentUseCase
+}
+
+// newUpdateFixture gives alice, bob, carol and dave UTC schedules and books
+// alice and bob into a review from 10:00 to 11:00 on Monday 2030-03-04.
+// Carol is busy from 10:30.
+func newUpdateFixture(t *testing.T) (updateFixture, string) {
+	t.Helper()
+
+	f := updateFixture{scheduleFixture: newScheduleFixture(t), notifications: &recordingNotificationGateway{}}
+	for _, ownerID := range []string{"alice", "bob", "carol", "dave"} {
+		_, err := f.create.Execute(dto.CreateScheduleRequest{OwnerID: ownerID, Timezone: "UTC"})
+		require.NoError(t, err)
+	}
+
+	detector := services.NewConflictDetectionService(nil)
+	f.book = usecases.NewCreateAppointmentUseCase(f.unitOfWork, noopNotificationGateway{}, detector, nil, 0)
+	f.update = usecases.NewUpdateAppointmentUseCase(f.unitOfWork, f.notifications, detector, nil, 0)
+
+	start := time.Date(2030, 3, 4, 10, 0, 0, 0, time.UTC)
+	_, err := f.book.Execute(dto.CreateAppointmentRequest{
+		Title:     "Busy",
+		StartTime: start.Add(30 * time.Minute),
+		EndTime:   start.Add(90 * time.Minute),
+		Attendees: []dto.AttendeeRequest{{ParticipantID: "carol"}},
+	})
+	require.NoError(t, err)
+
+	response, err := f.book.Execute(dto.CreateAppointmentRequest{
+		Title:     "Review",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Attendees: []dto.AttendeeRequest{{ParticipantID: "alice"}, {ParticipantID: "bob"}},
+		Location:  "Room 1",
+	})
+	require.NoError(t, err)
+	return f, response.ID
+}
+
+// booked reports whether appointmentID is on ownerID's schedule.
+func (f updateFixture) booked(t *testing.T, ownerID, appointmentID string) bool {
+	t.Helper()
+
+	schedule, err := f.schedules.FindByOwnerID(ownerID)
+	require.NoError(t, err)
+	for _, appointment := range schedule.Appointments() {
+		if appointment.ID() == appointmentID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUpdateAppointmentAttendees(t *testing.T) {
+	t.Run("added attendee with a conflict", func(t *testing.T) {
+		f, id := newUpdateFixture(t)
+
+		_, err := f.update.Execute(id, dto.UpdateAppointmentRequest{
+			Attendees: []dto.AttendeeRequest{{ParticipantID: "alice"}, {ParticipantID: "bob"}, {ParticipantID: "carol"}},
+		}, nil)
+		var conflictErr *usecases.ConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		require.Len(t, conflictErr.Conflicts, 1)
+		assert.Equal(t, "carol", conflictErr.Conflicts[0].ParticipantID)
+
+		// Nothing was saved
+		stored, err := f.appointments.FindByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "bob"}, stored.AttendeeIDs())
+		assert.False(t, f.booked(t, "carol", id))
+		assert.Empty(t, f.notifications.updates)
+	})
+
+	t.Run("added attendee without a conflict", func(t *testing.T) {
+		f, id := newUpdateFixture(t)
+
+		response, err := f.update.Execute(id, dto.UpdateAppointmentRequest{
+			Attendees: []dto.AttendeeRequest{{ParticipantID: "alice"}, {ParticipantID: "bob"}, {ParticipantID: "dave"}},
+		}, nil)
+		require.NoError(t, err)
+		assert.Len(t, response.Attendees, 3)
+		assert.True(t, f.booked(t, "dave", id))
+		assert.True(t, f.booked(t, "alice", id))
+		assert.True(t, f.booked(t, "bob", id))
+	})
+
+	t.Run("removed attendee", func(t *testing.T) {
+		f, id := newUpdateFixture(t)
+
+		_, err := f.update.Execute(id, dto.UpdateAppointmentRequest{
+			Attendees: []dto.AttendeeRequest{{ParticipantID: "alice"}},
+		}, nil)
+		require.NoError(t, err)
+		assert.False(t, f.booked(t, "bob", id))
+		assert.True(t, f.booked(t, "alice", id))
+	})
+}
+
+func TestUpdateAppointmentNotifiesChanges(t *testing.T) {
+	f, id := newUpdateFixture(t)
+
+	title, location := "Quarterly review", "Room 2"
+	_, err := f.update.Execute(id, dto.UpdateAppointmentRequest{
+		Title:     &title,
+		Location:  &location,
+		Attendees: []dto.AttendeeRequest{{ParticipantID: "alice"}, {ParticipantID: "dave", Role: "optional"}},
+	}, nil)
+	require.NoError(t, err)
+
+	require.Len(t, f.notifications.updates, 1)
+	assert.Equal(t, []usecases.AppointmentChange{
+		{Field: "title", From: "Review", To: "Quarterly review"},
+		{Field: "location", From: "Room 1", To: "Room 2"},
+		{
+			Field: "attendees",
+			From:  "alice (required, needs-action), bob (required, needs-action)",
+			To:    "alice (required, needs-action), dave (optional, needs-action)",
+		},
+	}, f.notifications.updates[0])
+
+	// An update that changes nothing sends nothing
+	_, err = f.update.Execute(id, dto.UpdateAppointmentRequest{Title: &title}, nil)
+	require.NoError(t, err)
+	assert.Len(t, f.notifications.updates, 1)
+}
+
+// racingUnitOfWork fails appointment writes as if another request had saved
+// the appointment first.
+type racingUnitOfWork struct {
+	usecases.UnitOfWork
+}
+
+func (u racingUnitOfWork) Do(fn func(repos usecases.TxRepositories) error) error {
+	return u.UnitOfWork.Do(func(repos usecases.TxRepositories) error {
+		repos.Appointments = racingAppointmentRepository{repos.Appointments}
+		return fn(repos)
+	})
+}
+
+type racingAppointmentRepository struct {
+	usecases.AppointmentRepository
+}
+
+func (racingAppointmentRepository) Update(*entities.Appointment) error {
+	return usecases.ErrVersionConflict
+}
+
+func TestUpdateAppointmentLostRace(t *testing.T) {
+	f, id := newUpdateFixture(t)
+	update := usecases.NewUpdateAppointmentUseCase(racingUnitOfWork{f.unitOfWork}, f.notifications, services.NewConflictDetectionService(nil), nil, 0)
+	stored, err := f.appointments.FindByID(id)
+	require.NoError(t, err)
+
+	title := "Quarterly review"
+	version := stored.Version()
+	_, err = update.Execute(id, dto.UpdateAppointmentRequest{Title: &title}, &version)
+	assert.ErrorIs(t, err, usecases.ErrPreconditionFailed, "the version the caller saw is gone")
+
+	_, err = update.Execute(id, dto.UpdateAppointmentRequest{Title: &title}, nil)
+	assert.NotErrorIs(t, err, usecases.ErrPreconditionFailed)
+	assert.ErrorIs(t, err, usecases.ErrVersionConflict)
+}
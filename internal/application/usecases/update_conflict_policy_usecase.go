@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+type UpdateConflictPolicyUseCase struct {
+	unitOfWork UnitOfWork
+}
+
+func NewUpdateConflictPolicyUseCase(unitOfWork UnitOfWork) *UpdateConflictPolicyUseCase {
+	return &UpdateConflictPolicyUseCase{
+		unitOfWork: unitOfWork,
+	}
+}
+
+// Execute replaces the schedule's conflict policy. It applies to later
+// bookings; appointments already on the schedule stay where they are.
+func (uc *UpdateConflictPolicyUseCase) Execute(ownerID string, request dto.UpdateConflictPolicyRequest) (*entities.Schedule, error) {
+	policy, err := parseConflictPolicy(dto.ConflictPolicyDTO(request))
+	if err != nil {
+		return nil, domainerrors.AtField("", "invalid conflict policy", err)
+	}
+
+	var schedule *entities.Schedule
+	err = uc.unitOfWork.Do(func(repos TxRepositories) error {
+		found, err := repos.Schedules.FindByOwnerID(ownerID)
+		if err != nil {
+			return lookupError(err, ErrScheduleNotFound, ownerID)
+		}
+
+		if err := found.SetConflictPolicy(policy); err != nil {
+			return err
+		}
+		schedule = found
+		return repos.Schedules.Save(found)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
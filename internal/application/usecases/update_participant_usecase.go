@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"errors"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+type UpdateParticipantUseCase struct {
+	participantRepo  ParticipantRepository
+	timezoneResolver TimezoneResolver
+	holidays         *services.HolidayCalendarService
+}
+
+func NewUpdateParticipantUseCase(
+	participantRepo ParticipantRepository,
+	timezoneResolver TimezoneResolver,
+	holidays *services.HolidayCalendarService,
+) *UpdateParticipantUseCase {
+	return &UpdateParticipantUseCase{
+		participantRepo:  participantRepo,
+		timezoneResolver: timezoneResolver,
+		holidays:         holidays,
+	}
+}
+
+func (uc *UpdateParticipantUseCase) Execute(participantID string, request dto.UpdateParticipantRequest) (*dto.ParticipantResponse, error) {
+	participant, err := uc.participantRepo.FindByID(participantID)
+	if err != nil {
+		return nil, lookupError(err, ErrParticipantNotFound, participantID)
+	}
+
+	if request.Name != nil {
+		if err := participant.Rename(*request.Name); err != nil {
+			return nil, domainerrors.AtField("name", "", err)
+		}
+	}
+
+	if request.Email != nil && *request.Email != participant.Email() {
+		if existing, err := uc.participantRepo.FindByEmail(*request.Email); err == nil && existing != nil && existing.ID() != participant.ID() {
+			return nil, ErrDuplicateEmail
+		} else if err != nil && !errors.Is(err, ErrParticipantNotFound) {
+			return nil, internalError("failed to look up email", err)
+		}
+
+		if err := participant.ChangeEmail(*request.Email); err != nil {
+			return nil, domainerrors.AtField("email", "", err)
+		}
+	}
+
+	if request.Timezone != nil {
+		location, err := uc.timezoneResolver.GetLocation(*request.Timezone)
+		if err != nil {
+			return nil, domainerrors.AtField("timezone", "", err)
+		}
+		participant.ChangeTimezone(location)
+	}
+
+	if request.HolidayCalendars != nil {
+		if err := checkHolidayCalendars(uc.holidays, *request.HolidayCalendars); err != nil {
+			return nil, domainerrors.AtField("holiday_calendars", "", err)
+		}
+		participant.SetHolidayCalendars(*request.HolidayCalendars)
+	}
+
+	if err := uc.participantRepo.Update(participant); err != nil {
+		return nil, internalError("failed to update participant", err)
+	}
+
+	response := newParticipantResponse(participant)
+	return &response, nil
+}
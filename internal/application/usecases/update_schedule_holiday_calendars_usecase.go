@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+)
+
+type UpdateScheduleHolidayCalendarsUseCase struct {
+	unitOfWork UnitOfWork
+	holidays   *services.HolidayCalendarService
+}
+
+func NewUpdateScheduleHolidayCalendarsUseCase(unitOfWork UnitOfWork, holidays *services.HolidayCalendarService) *UpdateScheduleHolidayCalendarsUseCase {
+	return &UpdateScheduleHolidayCalendarsUseCase{
+		unitOfWork: unitOfWork,
+		holidays:   holidays,
+	}
+}
+
+// Execute replaces the schedule's holiday calendar subscriptions. Existing
+// appointments on newly observed holidays are left in place.
+func (uc *UpdateScheduleHolidayCalendarsUseCase) Execute(ownerID string, request dto.UpdateHolidayCalendarsRequest) (*entities.Schedule, error) {
+	if err := checkHolidayCalendars(uc.holidays, request.Regions); err != nil {
+		return nil, domainerrors.AtField("regions", "", err)
+	}
+
+	var schedule *entities.Schedule
+	err := uc.unitOfWork.Do(func(repos TxRepositories) error {
+		found, err := repos.Schedules.FindByOwnerID(ownerID)
+		if err != nil {
+			return lookupError(err, ErrScheduleNotFound, ownerID)
+		}
+
+		found.SetHolidayCalendars(request.Regions)
+		schedule = found
+		return repos.Schedules.Save(found)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+type UpdateWorkingHoursUseCase struct {
+	unitOfWork UnitOfWork
+}
+
+func NewUpdateWorkingHoursUseCase(unitOfWork UnitOfWork) *UpdateWorkingHoursUseCase {
+	return &UpdateWorkingHoursUseCase{
+		unitOfWork: unitOfWork,
+	}
+}
+
+// Execute replaces the whole working-hours template, overrides included.
+func (uc *UpdateWorkingHoursUseCase) Execute(ownerID string, request dto.UpdateWorkingHoursRequest) (*entities.Schedule, error) {
+	workingHours, err := parseWorkingHours(request.WorkingHoursDTO)
+	if err != nil {
+		return nil, domainerrors.AtField("", "invalid working hours", err)
+	}
+
+	return uc.modify(ownerID, func(valueobjects.WorkingHours) (valueobjects.WorkingHours, error) {
+		return workingHours, nil
+	})
+}
+
+// SetOverride replaces the template on one local date. No intervals makes
+// the date a day off.
+func (uc *UpdateWorkingHoursUseCase) SetOverride(ownerID, date string, request dto.SetWorkingHoursOverrideRequest) (*entities.Schedule, error) {
+	if _, err := time.Parse(valueobjects.DateLayout, date); err != nil {
+		return nil, domainerrors.Invalid("invalid override date, expected YYYY-MM-DD: " + date)
+	}
+
+	intervals, err := parseWorkingIntervals(request.Intervals)
+	if err != nil {
+		return nil, domainerrors.AtField("intervals", "invalid working hours", err)
+	}
+
+	return uc.modify(ownerID, func(current valueobjects.WorkingHours) (valueobjects.WorkingHours, error) {
+		// The date is valid, so only the intervals can be rejected
+		workingHours, err := current.WithOverride(date, intervals)
+		if err != nil {
+			return current, domainerrors.AtField("intervals", "invalid working hours", err)
+		}
+		return workingHours, nil
+	})
+}
+
+// RemoveOverride makes the date follow the weekly template again.
+func (uc *UpdateWorkingHoursUseCase) RemoveOverride(ownerID, date string) (*entities.Schedule, error) {
+	return uc.modify(ownerID, func(current valueobjects.WorkingHours) (valueobjects.WorkingHours, error) {
+		if !current.HasOverride(date) {
+			return current, ErrWorkingHoursOverrideNotFound
+		}
+		return current.WithoutOverride(date), nil
+	})
+}
+
+func (uc *UpdateWorkingHoursUseCase) modify(ownerID string, change func(valueobjects.WorkingHours) (valueobjects.WorkingHours, error)) (*entities.Schedule, error) {
+	var schedule *entities.Schedule
+	err := uc.unitOfWork.Do(func(repos TxRepositories) error {
+		found, err := repos.Schedules.FindByOwnerID(ownerID)
+		if err != nil {
+			return lookupError(err, ErrScheduleNotFound, ownerID)
+		}
+
+		workingHours, err := change(found.WorkingHours())
+		if err != nil {
+			return err
+		}
+
+		found.SetWorkingHours(workingHours)
+		schedule = found
+		return repos.Schedules.Save(found)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,124 @@
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// TestWorkingHoursOverrides gives a Berlin schedule a weekly template and
+// overrides, and checks which Tuesday meetings conflict with working hours.
+// 2030-03-19 is a Tuesday.
+func TestWorkingHoursOverrides(t *testing.T) {
+	f := newScheduleFixture(t)
+	_, err := f.create.Execute(dto.CreateScheduleRequest{
+		OwnerID:  "alice",
+		Timezone: "Europe/Berlin",
+		WorkingHours: &dto.WorkingHoursDTO{
+			Weekly: map[string][]dto.WorkingIntervalDTO{
+				"Tuesday": {{Start: "13:00", End: "17:30"}, {Start: "09:00", End: "12:00"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	meeting := func(day, hour int) valueobjects.TimeRange {
+		start := time.Date(2030, time.March, day, hour, 0, 0, 0, berlin)
+		timeRange, err := valueobjects.NewTimeRange(start, start.Add(time.Hour))
+		require.NoError(t, err)
+		return timeRange
+	}
+
+	detector := services.NewConflictDetectionService(nil)
+	outsideWorkingHours := func(timeRange valueobjects.TimeRange) bool {
+		schedule, err := f.schedules.FindByOwnerID("alice")
+		require.NoError(t, err)
+		for _, conflict := range detector.DetectConflicts(schedule, timeRange).Conflicts {
+			if conflict.Type == services.ConflictTypeWorkingHours {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.False(t, outsideWorkingHours(meeting(19, 9)))
+	assert.True(t, outsideWorkingHours(meeting(19, 12)), "lunch break")
+	assert.True(t, outsideWorkingHours(meeting(20, 9)), "no hours on Wednesday")
+
+	update := usecases.NewUpdateWorkingHoursUseCase(f.unitOfWork)
+
+	t.Run("override", func(t *testing.T) {
+		schedule, err := update.SetOverride("alice", "2030-03-19", dto.SetWorkingHoursOverrideRequest{
+			Intervals: []dto.WorkingIntervalDTO{{Start: "12:00", End: "14:00"}},
+		})
+		require.NoError(t, err)
+		assert.True(t, schedule.WorkingHours().HasOverride("2030-03-19"))
+
+		assert.False(t, outsideWorkingHours(meeting(19, 12)))
+		assert.True(t, outsideWorkingHours(meeting(19, 9)), "the override replaces the template")
+		assert.False(t, outsideWorkingHours(meeting(26, 9)), "other Tuesdays follow the template")
+
+		_, err = update.SetOverride("alice", "2030-03-26", dto.SetWorkingHoursOverrideRequest{})
+		require.NoError(t, err)
+		assert.True(t, outsideWorkingHours(meeting(26, 9)), "day off")
+	})
+
+	t.Run("remove override", func(t *testing.T) {
+		_, err := update.RemoveOverride("alice", "2030-03-19")
+		require.NoError(t, err)
+		assert.False(t, outsideWorkingHours(meeting(19, 9)))
+		assert.True(t, outsideWorkingHours(meeting(26, 9)), "other overrides are kept")
+
+		_, err = update.RemoveOverride("alice", "2030-03-19")
+		assert.ErrorIs(t, err, usecases.ErrWorkingHoursOverrideNotFound)
+	})
+
+	t.Run("replace template", func(t *testing.T) {
+		schedule, err := update.Execute("alice", dto.UpdateWorkingHoursRequest{WorkingHoursDTO: dto.WorkingHoursDTO{
+			Weekly: map[string][]dto.WorkingIntervalDTO{"wednesday": {{Start: "08:00", End: "24:00"}}},
+		}})
+		require.NoError(t, err)
+		assert.Empty(t, schedule.WorkingHours().Overrides(), "overrides are replaced too")
+
+		assert.False(t, outsideWorkingHours(meeting(20, 23)))
+		assert.True(t, outsideWorkingHours(meeting(26, 9)))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := update.SetOverride("alice", "2030-03-19", dto.SetWorkingHoursOverrideRequest{
+			Intervals: []dto.WorkingIntervalDTO{{Start: "09:00", End: "12:00"}, {Start: "11:00", End: "13:00"}},
+		})
+		assertInvalidField(t, err, "intervals")
+
+		_, err = update.SetOverride("alice", "2030-03-19", dto.SetWorkingHoursOverrideRequest{
+			Intervals: []dto.WorkingIntervalDTO{{Start: "17:00", End: "09:00"}},
+		})
+		assertInvalidField(t, err, "intervals")
+
+		_, err = update.Execute("alice", dto.UpdateWorkingHoursRequest{WorkingHoursDTO: dto.WorkingHoursDTO{
+			Overrides: map[string][]dto.WorkingIntervalDTO{"2030-03-19": {{Start: "9am", End: "5pm"}}},
+		}})
+		assertInvalidField(t, err, "overrides.2030-03-19")
+
+		_, err = update.Execute("alice", dto.UpdateWorkingHoursRequest{WorkingHoursDTO: dto.WorkingHoursDTO{
+			Overrides: map[string][]dto.WorkingIntervalDTO{"19.03.2030": {}},
+		}})
+		assertInvalidField(t, err, "overrides.19.03.2030")
+
+		_, err = update.SetOverride("alice", "19.03.2030", dto.SetWorkingHoursOverrideRequest{})
+		kind, _ := domainerrors.KindOf(err)
+		assert.Equal(t, domainerrors.KindValidation, kind)
+
+		_, err = update.SetOverride("bob", "2030-03-19", dto.SetWorkingHoursOverrideRequest{})
+		assert.ErrorIs(t, err, usecases.ErrScheduleNotFound)
+	})
+}
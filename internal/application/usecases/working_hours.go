@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+func parseWorkingHours(request dto.WorkingHoursDTO) (valueobjects.WorkingHours, error) {
+	weekly := make(map[time.Weekday][]valueobjects.WorkingInterval, len(request.Weekly))
+	for name, intervals := range request.Weekly {
+		weekday, err := valueobjects.ParseWeekday(name)
+		if err != nil {
+			return valueobjects.WorkingHours{}, domainerrors.AtField("weekly."+name, "", err)
+		}
+
+		parsed, err := parseWorkingIntervals(intervals)
+		if err != nil {
+			return valueobjects.WorkingHours{}, domainerrors.AtField("weekly."+name, name, err)
+		}
+		weekly[weekday] = parsed
+	}
+
+	workingHours, err := valueobjects.NewWorkingHours(weekly)
+	if err != nil {
+		return valueobjects.WorkingHours{}, err
+	}
+
+	for date, intervals := range request.Overrides {
+		parsed, err := parseWorkingIntervals(intervals)
+		if err != nil {
+			return valueobjects.WorkingHours{}, domainerrors.AtField("overrides."+date, date, err)
+		}
+
+		workingHours, err = workingHours.WithOverride(date, parsed)
+		if err != nil {
+			return valueobjects.WorkingHours{}, domainerrors.AtField("overrides."+date, "", err)
+		}
+	}
+
+	return workingHours, nil
+}
+
+func parseWorkingIntervals(intervals []dto.WorkingIntervalDTO) ([]valueobjects.WorkingInterval, error) {
+	result := make([]valueobjects.WorkingInterval, 0, len(intervals))
+	for _, interval := range intervals {
+		start, err := valueobjects.ParseClockTime(interval.Start)
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := valueobjects.ParseClockTime(interval.End)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := valueobjects.NewWorkingInterval(start, end)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, parsed)
+	}
+	return result, nil
+}
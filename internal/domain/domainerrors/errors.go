@@ -0,0 +1,106 @@
+// Package domainerrors classifies failures, so callers can tell invalid input,
+// a missing entity and a clash with the current state apart without matching
+// on messages.
+package domainerrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Kind string
+
+const (
+	KindValidation Kind = "validation"
+	KindNotFound   Kind = "not_found"
+	KindConflict   Kind = "conflict"
+
+	KindPreconditionFailed Kind = "precondition_failed"
+)
+
+// Error is a classified failure. Field is the path of the offending value,
+// e.g. "attendees[1].role", when a validation error can be pinned to one.
+type Error struct {
+	Kind    Kind
+	Field   string
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Message
+	case e.Message == "":
+		return e.Err.Error()
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Invalid reports input that breaks a domain rule.
+func Invalid(message string) *Error {
+	return &Error{Kind: KindValidation, Message: message}
+}
+
+func Invalidf(format string, args ...any) *Error {
+	return Invalid(fmt.Sprintf(format, args...))
+}
+
+// NotFound reports a missing entity.
+func NotFound(message string) *Error {
+	return &Error{Kind: KindNotFound, Message: message}
+}
+
+// Conflict reports a request that is valid on its own but clashes with the
+// current state, like a double booking or a lost concurrent write.
+func Conflict(message string) *Error {
+	return &Error{Kind: KindConflict, Message: message}
+}
+
+// PreconditionFailed reports an entity that is no longer at the version the
+// caller based the request on.
+func PreconditionFailed(message string) *Error {
+	return &Error{Kind: KindPreconditionFailed, Message: message}
+}
+
+// InvalidField reports that the value at field, a path such as
+// "attendees[1].role", is invalid.
+func InvalidField(field, message string) *Error {
+	return &Error{Kind: KindValidation, Field: field, Message: message}
+}
+
+// AtField attributes a validation error to the value at field, prefixing its
+// message unless message is empty. The field is joined with any path err
+// already carries, so "attendees[1]" and "role" become "attendees[1].role".
+// Errors of other kinds keep their kind and only gain the message.
+func AtField(field, message string, err error) error {
+	var invalid *Error
+	if !errors.As(err, &invalid) || invalid.Kind != KindValidation {
+		if message == "" {
+			return err
+		}
+		return fmt.Errorf("%s: %w", message, err)
+	}
+
+	path := field
+	switch {
+	case field == "":
+		path = invalid.Field
+	case invalid.Field != "":
+		path = field + "." + invalid.Field
+	}
+	return &Error{Kind: KindValidation, Field: path, Message: message, Err: err}
+}
+
+// KindOf returns the kind of the outermost classified error in err's chain.
+func KindOf(err error) (Kind, bool) {
+	var classified *Error
+	if !errors.As(err, &classified) {
+		return "", false
+	}
+	return classified.Kind, true
+}
@@ -1,39 +1,37 @@
 package entities
 
 import (
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
 	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 )
 
-type AppointmentStatus string
-
-const (
-	StatusScheduled AppointmentStatus = "scheduled"
-	StatusCancelled AppointmentStatus = "cancelled"
-	StatusCompleted AppointmentStatus = "completed"
-)
-
 type Appointment struct {
 	id          string
 	title       string
+	description string
 	timeRange   valueobjects.TimeRange
-	attendees   []string
+	attendees   []Attendee
 	location    string
+	tags        []string
+	metadata    valueobjects.Metadata
 	status      AppointmentStatus
+	history     []StatusTransition
 	createdAt   time.Time
 	updatedAt   time.Time
+	version     int
 }
 
-func NewAppointment(title string, timeRange valueobjects.TimeRange, attendees []string, location string) (*Appointment, error) {
+// NewAppointment creates a confirmed appointment.
+func NewAppointment(title string, timeRange valueobjects.TimeRange, attendees []Attendee, location string) (*Appointment, error) {
 	if title == "" {
-		return nil, errors.New("appointment title cannot be empty")
+		return nil, domainerrors.Invalid("appointment title cannot be empty")
 	}
 	
-	if len(attendees) == 0 {
-		return nil, errors.New("appointment must have at least one attendee")
+	if err := validateAttendees(attendees); err != nil {
+		return nil, err
 	}
 	
 	now := time.Now()
@@ -43,12 +41,46 @@ func NewAppointment(title string, timeRange valueobjects.TimeRange, attendees []
 		timeRange: timeRange,
 		attendees: attendees,
 		location:  location,
-		status:    StatusScheduled,
+		tags:      make([]string, 0),
+		metadata:  make(valueobjects.Metadata),
+		status:    StatusConfirmed,
+		history:   make([]StatusTransition, 0),
 		createdAt: now,
 		updatedAt: now,
 	}, nil
 }
 
+// NewTentativeAppointment creates an appointment that holds the time until
+// it is confirmed.
+func NewTentativeAppointment(title string, timeRange valueobjects.TimeRange, attendees []Attendee, location string) (*Appointment, error) {
+	appointment, err := NewAppointment(title, timeRange, attendees, location)
+	if err != nil {
+		return nil, err
+	}
+	appointment.status = StatusTentative
+	return appointment, nil
+}
+
+// RestoreAppointment rebuilds an appointment from persisted state without
+// re-applying creation rules.
+func RestoreAppointment(id, title, description string, timeRange valueobjects.TimeRange, attendees []Attendee, location string, tags []string, metadata valueobjects.Metadata, status AppointmentStatus, history []StatusTransition, createdAt, updatedAt time.Time, version int) *Appointment {
+	return &Appointment{
+		id:          id,
+		title:       title,
+		description: description,
+		timeRange:   timeRange,
+		attendees:   attendees,
+		location:    location,
+		tags:        tags,
+		metadata:    metadata,
+		status:      status,
+		history:     history,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+		version:     version,
+	}
+}
+
 func (a *Appointment) ID() string {
 	return a.id
 }
@@ -61,18 +93,77 @@ func (a *Appointment) TimeRange() valueobjects.TimeRange {
 	return a.timeRange
 }
 
-func (a *Appointment) Attendees() []string {
+func (a *Appointment) Attendees() []Attendee {
 	return a.attendees
 }
 
+// AttendeeIDs are the participant IDs of the attendees, in order.
+func (a *Appointment) AttendeeIDs() []string {
+	return attendeeIDs(a.attendees)
+}
+
+func (a *Appointment) Attendee(participantID string) (Attendee, bool) {
+	for _, attendee := range a.attendees {
+		if attendee.participantID == participantID {
+			return attendee, true
+		}
+	}
+	return Attendee{}, false
+}
+
+// Respond records participantID's response to the invitation.
+func (a *Appointment) Respond(participantID string, response ResponseStatus) error {
+	if _, err := ParseResponseStatus(string(response)); err != nil {
+		return err
+	}
+
+	for i, attendee := range a.attendees {
+		if attendee.participantID == participantID {
+			now := time.Now()
+			a.attendees[i].response = response
+			a.attendees[i].respondedAt = &now
+			a.updatedAt = now
+			return nil
+		}
+	}
+	return domainerrors.NotFound("participant " + participantID + " is not an attendee")
+}
+
+// BlocksTimeFor reports whether the appointment takes up participantID's
+// time under the default conflict policy: it isn't cancelled and they are a
+// busy attendee, see Attendee.IsBusy. Schedules with their own policy decide
+// with Schedule.Blocks.
+func (a *Appointment) BlocksTimeFor(participantID string) bool {
+	return DefaultConflictPolicy().Blocks(a, participantID)
+}
+
 func (a *Appointment) Location() string {
 	return a.location
 }
 
+// SeriesID is the ID of the series the appointment is an occurrence of, or
+// empty for a one-off appointment.
+func (a *Appointment) SeriesID() string {
+	seriesID, _, _ := ParseOccurrenceID(a.id)
+	return seriesID
+}
+
+// RecurrenceID is the original start of a series occurrence, which stays the
+// same when the occurrence is moved. It is zero for a one-off appointment.
+func (a *Appointment) RecurrenceID() time.Time {
+	_, recurrenceID, _ := ParseOccurrenceID(a.id)
+	return recurrenceID
+}
+
 func (a *Appointment) Status() AppointmentStatus {
 	return a.status
 }
 
+// StatusHistory lists every status change, oldest first.
+func (a *Appointment) StatusHistory() []StatusTransition {
+	return a.history
+}
+
 func (a *Appointment) CreatedAt() time.Time {
 	return a.createdAt
 }
@@ -81,14 +172,50 @@ func (a *Appointment) UpdatedAt() time.Time {
 	return a.updatedAt
 }
 
-func (a *Appointment) Cancel() {
-	a.status = StatusCancelled
+// Version is the number of times the appointment has been stored. It is 0
+// until the appointment is first saved.
+func (a *Appointment) Version() int {
+	return a.version
+}
+
+// IncrementVersion is called by repositories after a successful write.
+func (a *Appointment) IncrementVersion() {
+	a.version++
+}
+
+func (a *Appointment) Retitle(title string) error {
+	if title == "" {
+		return domainerrors.Invalid("appointment title cannot be empty")
+	}
+	a.title = title
+	a.updatedAt = time.Now()
+	return nil
+}
+
+func (a *Appointment) Relocate(location string) {
+	a.location = location
 	a.updatedAt = time.Now()
 }
 
-func (a *Appointment) Complete() {
-	a.status = StatusCompleted
+// ReplaceAttendees changes who is invited. Participants who stay invited keep
+// their response, even if their role changes.
+func (a *Appointment) ReplaceAttendees(attendees []Attendee) error {
+	if err := validateAttendees(attendees); err != nil {
+		return err
+	}
+
+	replaced := make([]Attendee, len(attendees))
+	for i, attendee := range attendees {
+		if existing, ok := a.Attendee(attendee.participantID); ok {
+			attendee.response = existing.response
+			attendee.respondedAt = existing.respondedAt
+		}
+		replaced[i] = attendee
+	}
+
+	a.attendees = replaced
 	a.updatedAt = time.Now()
+	return nil
 }
 
 func (a *Appointment) Reschedule(newTimeRange valueobjects.TimeRange) {
@@ -0,0 +1,138 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+const (
+	maxDescriptionLength = 10000
+	maxTags              = 20
+	maxTagLength         = 50
+)
+
+// Description is free text such as an agenda, possibly Markdown.
+func (a *Appointment) Description() string {
+	return a.description
+}
+
+// Tags are lower-case labels for grouping and filtering appointments.
+func (a *Appointment) Tags() []string {
+	return a.tags
+}
+
+func (a *Appointment) HasTag(tag string) bool {
+	for _, t := range a.tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Appointment) Metadata() valueobjects.Metadata {
+	return a.metadata
+}
+
+// OrganizerID is the participant ID of the attendee with the organizer
+// role, or empty if there is none.
+func (a *Appointment) OrganizerID() string {
+	for _, attendee := range a.attendees {
+		if attendee.role == RoleOrganizer {
+			return attendee.participantID
+		}
+	}
+	return ""
+}
+
+func (a *Appointment) Describe(description string) error {
+	if len(description) > maxDescriptionLength {
+		return domainerrors.Invalid("appointment description cannot be longer than " + strconv.Itoa(maxDescriptionLength) + " characters")
+	}
+	a.description = description
+	a.updatedAt = time.Now()
+	return nil
+}
+
+// SetTags replaces the tags. Tags are trimmed and lower-cased, and
+// duplicates are dropped.
+func (a *Appointment) SetTags(tags []string) error {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	a.tags = normalized
+	a.updatedAt = time.Now()
+	return nil
+}
+
+// SetMetadata replaces the metadata.
+func (a *Appointment) SetMetadata(metadata valueobjects.Metadata) error {
+	if err := metadata.Validate(); err != nil {
+		return err
+	}
+	a.metadata = metadata.Clone()
+	a.updatedAt = time.Now()
+	return nil
+}
+
+// SetOrganizer makes participantID the organizer, inviting them if they
+// aren't an attendee yet; like a new organizer, they accept unless they have
+// already responded. The previous organizer stays on as a required attendee. An empty participantID leaves the appointment without an
+// organizer.
+func (a *Appointment) SetOrganizer(participantID string) error {
+	attendees := make([]Attendee, 0, len(a.attendees)+1)
+	found := false
+	for _, attendee := range a.attendees {
+		switch {
+		case attendee.participantID == participantID:
+			attendee.role = RoleOrganizer
+			if attendee.response == ResponseNeedsAction {
+				attendee.response = ResponseAccepted
+			}
+			found = true
+		case attendee.role == RoleOrganizer:
+			attendee.role = RoleRequired
+		}
+		attendees = append(attendees, attendee)
+	}
+
+	if !found && participantID != "" {
+		organizer, err := NewAttendee(participantID, RoleOrganizer)
+		if err != nil {
+			return err
+		}
+		attendees = append([]Attendee{organizer}, attendees...)
+	}
+
+	a.attendees = attendees
+	a.updatedAt = time.Now()
+	return nil
+}
+
+// NormalizeTags trims and lower-cases tags and drops duplicates, keeping the
+// first occurrence of each. Tags must be 1 to 50 characters without commas,
+// and there may be at most 20.
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len(tag) > maxTagLength || strings.Contains(tag, ",") {
+			return nil, domainerrors.Invalid("invalid tag: \"" + tag + "\" (tags are 1 to " + strconv.Itoa(maxTagLength) + " characters without commas)")
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+
+	if len(normalized) > maxTags {
+		return nil, domainerrors.Invalid("appointment cannot have more than " + strconv.Itoa(maxTags) + " tags")
+	}
+	return normalized, nil
+}
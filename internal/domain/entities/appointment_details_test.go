@@ -0,0 +1,46 @@
+package entities_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	many := make([]string, 21)
+	for i := range many {
+		many[i] = fmt.Sprintf("tag-%d", i)
+	}
+
+	tests := []struct {
+		name  string
+		tags  []string
+		want  []string
+		valid bool
+	}{
+		{name: "none", tags: nil, want: []string{}, valid: true},
+		{name: "trimmed and lower-cased", tags: []string{" Finance ", "Q1"}, want: []string{"finance", "q1"}, valid: true},
+		{name: "duplicates keep the first", tags: []string{"q1", "Finance", "FINANCE", "q1"}, want: []string{"q1", "finance"}, valid: true},
+		{name: "20 after dropping duplicates", tags: append(append([]string{}, many[:20]...), "TAG-0"), want: many[:20], valid: true},
+		{name: "21 tags", tags: many},
+		{name: "blank", tags: []string{"finance", "  "}},
+		{name: "comma", tags: []string{"finance,q1"}},
+		{name: "50 characters", tags: []string{strings.Repeat("t", 50)}, want: []string{strings.Repeat("t", 50)}, valid: true},
+		{name: "51 characters", tags: []string{strings.Repeat("t", 51)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := entities.NormalizeTags(tt.tags)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,475 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// AppointmentSeries is a recurring appointment stored as an RFC 5545 rule.
+// Its occurrences up to the series horizon are materialized as appointments
+// whose IDs are built by OccurrenceID, so schedules and conflict checks treat
+// them like any other appointment.
+type AppointmentSeries struct {
+	id         string
+	title      string
+	timeRange  valueobjects.TimeRange
+	attendees  []Attendee
+	location   string
+	rule       string
+	timezone   *time.Location
+	localTime  valueobjects.LocalTimePolicy
+	exceptions []time.Time
+	overrides  []OccurrenceOverride
+	horizon    time.Time
+	status     AppointmentStatus
+	createdAt  time.Time
+	updatedAt  time.Time
+	version    int
+}
+
+// NewAppointmentSeries starts a series whose first occurrence is timeRange.
+// rule is an RRULE value that the caller has already validated; it is
+// expanded as wall-clock time in timezone, with localTime resolving times
+// that a DST change skips or repeats.
+func NewAppointmentSeries(title string, timeRange valueobjects.TimeRange, attendees []Attendee, location, rule string, timezone *time.Location, localTime valueobjects.LocalTimePolicy) (*AppointmentSeries, error) {
+	if title == "" {
+		return nil, domainerrors.Invalid("appointment title cannot be empty")
+	}
+
+	if err := validateAttendees(attendees); err != nil {
+		return nil, err
+	}
+
+	if rule == "" {
+		return nil, domainerrors.Invalid("recurrence rule is required")
+	}
+
+	if timezone == nil {
+		timezone = time.UTC
+	}
+
+	now := time.Now()
+	return &AppointmentSeries{
+		id:         uuid.New().String(),
+		title:      title,
+		timeRange:  timeRange,
+		attendees:  attendees,
+		location:   location,
+		rule:       rule,
+		timezone:   timezone,
+		localTime:  localTime.Or(valueobjects.DefaultLocalTimePolicy()),
+		exceptions: make([]time.Time, 0),
+		overrides:  make([]OccurrenceOverride, 0),
+		status:     StatusConfirmed,
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// RestoreAppointmentSeries rebuilds a series from persisted state.
+func RestoreAppointmentSeries(
+	id, title string,
+	timeRange valueobjects.TimeRange,
+	attendees []Attendee,
+	location, rule string,
+	timezone *time.Location,
+	localTime valueobjects.LocalTimePolicy,
+	exceptions []time.Time,
+	overrides []OccurrenceOverride,
+	horizon time.Time,
+	status AppointmentStatus,
+	createdAt, updatedAt time.Time,
+	version int,
+) *AppointmentSeries {
+	return &AppointmentSeries{
+		id:         id,
+		title:      title,
+		timeRange:  timeRange,
+		attendees:  attendees,
+		location:   location,
+		rule:       rule,
+		timezone:   timezone,
+		localTime:  localTime.Or(valueobjects.DefaultLocalTimePolicy()),
+		exceptions: exceptions,
+		overrides:  overrides,
+		horizon:    horizon,
+		status:     status,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+		version:    version,
+	}
+}
+
+func (s *AppointmentSeries) ID() string {
+	return s.id
+}
+
+func (s *AppointmentSeries) Title() string {
+	return s.title
+}
+
+// TimeRange is the first occurrence of the series, its DTSTART and DTEND.
+func (s *AppointmentSeries) TimeRange() valueobjects.TimeRange {
+	return s.timeRange
+}
+
+// Attendees are invited to every occurrence. Each occurrence keeps its own
+// copy, so responses are per occurrence.
+func (s *AppointmentSeries) Attendees() []Attendee {
+	return s.attendees
+}
+
+// AttendeeIDs are the participant IDs of the attendees, in order.
+func (s *AppointmentSeries) AttendeeIDs() []string {
+	return attendeeIDs(s.attendees)
+}
+
+func (s *AppointmentSeries) Location() string {
+	return s.location
+}
+
+// Rule is the RRULE value, without the "RRULE:" prefix.
+func (s *AppointmentSeries) Rule() string {
+	return s.rule
+}
+
+func (s *AppointmentSeries) Timezone() *time.Location {
+	return s.timezone
+}
+
+// LocalTimePolicy resolves occurrence times that fall in a DST gap or
+// overlap in the series' timezone.
+func (s *AppointmentSeries) LocalTimePolicy() valueobjects.LocalTimePolicy {
+	return s.localTime
+}
+
+// Exceptions are the original start times of occurrences removed from the
+// series, its EXDATEs.
+func (s *AppointmentSeries) Exceptions() []time.Time {
+	return s.exceptions
+}
+
+func (s *AppointmentSeries) Overrides() []OccurrenceOverride {
+	return s.overrides
+}
+
+// Horizon is the time up to which occurrences have been materialized.
+func (s *AppointmentSeries) Horizon() time.Time {
+	return s.horizon
+}
+
+func (s *AppointmentSeries) Status() AppointmentStatus {
+	return s.status
+}
+
+func (s *AppointmentSeries) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *AppointmentSeries) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// Version is the number of times the series has been stored.
+func (s *AppointmentSeries) Version() int {
+	return s.version
+}
+
+// IncrementVersion is called by repositories after a successful write.
+func (s *AppointmentSeries) IncrementVersion() {
+	s.version++
+}
+
+// OccurrenceID identifies the occurrence whose original start is
+// recurrenceID. It stays the same when the occurrence is moved.
+func (s *AppointmentSeries) OccurrenceID(recurrenceID time.Time) string {
+	return s.id + "@" + FormatRecurrenceID(recurrenceID)
+}
+
+// FormatRecurrenceID writes an occurrence's original start the way it
+// appears in occurrence IDs, e.g. "20240115T090000Z".
+func FormatRecurrenceID(recurrenceID time.Time) string {
+	return recurrenceID.UTC().Format(occurrenceIDFormat)
+}
+
+// ParseRecurrenceID reads a recurrence ID written by FormatRecurrenceID or
+// as an RFC 3339 time.
+func ParseRecurrenceID(value string) (time.Time, error) {
+	if recurrenceID, err := time.Parse(occurrenceIDFormat, value); err == nil {
+		return recurrenceID, nil
+	}
+
+	recurrenceID, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, domainerrors.Invalid("invalid recurrence ID: " + value)
+	}
+	return recurrenceID.UTC(), nil
+}
+
+func (s *AppointmentSeries) IsExcluded(recurrenceID time.Time) bool {
+	for _, exception := range s.exceptions {
+		if exception.Equal(recurrenceID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Override returns the changes made to the occurrence at recurrenceID, if any.
+func (s *AppointmentSeries) Override(recurrenceID time.Time) (OccurrenceOverride, bool) {
+	for _, override := range s.overrides {
+		if override.recurrenceID.Equal(recurrenceID) {
+			return override, true
+		}
+	}
+	return OccurrenceOverride{}, false
+}
+
+// Occurrence builds the appointment for the occurrence at recurrenceID,
+// scheduled for timeRange by the rule, with any override applied. Series
+// have no description, tags or metadata and can't be tentative, so every
+// occurrence starts out confirmed without them.
+func (s *AppointmentSeries) Occurrence(recurrenceID time.Time, timeRange valueobjects.TimeRange) *Appointment {
+	title, location := s.title, s.location
+	if override, exists := s.Override(recurrenceID); exists {
+		if override.timeRange != nil {
+			timeRange = *override.timeRange
+		}
+		if override.title != nil {
+			title = *override.title
+		}
+		if override.location != nil {
+			location = *override.location
+		}
+	}
+
+	now := time.Now()
+	return &Appointment{
+		id:        s.OccurrenceID(recurrenceID),
+		title:     title,
+		timeRange: timeRange,
+		attendees: append([]Attendee(nil), s.attendees...),
+		location:  location,
+		tags:      make([]string, 0),
+		metadata:  make(valueobjects.Metadata),
+		status:    StatusConfirmed,
+		history:   make([]StatusTransition, 0),
+		createdAt: now,
+		updatedAt: now,
+	}
+}
+
+// Exclude removes the occurrence at recurrenceID from the series, dropping
+// any override it had.
+func (s *AppointmentSeries) Exclude(recurrenceID time.Time) {
+	if !s.IsExcluded(recurrenceID) {
+		s.exceptions = append(s.exceptions, recurrenceID)
+		sort.Slice(s.exceptions, func(i, j int) bool {
+			return s.exceptions[i].Before(s.exceptions[j])
+		})
+	}
+	s.removeOverride(recurrenceID)
+	s.updatedAt = time.Now()
+}
+
+// SetOverride records changes to a single occurrence, merging them with the
+// changes already made to it.
+func (s *AppointmentSeries) SetOverride(override OccurrenceOverride) {
+	if existing, exists := s.Override(override.recurrenceID); exists {
+		if override.timeRange == nil {
+			override.timeRange = existing.timeRange
+		}
+		if override.title == nil {
+			override.title = existing.title
+		}
+		if override.location == nil {
+			override.location = existing.location
+		}
+	}
+
+	s.removeOverride(override.recurrenceID)
+	s.overrides = append(s.overrides, override)
+	sort.Slice(s.overrides, func(i, j int) bool {
+		return s.overrides[i].recurrenceID.Before(s.overrides[j].recurrenceID)
+	})
+	s.updatedAt = time.Now()
+}
+
+func (s *AppointmentSeries) removeOverride(recurrenceID time.Time) {
+	for i, override := range s.overrides {
+		if override.recurrenceID.Equal(recurrenceID) {
+			s.overrides = append(s.overrides[:i], s.overrides[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *AppointmentSeries) Retitle(title string) error {
+	if title == "" {
+		return domainerrors.Invalid("appointment title cannot be empty")
+	}
+	s.title = title
+	s.updatedAt = time.Now()
+	return nil
+}
+
+func (s *AppointmentSeries) Relocate(location string) {
+	s.location = location
+	s.updatedAt = time.Now()
+}
+
+// Reschedule moves the first occurrence, and with it every occurrence, to
+// timeRange. Exceptions and overrides are moved by the same wall-clock amount
+// so they keep referring to the same occurrences across DST changes.
+func (s *AppointmentSeries) Reschedule(timeRange valueobjects.TimeRange) {
+	shift := s.wallClock(timeRange.StartTime()).Sub(s.wallClock(s.timeRange.StartTime()))
+
+	exceptions := make([]time.Time, 0, len(s.exceptions))
+	for _, exception := range s.exceptions {
+		if moved, ok := s.shiftWallClock(exception, shift); ok {
+			exceptions = append(exceptions, moved)
+		}
+	}
+
+	overrides := make([]OccurrenceOverride, 0, len(s.overrides))
+	for _, override := range s.overrides {
+		if moved, ok := s.shiftWallClock(override.recurrenceID, shift); ok {
+			override.recurrenceID = moved
+			overrides = append(overrides, override)
+		}
+	}
+
+	s.exceptions = exceptions
+	s.overrides = overrides
+	s.timeRange = timeRange
+	s.updatedAt = time.Now()
+}
+
+// wallClock returns what clocks in the series' timezone show at instant, as
+// a time in UTC so wall-clock differences can be taken without DST getting
+// in the way.
+func (s *AppointmentSeries) wallClock(instant time.Time) time.Time {
+	local := instant.In(s.Timezone())
+	year, month, day := local.Date()
+	hour, minute, second := local.Clock()
+	return time.Date(year, month, day, hour, minute, second, local.Nanosecond(), time.UTC)
+}
+
+// shiftWallClock moves instant by shift on the series' wall clock, the way
+// its occurrences move. It returns false when the new wall-clock time is one
+// the series' local time policy skips, as no occurrence is left there.
+func (s *AppointmentSeries) shiftWallClock(instant time.Time, shift time.Duration) (time.Time, bool) {
+	moved, ok := s.localTime.Resolve(s.wallClock(instant).Add(shift), s.Timezone())
+	if !ok {
+		return time.Time{}, false
+	}
+	return moved.In(instant.Location()), true
+}
+
+// SetRule replaces the recurrence rule. The caller validates it.
+func (s *AppointmentSeries) SetRule(rule string) {
+	s.rule = rule
+	s.updatedAt = time.Now()
+}
+
+func (s *AppointmentSeries) SetHorizon(horizon time.Time) {
+	s.horizon = horizon
+}
+
+func (s *AppointmentSeries) Cancel() {
+	s.status = StatusCancelled
+	s.updatedAt = time.Now()
+}
+
+// SplitAt ends the series before the occurrence at recurrenceID and returns
+// a new series holding that occurrence and the ones after it. headRule is
+// this series' rule cut short, tailRule the rule of the new series, whose
+// first occurrence is first. Exceptions and overrides from recurrenceID on
+// move to the new series.
+func (s *AppointmentSeries) SplitAt(recurrenceID time.Time, headRule, tailRule string, first valueobjects.TimeRange) *AppointmentSeries {
+	now := time.Now()
+	tail := &AppointmentSeries{
+		id:         uuid.New().String(),
+		title:      s.title,
+		timeRange:  first,
+		attendees:  append([]Attendee(nil), s.attendees...),
+		location:   s.location,
+		rule:       tailRule,
+		timezone:   s.timezone,
+		localTime:  s.localTime,
+		exceptions: make([]time.Time, 0),
+		overrides:  make([]OccurrenceOverride, 0),
+		status:     s.status,
+		createdAt:  now,
+		updatedAt:  now,
+	}
+
+	// The new series may start at a different time, so shift what moves over
+	// on the wall clock, as its occurrences are
+	shift := s.wallClock(first.StartTime()).Sub(s.wallClock(recurrenceID))
+
+	exceptions := make([]time.Time, 0, len(s.exceptions))
+	for _, exception := range s.exceptions {
+		if exception.Before(recurrenceID) {
+			exceptions = append(exceptions, exception)
+		} else if moved, ok := s.shiftWallClock(exception, shift); ok {
+			tail.exceptions = append(tail.exceptions, moved)
+		}
+	}
+
+	overrides := make([]OccurrenceOverride, 0, len(s.overrides))
+	for _, override := range s.overrides {
+		if override.recurrenceID.Before(recurrenceID) {
+			overrides = append(overrides, override)
+		} else if moved, ok := s.shiftWallClock(override.recurrenceID, shift); ok {
+			override.recurrenceID = moved
+			tail.overrides = append(tail.overrides, override)
+		}
+	}
+
+	s.exceptions = exceptions
+	s.overrides = overrides
+	s.rule = headRule
+	s.updatedAt = now
+	return tail
+}
+
+// OccurrenceOverride changes a single occurrence of a series, identified by
+// its original start time (its RFC 5545 RECURRENCE-ID). Nil fields keep the
+// series' value.
+type OccurrenceOverride struct {
+	recurrenceID time.Time
+	timeRange    *valueobjects.TimeRange
+	title        *string
+	location     *string
+}
+
+func NewOccurrenceOverride(recurrenceID time.Time, timeRange *valueobjects.TimeRange, title, location *string) OccurrenceOverride {
+	return OccurrenceOverride{
+		recurrenceID: recurrenceID,
+		timeRange:    timeRange,
+		title:        title,
+		location:     location,
+	}
+}
+
+func (o OccurrenceOverride) RecurrenceID() time.Time {
+	return o.recurrenceID
+}
+
+// TimeRange is where the occurrence was moved to, or nil if it was not moved.
+func (o OccurrenceOverride) TimeRange() *valueobjects.TimeRange {
+	return o.timeRange
+}
+
+func (o OccurrenceOverride) Title() *string {
+	return o.title
+}
+
+func (o OccurrenceOverride) Location() *string {
+	return o.location
+}
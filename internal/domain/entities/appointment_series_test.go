@@ -0,0 +1,78 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// newWeeklySeries starts a weekly 09:00-10:00 series in New York on start,
+// with an exception and a retitled occurrence on the given dates.
+func newWeeklySeries(t *testing.T, newYork *time.Location, start, excluded, overridden time.Time) *entities.AppointmentSeries {
+	t.Helper()
+
+	organizer, err := entities.NewAttendee("alice", entities.RoleOrganizer)
+	require.NoError(t, err)
+
+	timeRange, err := valueobjects.NewTimeRange(start, start.Add(time.Hour))
+	require.NoError(t, err)
+
+	series, err := entities.NewAppointmentSeries("Standup", timeRange, []entities.Attendee{organizer}, "", "FREQ=WEEKLY", newYork, valueobjects.LocalTimePolicy{})
+	require.NoError(t, err)
+
+	title := "Planning"
+	series.Exclude(excluded.UTC())
+	series.SetOverride(entities.NewOccurrenceOverride(overridden.UTC(), nil, &title, nil))
+	return series
+}
+
+// TestAppointmentSeriesShiftsExceptionsOnWallClock moves a series from
+// before to after the start of DST in New York on March 10, 2030, so that
+// the absolute shift is an hour short of the wall-clock one. Exceptions and
+// overrides must still land on 09:00 local time.
+func TestAppointmentSeriesShiftsExceptionsOnWallClock(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	at := func(day int) time.Time {
+		return time.Date(2030, 3, day, 9, 0, 0, 0, newYork)
+	}
+
+	t.Run("reschedule", func(t *testing.T) {
+		series := newWeeklySeries(t, newYork, at(8), at(22), at(29))
+
+		// From Friday to Monday, three days and an hour less later
+		first, err := valueobjects.NewTimeRange(at(11), at(11).Add(time.Hour))
+		require.NoError(t, err)
+		series.Reschedule(first)
+
+		require.Len(t, series.Exceptions(), 1)
+		assert.True(t, series.Exceptions()[0].Equal(at(25)), "exception at %s", series.Exceptions()[0].In(newYork))
+
+		require.Len(t, series.Overrides(), 1)
+		assert.True(t, series.Overrides()[0].RecurrenceID().Equal(at(32)), "override at %s", series.Overrides()[0].RecurrenceID().In(newYork))
+	})
+
+	t.Run("split", func(t *testing.T) {
+		series := newWeeklySeries(t, newYork, at(1), at(22), at(29))
+
+		// The following occurrences move from Fridays to Mondays from the
+		// last Friday before DST starts
+		first, err := valueobjects.NewTimeRange(at(11), at(11).Add(time.Hour))
+		require.NoError(t, err)
+		tail := series.SplitAt(at(8).UTC(), "FREQ=WEEKLY;UNTIL=20300301T140000Z", "FREQ=WEEKLY", first)
+
+		assert.Empty(t, series.Exceptions())
+		assert.Empty(t, series.Overrides())
+
+		require.Len(t, tail.Exceptions(), 1)
+		assert.True(t, tail.Exceptions()[0].Equal(at(25)), "exception at %s", tail.Exceptions()[0].In(newYork))
+
+		require.Len(t, tail.Overrides(), 1)
+		assert.True(t, tail.Overrides()[0].RecurrenceID().Equal(at(32)), "override at %s", tail.Overrides()[0].RecurrenceID().In(newYork))
+	})
+}
@@ -0,0 +1,138 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+)
+
+type AppointmentStatus string
+
+const (
+	StatusTentative  AppointmentStatus = "tentative" // Held, pending approval
+	StatusConfirmed  AppointmentStatus = "confirmed"
+	StatusInProgress AppointmentStatus = "in-progress"
+	StatusCompleted  AppointmentStatus = "completed"
+	StatusNoShow     AppointmentStatus = "no-show"
+	StatusCancelled  AppointmentStatus = "cancelled"
+)
+
+// StatusScheduled is how confirmed appointments were stored before the
+// lifecycle had a tentative state.
+const StatusScheduled AppointmentStatus = "scheduled"
+
+// ErrInvalidStatusTransition is returned when an appointment can't move from
+// its current status to the requested one.
+var ErrInvalidStatusTransition = domainerrors.Conflict("invalid status transition")
+
+func ParseAppointmentStatus(status string) (AppointmentStatus, error) {
+	switch AppointmentStatus(status) {
+	case StatusTentative, StatusConfirmed, StatusInProgress, StatusCompleted, StatusNoShow, StatusCancelled:
+		return AppointmentStatus(status), nil
+	}
+	return "", domainerrors.Invalid("invalid appointment status: " + status + " (expected tentative, confirmed, in-progress, completed, no-show or cancelled)")
+}
+
+// IsActive reports whether the appointment is still going to happen or
+// happening, so its details and attendees can change.
+func (s AppointmentStatus) IsActive() bool {
+	return s == StatusTentative || s == StatusConfirmed || s == StatusInProgress
+}
+
+// StatusTransition records one change of an appointment's status.
+type StatusTransition struct {
+	from      AppointmentStatus
+	to        AppointmentStatus
+	changedBy string
+	reason    string
+	changedAt time.Time
+}
+
+// RestoreStatusTransition rebuilds a recorded transition from persisted
+// state.
+func RestoreStatusTransition(from, to AppointmentStatus, changedBy, reason string, changedAt time.Time) StatusTransition {
+	return StatusTransition{
+		from:      from,
+		to:        to,
+		changedBy: changedBy,
+		reason:    reason,
+		changedAt: changedAt,
+	}
+}
+
+func (t StatusTransition) From() AppointmentStatus {
+	return t.from
+}
+
+func (t StatusTransition) To() AppointmentStatus {
+	return t.to
+}
+
+// ChangedBy is who made the change; empty when the system did, e.g. when a
+// series is cancelled.
+func (t StatusTransition) ChangedBy() string {
+	return t.changedBy
+}
+
+func (t StatusTransition) Reason() string {
+	return t.reason
+}
+
+func (t StatusTransition) ChangedAt() time.Time {
+	return t.changedAt
+}
+
+// Confirm approves a tentative appointment.
+func (a *Appointment) Confirm(changedBy, reason string) error {
+	return a.transition(StatusConfirmed, changedBy, reason, StatusTentative)
+}
+
+// Start marks a confirmed appointment as under way.
+func (a *Appointment) Start(changedBy, reason string) error {
+	return a.transition(StatusInProgress, changedBy, reason, StatusConfirmed)
+}
+
+func (a *Appointment) Complete(changedBy, reason string) error {
+	return a.transition(StatusCompleted, changedBy, reason, StatusConfirmed, StatusInProgress)
+}
+
+// MarkNoShow records that a confirmed appointment didn't take place because
+// attendees didn't turn up.
+func (a *Appointment) MarkNoShow(changedBy, reason string) error {
+	return a.transition(StatusNoShow, changedBy, reason, StatusConfirmed)
+}
+
+// Cancel calls off an appointment that hasn't started.
+func (a *Appointment) Cancel(changedBy, reason string) error {
+	return a.transition(StatusCancelled, changedBy, reason, StatusTentative, StatusConfirmed)
+}
+
+// Reopen takes back a completion, no-show or cancellation, leaving the
+// appointment confirmed.
+func (a *Appointment) Reopen(changedBy, reason string) error {
+	return a.transition(StatusConfirmed, changedBy, reason, StatusCompleted, StatusNoShow, StatusCancelled)
+}
+
+// transition moves the appointment to status to, if its current status is
+// one of from, and records the change.
+func (a *Appointment) transition(to AppointmentStatus, changedBy, reason string, from ...AppointmentStatus) error {
+	for _, allowed := range from {
+		if a.status != allowed {
+			continue
+		}
+
+		now := time.Now()
+		a.history = append(a.history, StatusTransition{
+			from:      a.status,
+			to:        to,
+			changedBy: changedBy,
+			reason:    reason,
+			changedAt: now,
+		})
+		a.status = to
+		a.updatedAt = now
+		return nil
+	}
+	return fmt.Errorf("%w: appointment is %s and can't become %s", ErrInvalidStatusTransition, a.status, to)
+}
@@ -0,0 +1,130 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+func appointmentWithStatus(t *testing.T, status entities.AppointmentStatus) *entities.Appointment {
+	t.Helper()
+
+	organizer, err := entities.NewAttendee("alice", entities.RoleOrganizer)
+	require.NoError(t, err)
+	start := time.Date(2030, 3, 4, 9, 0, 0, 0, time.UTC)
+	timeRange, err := valueobjects.NewTimeRange(start, start.Add(time.Hour))
+	require.NoError(t, err)
+
+	created := start.AddDate(0, -1, 0)
+	return entities.RestoreAppointment("a1", "Review", "", timeRange, []entities.Attendee{organizer}, "", nil, nil, status, nil, created, created, 1)
+}
+
+// TestAppointmentStatusTransitions tries every action from every status.
+func TestAppointmentStatusTransitions(t *testing.T) {
+	actions := map[string]func(*entities.Appointment) error{
+		"confirm":  func(a *entities.Appointment) error { return a.Confirm("bob", "approved") },
+		"start":    func(a *entities.Appointment) error { return a.Start("bob", "approved") },
+		"complete": func(a *entities.Appointment) error { return a.Complete("bob", "approved") },
+		"no-show":  func(a *entities.Appointment) error { return a.MarkNoShow("bob", "approved") },
+		"cancel":   func(a *entities.Appointment) error { return a.Cancel("bob", "approved") },
+		"reopen":   func(a *entities.Appointment) error { return a.Reopen("bob", "approved") },
+	}
+
+	// The status each action leads to from the statuses it's allowed in
+	legal := map[string]map[entities.AppointmentStatus]entities.AppointmentStatus{
+		"confirm": {
+			entities.StatusTentative: entities.StatusConfirmed,
+		},
+		"start": {
+			entities.StatusConfirmed: entities.StatusInProgress,
+		},
+		"complete": {
+			entities.StatusConfirmed:  entities.StatusCompleted,
+			entities.StatusInProgress: entities.StatusCompleted,
+		},
+		"no-show": {
+			entities.StatusConfirmed: entities.StatusNoShow,
+		},
+		"cancel": {
+			entities.StatusTentative: entities.StatusCancelled,
+			entities.StatusConfirmed: entities.StatusCancelled,
+		},
+		"reopen": {
+			entities.StatusCompleted: entities.StatusConfirmed,
+			entities.StatusNoShow:    entities.StatusConfirmed,
+			entities.StatusCancelled: entities.StatusConfirmed,
+		},
+	}
+
+	statuses := []entities.AppointmentStatus{
+		entities.StatusTentative,
+		entities.StatusConfirmed,
+		entities.StatusInProgress,
+		entities.StatusCompleted,
+		entities.StatusNoShow,
+		entities.StatusCancelled,
+	}
+
+	for name, action := range actions {
+		for _, from := range statuses {
+			t.Run(name+" from "+string(from), func(t *testing.T) {
+				appointment := appointmentWithStatus(t, from)
+				before := time.Now()
+				err := action(appointment)
+
+				to, ok := legal[name][from]
+				if !ok {
+					assert.ErrorIs(t, err, entities.ErrInvalidStatusTransition)
+					assert.Equal(t, from, appointment.Status(), "the status is kept")
+					assert.Empty(t, appointment.StatusHistory(), "nothing is recorded")
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, to, appointment.Status())
+				require.Len(t, appointment.StatusHistory(), 1)
+
+				transition := appointment.StatusHistory()[0]
+				assert.Equal(t, from, transition.From())
+				assert.Equal(t, to, transition.To())
+				assert.Equal(t, "bob", transition.ChangedBy())
+				assert.Equal(t, "approved", transition.Reason())
+				assert.False(t, transition.ChangedAt().Before(before))
+				assert.Equal(t, transition.ChangedAt(), appointment.UpdatedAt())
+			})
+		}
+	}
+}
+
+func TestAppointmentStatusHistoryAccumulates(t *testing.T) {
+	appointment := appointmentWithStatus(t, entities.StatusTentative)
+	require.NoError(t, appointment.Confirm("bob", ""))
+	require.NoError(t, appointment.Start("", ""))
+	require.NoError(t, appointment.Complete("", ""))
+	require.NoError(t, appointment.Reopen("carol", "notes were lost"))
+
+	steps := make([]entities.AppointmentStatus, 0)
+	for _, transition := range appointment.StatusHistory() {
+		steps = append(steps, transition.To())
+	}
+	assert.Equal(t, []entities.AppointmentStatus{
+		entities.StatusConfirmed,
+		entities.StatusInProgress,
+		entities.StatusCompleted,
+		entities.StatusConfirmed,
+	}, steps)
+}
+
+func TestParseAppointmentStatus(t *testing.T) {
+	status, err := entities.ParseAppointmentStatus("no-show")
+	require.NoError(t, err)
+	assert.Equal(t, entities.StatusNoShow, status)
+
+	// Only the repositories still read the old name
+	_, err = entities.ParseAppointmentStatus(string(entities.StatusScheduled))
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+)
+
+// AttendeeRole is the part an attendee plays in an appointment, after the
+// RFC 5545 ROLE and CUTYPE parameters.
+type AttendeeRole string
+
+const (
+	RoleOrganizer AttendeeRole = "organizer"
+	RoleRequired  AttendeeRole = "required"
+	RoleOptional  AttendeeRole = "optional"
+	RoleResource  AttendeeRole = "resource" // A room or piece of equipment
+)
+
+// ResponseStatus is an attendee's answer to an invitation, after the RFC 5545
+// PARTSTAT parameter.
+type ResponseStatus string
+
+const (
+	ResponseNeedsAction ResponseStatus = "needs-action"
+	ResponseAccepted    ResponseStatus = "accepted"
+	ResponseTentative   ResponseStatus = "tentative"
+	ResponseDeclined    ResponseStatus = "declined"
+)
+
+func ParseAttendeeRole(role string) (AttendeeRole, error) {
+	switch AttendeeRole(role) {
+	case RoleOrganizer, RoleRequired, RoleOptional, RoleResource:
+		return AttendeeRole(role), nil
+	}
+	return "", domainerrors.Invalid("invalid attendee role: " + role + " (expected organizer, required, optional or resource)")
+}
+
+func ParseResponseStatus(response string) (ResponseStatus, error) {
+	switch ResponseStatus(response) {
+	case ResponseNeedsAction, ResponseAccepted, ResponseTentative, ResponseDeclined:
+		return ResponseStatus(response), nil
+	}
+	return "", domainerrors.Invalid("invalid response status: " + response + " (expected needs-action, accepted, tentative or declined)")
+}
+
+// Attendee is a participant invited to an appointment, with their role and
+// response.
+type Attendee struct {
+	participantID string
+	role          AttendeeRole
+	response      ResponseStatus
+	respondedAt   *time.Time
+}
+
+// NewAttendee invites participantID in role. The organizer has accepted
+// their own appointment; everyone else has yet to respond.
+func NewAttendee(participantID string, role AttendeeRole) (Attendee, error) {
+	if participantID == "" {
+		return Attendee{}, domainerrors.Invalid("attendee participant ID cannot be empty")
+	}
+
+	if _, err := ParseAttendeeRole(string(role)); err != nil {
+		return Attendee{}, err
+	}
+
+	response := ResponseNeedsAction
+	if role == RoleOrganizer {
+		response = ResponseAccepted
+	}
+
+	return Attendee{
+		participantID: participantID,
+		role:          role,
+		response:      response,
+	}, nil
+}
+
+// RequiredAttendees invites each of participantIDs as a required attendee.
+func RequiredAttendees(participantIDs []string) []Attendee {
+	attendees := make([]Attendee, len(participantIDs))
+	for i, participantID := range participantIDs {
+		attendees[i] = Attendee{participantID: participantID, role: RoleRequired, response: ResponseNeedsAction}
+	}
+	return attendees
+}
+
+// RestoreAttendee rebuilds an attendee from persisted state.
+func RestoreAttendee(participantID string, role AttendeeRole, response ResponseStatus, respondedAt *time.Time) Attendee {
+	return Attendee{
+		participantID: participantID,
+		role:          role,
+		response:      response,
+		respondedAt:   respondedAt,
+	}
+}
+
+func (a Attendee) ParticipantID() string {
+	return a.participantID
+}
+
+func (a Attendee) Role() AttendeeRole {
+	return a.role
+}
+
+func (a Attendee) Response() ResponseStatus {
+	return a.response
+}
+
+// RespondedAt is when the attendee last responded, nil if they haven't.
+func (a Attendee) RespondedAt() *time.Time {
+	return a.respondedAt
+}
+
+// IsOptional reports whether the appointment can go ahead without the
+// attendee.
+func (a Attendee) IsOptional() bool {
+	return a.role == RoleOptional
+}
+
+// IsBusy reports whether the appointment takes up the attendee's time. It
+// doesn't once they've declined, nor for optional attendees, who can skip it
+// for something else.
+func (a Attendee) IsBusy() bool {
+	return a.response != ResponseDeclined && !a.IsOptional()
+}
+
+// validateAttendees checks that there is at least one attendee, that nobody
+// is listed twice and that there is at most one organizer.
+func validateAttendees(attendees []Attendee) error {
+	if len(attendees) == 0 {
+		return domainerrors.Invalid("appointment must have at least one attendee")
+	}
+
+	seen := make(map[string]bool, len(attendees))
+	organizers := 0
+	for _, attendee := range attendees {
+		if attendee.participantID == "" {
+			return domainerrors.Invalid("attendee participant ID cannot be empty")
+		}
+		if seen[attendee.participantID] {
+			return domainerrors.Invalid("attendee listed more than once: " + attendee.participantID)
+		}
+		seen[attendee.participantID] = true
+
+		if attendee.role == RoleOrganizer {
+			organizers++
+		}
+	}
+
+	if organizers > 1 {
+		return domainerrors.Invalid("appointment can have at most one organizer")
+	}
+	return nil
+}
+
+func attendeeIDs(attendees []Attendee) []string {
+	ids := make([]string, len(attendees))
+	for i, attendee := range attendees {
+		ids[i] = attendee.participantID
+	}
+	return ids
+}
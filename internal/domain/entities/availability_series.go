@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// occurrenceIDFormat is the UTC start time suffix that identifies a single
+// occurrence of a series, e.g. "<series id>@20240115T090000Z".
+const occurrenceIDFormat = "20060102T150405Z"
+
+// AvailabilitySeries is recurring availability stored as a rule. Its
+// occurrences are expanded on demand for the window being looked at.
+type AvailabilitySeries struct {
+	id         string
+	timeRange  valueobjects.TimeRange
+	pattern    string
+	interval   int
+	endDate    *time.Time
+	exceptions []time.Time
+}
+
+func NewAvailabilitySeries(timeRange valueobjects.TimeRange, pattern string, interval int, endDate *time.Time) (AvailabilitySeries, error) {
+	if pattern == "" {
+		return AvailabilitySeries{}, domainerrors.Invalid("recurrence pattern is required")
+	}
+
+	if interval <= 0 {
+		return AvailabilitySeries{}, domainerrors.Invalid("recurrence interval must be positive")
+	}
+
+	if endDate != nil && endDate.Before(timeRange.StartTime()) {
+		return AvailabilitySeries{}, domainerrors.Invalid("recurrence end date cannot be before the first occurrence")
+	}
+
+	return AvailabilitySeries{
+		id:         uuid.New().String(),
+		timeRange:  timeRange,
+		pattern:    pattern,
+		interval:   interval,
+		endDate:    endDate,
+		exceptions: make([]time.Time, 0),
+	}, nil
+}
+
+// RestoreAvailabilitySeries rebuilds a series from persisted state.
+func RestoreAvailabilitySeries(id string, timeRange valueobjects.TimeRange, pattern string, interval int, endDate *time.Time, exceptions []time.Time) AvailabilitySeries {
+	return AvailabilitySeries{
+		id:         id,
+		timeRange:  timeRange,
+		pattern:    pattern,
+		interval:   interval,
+		endDate:    endDate,
+		exceptions: exceptions,
+	}
+}
+
+func (s AvailabilitySeries) ID() string {
+	return s.id
+}
+
+// TimeRange is the first occurrence of the series.
+func (s AvailabilitySeries) TimeRange() valueobjects.TimeRange {
+	return s.timeRange
+}
+
+func (s AvailabilitySeries) Pattern() string {
+	return s.pattern
+}
+
+func (s AvailabilitySeries) Interval() int {
+	return s.interval
+}
+
+func (s AvailabilitySeries) EndDate() *time.Time {
+	return s.endDate
+}
+
+// Exceptions are the start times of occurrences removed from the series.
+func (s AvailabilitySeries) Exceptions() []time.Time {
+	return s.exceptions
+}
+
+func (s AvailabilitySeries) IsExcluded(start time.Time) bool {
+	for _, exception := range s.exceptions {
+		if exception.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// OccurrenceID identifies the occurrence of the series starting at start.
+func (s AvailabilitySeries) OccurrenceID(start time.Time) string {
+	return s.id + "@" + start.UTC().Format(occurrenceIDFormat)
+}
+
+// ParseOccurrenceID splits an ID built by OccurrenceID into the series ID and
+// the occurrence start time.
+func ParseOccurrenceID(id string) (string, time.Time, bool) {
+	seriesID, suffix, found := strings.Cut(id, "@")
+	if !found {
+		return "", time.Time{}, false
+	}
+
+	start, err := time.Parse(occurrenceIDFormat, suffix)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return seriesID, start, true
+}
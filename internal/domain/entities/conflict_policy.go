@@ -0,0 +1,181 @@
+package entities
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// ConflictPolicy decides which appointments on a schedule take up its
+// owner's time and how close together they may be. Start from
+// DefaultConflictPolicy, which treats any overlap with a busy appointment as
+// a conflict.
+type ConflictPolicy struct {
+	// PreBuffer and PostBuffer are kept free before and after every
+	// appointment. The post-buffer of one appointment and the pre-buffer of
+	// the next add up.
+	PreBuffer  time.Duration
+	PostBuffer time.Duration
+
+	// OverlapTolerance is how long an overlap may be before it counts, so
+	// back-to-back meetings that run a few minutes over still fit.
+	OverlapTolerance time.Duration
+
+	// MaxConcurrent is how many appointments may take up the owner's time at
+	// once; 1 allows no double-booking.
+	MaxConcurrent int
+
+	// BusyStatuses are the appointment statuses that take up time, and
+	// BusyResponses the attendee responses that do. Optional attendees are
+	// never busy.
+	BusyStatuses  []AppointmentStatus
+	BusyResponses []ResponseStatus
+
+	// MajorThreshold and CriticalThreshold are the shares of a proposed time
+	// an overlap must cover to count as a major or critical conflict.
+	MajorThreshold    float64
+	CriticalThreshold float64
+}
+
+func DefaultConflictPolicy() ConflictPolicy {
+	return ConflictPolicy{
+		MaxConcurrent:     1,
+		BusyStatuses:      []AppointmentStatus{StatusTentative, StatusConfirmed, StatusInProgress, StatusCompleted, StatusNoShow},
+		BusyResponses:     []ResponseStatus{ResponseNeedsAction, ResponseAccepted, ResponseTentative},
+		MajorThreshold:    0.3,
+		CriticalThreshold: 0.8,
+	}
+}
+
+func (p ConflictPolicy) Validate() error {
+	switch {
+	case p.PreBuffer < 0 || p.PostBuffer < 0:
+		return domainerrors.Invalid("buffers cannot be negative")
+	case p.OverlapTolerance < 0:
+		return domainerrors.Invalid("overlap tolerance cannot be negative")
+	case p.MaxConcurrent < 1:
+		return domainerrors.Invalid("maximum concurrent appointments must be at least 1")
+	case slices.Contains(p.BusyStatuses, StatusCancelled):
+		return domainerrors.Invalid("cancelled appointments cannot take up time")
+	case p.MajorThreshold <= 0 || p.CriticalThreshold > 1:
+		return domainerrors.Invalid("severity thresholds must be above 0 and at most 1")
+	case p.MajorThreshold > p.CriticalThreshold:
+		return domainerrors.Invalid("major threshold cannot be above critical threshold")
+	}
+	return nil
+}
+
+// Blocks reports whether appointment takes up participantID's time under
+// the policy. Participants who aren't attendees only go by the status, so an
+// appointment on someone's schedule blocks it unless they've opted out.
+func (p ConflictPolicy) Blocks(appointment *Appointment, participantID string) bool {
+	if attendee, ok := appointment.Attendee(participantID); ok {
+		return p.BlocksAttendee(appointment.Status(), attendee)
+	}
+	return slices.Contains(p.BusyStatuses, appointment.Status())
+}
+
+// BlocksAttendee reports whether an appointment in status takes up
+// attendee's time under the policy.
+func (p ConflictPolicy) BlocksAttendee(status AppointmentStatus, attendee Attendee) bool {
+	return slices.Contains(p.BusyStatuses, status) &&
+		slices.Contains(p.BusyResponses, attendee.Response()) &&
+		!attendee.IsOptional()
+}
+
+// Clashes returns the time a and b share when it is longer than the overlap
+// tolerance.
+func (p ConflictPolicy) Clashes(a, b valueobjects.TimeRange) (valueobjects.TimeRange, bool) {
+	overlap, ok := a.Overlap(b)
+	if !ok || overlap.Duration() <= p.OverlapTolerance {
+		return valueobjects.TimeRange{}, false
+	}
+	return overlap, true
+}
+
+// Buffered is the time an appointment occupying timeRange keeps free.
+func (p ConflictPolicy) Buffered(timeRange valueobjects.TimeRange) valueobjects.TimeRange {
+	return timeRange.Extend(p.PreBuffer, p.PostBuffer)
+}
+
+// AppointmentClash is a booked appointment that keeps a proposed time from
+// being booked.
+type AppointmentClash struct {
+	Appointment *Appointment
+	Overlap     valueobjects.TimeRange // Of the appointments, or of their buffers when InBuffer
+	InBuffer    bool                   // Only the buffers clash; the appointments themselves fit
+}
+
+// clashingAppointments returns the appointments that keep timeRange from
+// being booked: the busy ones whose buffered times clash with its buffered
+// time, wherever MaxConcurrent or more of them do at once.
+func (p ConflictPolicy) clashingAppointments(appointments []*Appointment, ownerID string, timeRange valueobjects.TimeRange) []AppointmentClash {
+	buffered := p.Buffered(timeRange)
+
+	clashes := make([]AppointmentClash, 0)
+	within := make([]valueobjects.TimeRange, 0) // Of each clash, within buffered
+	for _, appointment := range appointments {
+		if !p.Blocks(appointment, ownerID) {
+			continue
+		}
+
+		bufferOverlap, ok := p.Clashes(p.Buffered(appointment.TimeRange()), buffered)
+		if !ok {
+			continue
+		}
+
+		clash := AppointmentClash{Appointment: appointment, Overlap: bufferOverlap, InBuffer: true}
+		if overlap, ok := p.Clashes(appointment.TimeRange(), timeRange); ok {
+			clash.Overlap = overlap
+			clash.InBuffer = false
+		}
+		clashes = append(clashes, clash)
+		within = append(within, bufferOverlap)
+	}
+
+	if len(clashes) < p.MaxConcurrent {
+		return clashes[:0]
+	}
+	if p.MaxConcurrent <= 1 {
+		return clashes
+	}
+
+	// Keep the clashes that take part in a stretch where too many
+	// appointments overlap the proposed time at once
+	bounds := make([]time.Time, 0, 2*len(within))
+	for _, overlap := range within {
+		bounds = append(bounds, overlap.StartTime(), overlap.EndTime())
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].Before(bounds[j]) })
+
+	overbooked := make([]bool, len(clashes))
+	for i := 0; i+1 < len(bounds); i++ {
+		if !bounds[i].Before(bounds[i+1]) {
+			continue
+		}
+
+		concurrent := make([]int, 0)
+		for j, overlap := range within {
+			if !overlap.StartTime().After(bounds[i]) && !overlap.EndTime().Before(bounds[i+1]) {
+				concurrent = append(concurrent, j)
+			}
+		}
+		if len(concurrent) < p.MaxConcurrent {
+			continue
+		}
+		for _, j := range concurrent {
+			overbooked[j] = true
+		}
+	}
+
+	result := make([]AppointmentClash, 0, len(clashes))
+	for i, clash := range clashes {
+		if overbooked[i] {
+			result = append(result, clash)
+		}
+	}
+	return result
+}
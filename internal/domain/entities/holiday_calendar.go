@@ -0,0 +1,213 @@
+package entities
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// HolidayCalendar is the set of public holidays of a region, identified by a
+// region code such as "DE" or "US-CA". Holidays are stored as rules and
+// resolved per year.
+type HolidayCalendar struct {
+	region string
+	name   string
+	rules  []HolidayRule
+}
+
+func NewHolidayCalendar(region, name string, rules []HolidayRule) (*HolidayCalendar, error) {
+	region = NormalizeRegionCode(region)
+	if region == "" {
+		return nil, domainerrors.Invalid("holiday calendar region cannot be empty")
+	}
+
+	if name == "" {
+		name = region
+	}
+
+	return &HolidayCalendar{
+		region: region,
+		name:   name,
+		rules:  append([]HolidayRule(nil), rules...),
+	}, nil
+}
+
+// NormalizeRegionCode upper-cases and trims a region code so subscriptions
+// match calendars regardless of how they were spelled.
+func NormalizeRegionCode(region string) string {
+	return strings.ToUpper(strings.TrimSpace(region))
+}
+
+// normalizeRegionCodes normalizes and de-duplicates subscribed regions,
+// keeping their order.
+func normalizeRegionCodes(regions []string) []string {
+	result := make([]string, 0, len(regions))
+	seen := make(map[string]bool, len(regions))
+	for _, region := range regions {
+		region = NormalizeRegionCode(region)
+		if region == "" || seen[region] {
+			continue
+		}
+		seen[region] = true
+		result = append(result, region)
+	}
+	return result
+}
+
+func (c *HolidayCalendar) Region() string {
+	return c.region
+}
+
+func (c *HolidayCalendar) Name() string {
+	return c.name
+}
+
+func (c *HolidayCalendar) Rules() []HolidayRule {
+	return c.rules
+}
+
+// HolidaysIn returns the holidays falling in year, ordered by date. Rules
+// that resolve to the same date are reported once, under the first name.
+func (c *HolidayCalendar) HolidaysIn(year int) []valueobjects.Holiday {
+	byDate := make(map[string]valueobjects.Holiday)
+	for _, rule := range c.rules {
+		date, ok := rule.dateIn(year)
+		if !ok {
+			continue
+		}
+
+		holiday := valueobjects.NewHoliday(c.region, date, rule.name)
+		if _, exists := byDate[holiday.Date()]; !exists {
+			byDate[holiday.Date()] = holiday
+		}
+	}
+
+	result := make([]valueobjects.Holiday, 0, len(byDate))
+	for _, holiday := range byDate {
+		result = append(result, holiday)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Date() < result[j].Date()
+	})
+	return result
+}
+
+// HolidayOn returns the holiday on a local date, if there is one.
+func (c *HolidayCalendar) HolidayOn(year int, month time.Month, day int) (valueobjects.Holiday, bool) {
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	for _, rule := range c.rules {
+		if ruleDate, ok := rule.dateIn(year); ok && ruleDate.Equal(date) {
+			return valueobjects.NewHoliday(c.region, date, rule.name), true
+		}
+	}
+	return valueobjects.Holiday{}, false
+}
+
+type holidayRuleKind int
+
+const (
+	holidayFixed holidayRuleKind = iota
+	holidayDated
+	holidayWeekday
+	holidayEaster
+)
+
+// HolidayRule resolves a named holiday to a date in a given year.
+type HolidayRule struct {
+	name    string
+	kind    holidayRuleKind
+	year    int
+	month   time.Month
+	day     int
+	weekday time.Weekday
+	week    int
+	offset  int
+}
+
+// NewFixedHolidayRule is a holiday on the same date every year, e.g. 25 December.
+func NewFixedHolidayRule(name string, month time.Month, day int) (HolidayRule, error) {
+	// Checked against a leap year, so 29 February is allowed
+	if month < time.January || month > time.December || day < 1 || day > time.Date(2000, month+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+		return HolidayRule{}, domainerrors.Invalidf("invalid holiday date for %s: %d-%d", name, month, day)
+	}
+	return HolidayRule{name: name, kind: holidayFixed, month: month, day: day}, nil
+}
+
+// NewDatedHolidayRule is a one-off holiday on a single date.
+func NewDatedHolidayRule(name string, date time.Time) HolidayRule {
+	return HolidayRule{name: name, kind: holidayDated, year: date.Year(), month: date.Month(), day: date.Day()}
+}
+
+// NewWeekdayHolidayRule is a holiday on the nth weekday of a month, e.g. the
+// fourth Thursday of November. Negative weeks count from the end of the
+// month, so -1 is the last one.
+func NewWeekdayHolidayRule(name string, month time.Month, weekday time.Weekday, week int) (HolidayRule, error) {
+	if month < time.January || month > time.December {
+		return HolidayRule{}, domainerrors.Invalidf("invalid holiday month for %s: %d", name, month)
+	}
+	if week == 0 || week < -5 || week > 5 {
+		return HolidayRule{}, domainerrors.Invalidf("invalid holiday week for %s: %d", name, week)
+	}
+	return HolidayRule{name: name, kind: holidayWeekday, month: month, weekday: weekday, week: week}, nil
+}
+
+// NewEasterHolidayRule is a holiday a number of days from Western Easter
+// Sunday, e.g. -2 for Good Friday.
+func NewEasterHolidayRule(name string, offset int) HolidayRule {
+	return HolidayRule{name: name, kind: holidayEaster, offset: offset}
+}
+
+func (r HolidayRule) Name() string {
+	return r.name
+}
+
+func (r HolidayRule) dateIn(year int) (time.Time, bool) {
+	switch r.kind {
+	case holidayFixed:
+		date := time.Date(year, r.month, r.day, 0, 0, 0, 0, time.UTC)
+		// 29 February only exists in leap years
+		return date, date.Month() == r.month
+	case holidayDated:
+		return time.Date(r.year, r.month, r.day, 0, 0, 0, 0, time.UTC), r.year == year
+	case holidayWeekday:
+		return nthWeekday(year, r.month, r.weekday, r.week)
+	case holidayEaster:
+		return easterSunday(year).AddDate(0, 0, r.offset), true
+	}
+	return time.Time{}, false
+}
+
+func nthWeekday(year int, month time.Month, weekday time.Weekday, week int) (time.Time, bool) {
+	if week > 0 {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		date := first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+(week-1)*7)
+		return date, date.Month() == month
+	}
+
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	date := last.AddDate(0, 0, -((int(last.Weekday())-int(weekday)+7)%7)+(week+1)*7)
+	return date, date.Month() == month
+}
+
+// easterSunday uses the anonymous Gregorian algorithm.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
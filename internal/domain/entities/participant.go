@@ -1,11 +1,11 @@
 package entities
 
 import (
-	"errors"
 	"regexp"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
 	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 )
 
@@ -15,15 +15,17 @@ type Participant struct {
 	email        string
 	timezone     *time.Location
 	availability []valueobjects.TimeSlot
+	series       []AvailabilitySeries
+	holidays     []string
 }
 
 func NewParticipant(name, email string, timezone *time.Location) (*Participant, error) {
 	if name == "" {
-		return nil, errors.New("participant name cannot be empty")
+		return nil, domainerrors.Invalid("participant name cannot be empty")
 	}
 	
 	if !isValidEmail(email) {
-		return nil, errors.New("invalid email format")
+		return nil, domainerrors.Invalid("invalid email format")
 	}
 	
 	if timezone == nil {
@@ -36,9 +38,29 @@ func NewParticipant(name, email string, timezone *time.Location) (*Participant,
 		email:        email,
 		timezone:     timezone,
 		availability: make([]valueobjects.TimeSlot, 0),
+		series:       make([]AvailabilitySeries, 0),
+		holidays:     make([]string, 0),
 	}, nil
 }
 
+// RestoreParticipant rebuilds a participant from persisted state without
+// re-applying creation rules.
+func RestoreParticipant(id, name, email string, timezone *time.Location, availability []valueobjects.TimeSlot, series []AvailabilitySeries, holidayCalendars []string) *Participant {
+	if timezone == nil {
+		timezone = time.UTC
+	}
+
+	return &Participant{
+		id:           id,
+		name:         name,
+		email:        email,
+		timezone:     timezone,
+		availability: availability,
+		series:       series,
+		holidays:     holidayCalendars,
+	}
+}
+
 func (p *Participant) ID() string {
 	return p.id
 }
@@ -55,14 +77,89 @@ func (p *Participant) Timezone() *time.Location {
 	return p.timezone
 }
 
+// HolidayCalendars are the region codes of the holiday calendars the
+// participant observes.
+func (p *Participant) HolidayCalendars() []string {
+	return p.holidays
+}
+
+func (p *Participant) SetHolidayCalendars(regions []string) {
+	p.holidays = normalizeRegionCodes(regions)
+}
+
 func (p *Participant) Availability() []valueobjects.TimeSlot {
 	return p.availability
 }
 
+func (p *Participant) Rename(name string) error {
+	if name == "" {
+		return domainerrors.Invalid("participant name cannot be empty")
+	}
+	p.name = name
+	return nil
+}
+
+func (p *Participant) ChangeEmail(email string) error {
+	if !isValidEmail(email) {
+		return domainerrors.Invalid("invalid email format")
+	}
+	p.email = email
+	return nil
+}
+
+func (p *Participant) ChangeTimezone(timezone *time.Location) {
+	if timezone == nil {
+		timezone = time.UTC
+	}
+	p.timezone = timezone
+}
+
+// AvailabilitySeries returns the recurring availability rules. Their
+// occurrences are not part of Availability.
+func (p *Participant) AvailabilitySeries() []AvailabilitySeries {
+	return p.series
+}
+
 func (p *Participant) AddAvailability(slot valueobjects.TimeSlot) {
 	p.availability = append(p.availability, slot)
 }
 
+func (p *Participant) AddAvailabilitySeries(series AvailabilitySeries) {
+	p.series = append(p.series, series)
+}
+
+// RemoveAvailability removes a single slot, a whole series or, given an
+// occurrence ID, one occurrence of a series. It reports whether anything
+// matched.
+func (p *Participant) RemoveAvailability(id string) bool {
+	for i, slot := range p.availability {
+		if slot.ID() == id {
+			p.availability = append(p.availability[:i], p.availability[i+1:]...)
+			return true
+		}
+	}
+
+	for i, series := range p.series {
+		if series.ID() == id {
+			p.series = append(p.series[:i], p.series[i+1:]...)
+			return true
+		}
+	}
+
+	seriesID, start, ok := ParseOccurrenceID(id)
+	if !ok {
+		return false
+	}
+
+	for i, series := range p.series {
+		if series.ID() == seriesID && !series.IsExcluded(start) {
+			p.series[i].exceptions = append(append([]time.Time(nil), series.exceptions...), start)
+			return true
+		}
+	}
+	return false
+}
+
 func (p *Participant) IsAvailableAt(timeRange valueobjects.TimeRange) bool {
 	for _, slot := range p.availability {
 		if slot.Contains(timeRange) {
@@ -1,10 +1,10 @@
 package entities
 
 import (
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
 	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 )
 
@@ -12,14 +12,17 @@ type Schedule struct {
 	id           string
 	ownerID      string
 	timezone     *time.Location
-	workingHours valueobjects.TimeRange
+	workingHours valueobjects.WorkingHours
+	holidays     []string
 	appointments []*Appointment
-	blockedTimes []valueobjects.TimeRange
+	blockedTimes []valueobjects.BlockedTime
+	policy       ConflictPolicy
+	version      int
 }
 
-func NewSchedule(ownerID string, timezone *time.Location, workingHours valueobjects.TimeRange) (*Schedule, error) {
+func NewSchedule(ownerID string, timezone *time.Location, workingHours valueobjects.WorkingHours) (*Schedule, error) {
 	if ownerID == "" {
-		return nil, errors.New("owner ID cannot be empty")
+		return nil, domainerrors.Invalid("owner ID cannot be empty")
 	}
 	
 	if timezone == nil {
@@ -31,11 +34,33 @@ func NewSchedule(ownerID string, timezone *time.Location, workingHours valueobje
 		ownerID:      ownerID,
 		timezone:     timezone,
 		workingHours: workingHours,
+		holidays:     make([]string, 0),
 		appointments: make([]*Appointment, 0),
-		blockedTimes: make([]valueobjects.TimeRange, 0),
+		blockedTimes: make([]valueobjects.BlockedTime, 0),
+		policy:       DefaultConflictPolicy(),
 	}, nil
 }
 
+// RestoreSchedule rebuilds a schedule from persisted state without
+// re-applying creation rules.
+func RestoreSchedule(id, ownerID string, timezone *time.Location, workingHours valueobjects.WorkingHours, holidayCalendars []string, appointments []*Appointment, blockedTimes []valueobjects.BlockedTime, policy ConflictPolicy, version int) *Schedule {
+	if timezone == nil {
+		timezone = time.UTC
+	}
+
+	return &Schedule{
+		id:           id,
+		ownerID:      ownerID,
+		timezone:     timezone,
+		workingHours: workingHours,
+		holidays:     holidayCalendars,
+		appointments: appointments,
+		blockedTimes: blockedTimes,
+		policy:       policy,
+		version:      version,
+	}
+}
+
 func (s *Schedule) ID() string {
 	return s.id
 }
@@ -48,25 +73,69 @@ func (s *Schedule) Timezone() *time.Location {
 	return s.timezone
 }
 
-func (s *Schedule) WorkingHours() valueobjects.TimeRange {
+// WorkingHours is the weekly template, in the schedule's timezone.
+func (s *Schedule) WorkingHours() valueobjects.WorkingHours {
 	return s.workingHours
 }
 
+// HolidayCalendars are the region codes of the holiday calendars the
+// schedule observes.
+func (s *Schedule) HolidayCalendars() []string {
+	return s.holidays
+}
+
 func (s *Schedule) Appointments() []*Appointment {
 	return s.appointments
 }
 
-func (s *Schedule) BlockedTimes() []valueobjects.TimeRange {
+func (s *Schedule) BlockedTimes() []valueobjects.BlockedTime {
 	return s.blockedTimes
 }
 
+// ConflictPolicy decides which appointments take up the owner's time and
+// how close together they may be.
+func (s *Schedule) ConflictPolicy() ConflictPolicy {
+	return s.policy
+}
+
+// Blocks reports whether appointment takes up the owner's time under the
+// schedule's conflict policy.
+func (s *Schedule) Blocks(appointment *Appointment) bool {
+	return s.policy.Blocks(appointment, s.ownerID)
+}
+
+// ClashingAppointments returns the appointments that keep timeRange from
+// being booked under the schedule's conflict policy.
+func (s *Schedule) ClashingAppointments(timeRange valueobjects.TimeRange) []AppointmentClash {
+	return s.policy.clashingAppointments(s.appointments, s.ownerID, timeRange)
+}
+
+// Version is the number of times the schedule has been stored. It is 0
+// until the schedule is first saved.
+func (s *Schedule) Version() int {
+	return s.version
+}
+
+// IncrementVersion is called by repositories after a successful write.
+func (s *Schedule) IncrementVersion() {
+	s.version++
+}
+
+// AddAppointment books appointment on the schedule. Appointments that don't
+// take up the owner's time, such as ones they are optional for, are added
+// without checking working hours or conflicts.
 func (s *Schedule) AddAppointment(appointment *Appointment) error {
-	if !s.isWithinWorkingHours(appointment.TimeRange()) {
-		return errors.New("appointment is outside working hours")
+	if !s.Blocks(appointment) {
+		s.appointments = append(s.appointments, appointment)
+		return nil
+	}
+
+	if !s.IsWithinWorkingHours(appointment.TimeRange()) {
+		return domainerrors.Conflict("appointment is outside working hours")
 	}
 	
 	if s.hasConflict(appointment.TimeRange()) {
-		return errors.New("appointment conflicts with existing schedule")
+		return domainerrors.Conflict("appointment conflicts with existing schedule")
 	}
 	
 	s.appointments = append(s.appointments, appointment)
@@ -80,35 +149,63 @@ func (s *Schedule) RemoveAppointment(appointmentID string) error {
 			return nil
 		}
 	}
-	return errors.New("appointment not found")
+	return domainerrors.NotFound("appointment not found")
+}
+
+func (s *Schedule) AddBlockedTime(timeRange valueobjects.TimeRange, reason string) valueobjects.BlockedTime {
+	blocked := valueobjects.NewBlockedTime(timeRange, reason)
+	s.blockedTimes = append(s.blockedTimes, blocked)
+	return blocked
+}
+
+func (s *Schedule) RemoveBlockedTime(blockedTimeID string) error {
+	for i, blocked := range s.blockedTimes {
+		if blocked.ID() == blockedTimeID {
+			s.blockedTimes = append(s.blockedTimes[:i], s.blockedTimes[i+1:]...)
+			return nil
+		}
+	}
+	return domainerrors.NotFound("blocked time not found")
+}
+
+func (s *Schedule) SetWorkingHours(workingHours valueobjects.WorkingHours) {
+	s.workingHours = workingHours
+}
+
+func (s *Schedule) SetHolidayCalendars(regions []string) {
+	s.holidays = normalizeRegionCodes(regions)
 }
 
-func (s *Schedule) AddBlockedTime(timeRange valueobjects.TimeRange) {
-	s.blockedTimes = append(s.blockedTimes, timeRange)
+// SetConflictPolicy applies to later bookings; appointments already on the
+// schedule stay even if they clash under the new policy.
+func (s *Schedule) SetConflictPolicy(policy ConflictPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	s.policy = policy
+	return nil
 }
 
 func (s *Schedule) IsAvailable(timeRange valueobjects.TimeRange) bool {
-	return s.isWithinWorkingHours(timeRange) && !s.hasConflict(timeRange)
+	return s.IsWithinWorkingHours(timeRange) && !s.hasConflict(timeRange)
 }
 
-func (s *Schedule) isWithinWorkingHours(timeRange valueobjects.TimeRange) bool {
-	return s.workingHours.Contains(timeRange)
+// IsWithinWorkingHours evaluates the working-hours template as wall-clock time
+// in the schedule's timezone, so it follows DST changes.
+func (s *Schedule) IsWithinWorkingHours(timeRange valueobjects.TimeRange) bool {
+	return s.workingHours.Covers(timeRange, s.timezone)
 }
 
 func (s *Schedule) hasConflict(timeRange valueobjects.TimeRange) bool {
-	// Check appointments
-	for _, appointment := range s.appointments {
-		if appointment.Status() != StatusCancelled && appointment.TimeRange().OverlapsWith(timeRange) {
-			return true
-		}
+	if len(s.ClashingAppointments(timeRange)) > 0 {
+		return true
 	}
-	
-	// Check blocked times
+
 	for _, blocked := range s.blockedTimes {
-		if blocked.OverlapsWith(timeRange) {
+		if _, ok := s.policy.Clashes(blocked.TimeRange(), timeRange); ok {
 			return true
 		}
 	}
-	
+
 	return false
 }
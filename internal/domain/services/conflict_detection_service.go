@@ -5,94 +5,127 @@ import (
 	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 )
 
-type ConflictDetectionService struct{}
+type ConflictDetectionService struct {
+	holidays *HolidayCalendarService
+}
 
-func NewConflictDetectionService() *ConflictDetectionService {
-	return &ConflictDetectionService{}
+// NewConflictDetectionService treats the holidays of the calendars a schedule
+// or participant subscribes to as blocked. holidays may be nil.
+func NewConflictDetectionService(holidays *HolidayCalendarService) *ConflictDetectionService {
+	return &ConflictDetectionService{
+		holidays: holidays,
+	}
 }
 
+// ConflictResult lists everything that keeps a proposed time from being
+// booked on a schedule.
 type ConflictResult struct {
-	HasConflict        bool
-	ConflictingSlots   []ConflictingSlot
-	ConflictType       ConflictType
-	Severity          ConflictSeverity
+	HasConflict bool
+	Conflicts   []Conflict
+	Severity    ConflictSeverity // The most severe of Conflicts
 }
 
-type ConflictingSlot struct {
-	AppointmentID string
-	TimeRange     valueobjects.TimeRange
-	OverlapRange  valueobjects.TimeRange
+// Conflict is one appointment, blocked time, holiday or rule that clashes
+// with a proposed time.
+type Conflict struct {
+	Type         ConflictType
+	SourceID     string                 // Appointment, blocked time or holiday ID; empty for working hours and availability
+	TimeRange    valueobjects.TimeRange // Of the source; the proposed time for working hours and availability
+	OverlapRange valueobjects.TimeRange // Of the buffers for a buffer conflict
+	Severity     ConflictSeverity
 }
 
 type ConflictType string
 
 const (
-	ConflictTypeAppointment ConflictType = "appointment"
-	ConflictTypeBlocked     ConflictType = "blocked_time"
+	ConflictTypeAppointment  ConflictType = "appointment"
+	ConflictTypeBuffer       ConflictType = "buffer" // Within the buffer time around an appointment
+	ConflictTypeBlocked      ConflictType = "blocked_time"
 	ConflictTypeWorkingHours ConflictType = "working_hours"
+	ConflictTypeHoliday      ConflictType = "holiday"
+	ConflictTypeAvailability ConflictType = "availability" // Outside the participant's availability
 )
 
 type ConflictSeverity string
 
 const (
-	SeverityMinor    ConflictSeverity = "minor"     // Small overlap
-	SeverityMajor    ConflictSeverity = "major"     // Significant overlap
-	SeverityCritical ConflictSeverity = "critical"  // Complete overlap
+	SeverityMinor    ConflictSeverity = "minor"    // Small overlap, or only buffers clash
+	SeverityMajor    ConflictSeverity = "major"    // Significant overlap
+	SeverityCritical ConflictSeverity = "critical" // Complete overlap
 )
 
+var severityRanks = map[ConflictSeverity]int{
+	SeverityMinor:    1,
+	SeverityMajor:    2,
+	SeverityCritical: 3,
+}
+
+// MoreSevereThan reports whether s is worse than other; any severity is worse
+// than none.
+func (s ConflictSeverity) MoreSevereThan(other ConflictSeverity) bool {
+	return severityRanks[s] > severityRanks[other]
+}
+
+// add records conflict and raises the result's severity to match.
+func (r *ConflictResult) add(conflict Conflict) {
+	r.HasConflict = true
+	r.Conflicts = append(r.Conflicts, conflict)
+	if conflict.Severity.MoreSevereThan(r.Severity) {
+		r.Severity = conflict.Severity
+	}
+}
+
+// DetectConflicts checks a proposed time against the schedule's working
+// hours, appointments, blocked times and holidays, and reports every clash
+// rather than stopping at the first. Appointments and blocked times clash
+// under the schedule's conflict policy, which also sets the severity
+// thresholds.
 func (s *ConflictDetectionService) DetectConflicts(schedule *entities.Schedule, proposedTimeRange valueobjects.TimeRange) ConflictResult {
 	result := ConflictResult{
-		HasConflict:      false,
-		ConflictingSlots: make([]ConflictingSlot, 0),
+		HasConflict: false,
+		Conflicts:   make([]Conflict, 0),
 	}
 
 	// Check working hours conflict
-	if !schedule.WorkingHours().Contains(proposedTimeRange) {
-		result.HasConflict = true
-		result.ConflictType = ConflictTypeWorkingHours
-		result.Severity = SeverityCritical
-		return result
+	if !schedule.IsWithinWorkingHours(proposedTimeRange) {
+		result.add(Conflict{
+			Type:         ConflictTypeWorkingHours,
+			TimeRange:    proposedTimeRange,
+			OverlapRange: proposedTimeRange,
+			Severity:     SeverityCritical,
+		})
 	}
 
-	// Check appointment conflicts
-	for _, appointment := range schedule.Appointments() {
-		if appointment.Status() == entities.StatusCancelled {
-			continue
+	policy := schedule.ConflictPolicy()
+
+	// Check appointment conflicts; appointments that aren't busy under the
+	// policy, such as cancelled ones, leave the time free
+	for _, clash := range schedule.ClashingAppointments(proposedTimeRange) {
+		conflict := Conflict{
+			Type:         ConflictTypeAppointment,
+			SourceID:     clash.Appointment.ID(),
+			TimeRange:    clash.Appointment.TimeRange(),
+			OverlapRange: clash.Overlap,
+			Severity:     s.calculateSeverity(policy, proposedTimeRange, clash.Overlap),
 		}
-
-		if appointment.TimeRange().OverlapsWith(proposedTimeRange) {
-			result.HasConflict = true
-			result.ConflictType = ConflictTypeAppointment
-			
-			overlapRange := s.calculateOverlap(appointment.TimeRange(), proposedTimeRange)
-			conflictingSlot := ConflictingSlot{
-				AppointmentID: appointment.ID(),
-				TimeRange:     appointment.TimeRange(),
-				OverlapRange:  overlapRange,
-			}
-			result.ConflictingSlots = append(result.ConflictingSlots, conflictingSlot)
+		if clash.InBuffer {
+			conflict.Type = ConflictTypeBuffer
+			conflict.Severity = SeverityMinor
 		}
+		result.add(conflict)
 	}
 
 	// Check blocked time conflicts
 	for _, blockedTime := range schedule.BlockedTimes() {
-		if blockedTime.OverlapsWith(proposedTimeRange) {
-			result.HasConflict = true
-			result.ConflictType = ConflictTypeBlocked
-			
-			overlapRange := s.calculateOverlap(blockedTime, proposedTimeRange)
-			conflictingSlot := ConflictingSlot{
-				AppointmentID: "blocked",
-				TimeRange:     blockedTime,
-				OverlapRange:  overlapRange,
-			}
-			result.ConflictingSlots = append(result.ConflictingSlots, conflictingSlot)
+		if overlapRange, ok := policy.Clashes(blockedTime.TimeRange(), proposedTimeRange); ok {
+			result.add(s.overlapConflict(policy, ConflictTypeBlocked, blockedTime.ID(), blockedTime.TimeRange(), overlapRange, proposedTimeRange))
 		}
 	}
 
-	// Determine severity based on overlap
-	if result.HasConflict {
-		result.Severity = s.calculateSeverity(proposedTimeRange, result.ConflictingSlots)
+	// Check holiday conflicts in the schedule's timezone
+	for _, holiday := range s.holidays.HolidaysOverlapping(schedule.HolidayCalendars(), proposedTimeRange, schedule.Timezone()) {
+		overlapRange, _ := holiday.TimeRange.Overlap(proposedTimeRange)
+		result.add(s.overlapConflict(policy, ConflictTypeHoliday, holiday.Holiday.ID(), holiday.TimeRange, overlapRange, proposedTimeRange))
 	}
 
 	return result
@@ -102,49 +135,57 @@ func (s *ConflictDetectionService) DetectMultiParticipantConflicts(participants
 	conflicts := make(map[string]ConflictResult)
 
 	for _, participant := range participants {
+		result := ConflictResult{
+			HasConflict: false,
+			Conflicts:   make([]Conflict, 0),
+		}
+
 		if !participant.IsAvailableAt(proposedTimeRange) {
-			conflicts[participant.ID()] = ConflictResult{
-				HasConflict:  true,
-				ConflictType: ConflictTypeAppointment,
+			result.add(Conflict{
+				Type:         ConflictTypeAvailability,
+				TimeRange:    proposedTimeRange,
+				OverlapRange: proposedTimeRange,
 				Severity:     SeverityCritical,
-			}
+			})
+		}
+
+		for _, holiday := range s.participantHolidays(participant, proposedTimeRange) {
+			overlapRange, _ := holiday.TimeRange.Overlap(proposedTimeRange)
+			result.add(s.overlapConflict(entities.DefaultConflictPolicy(), ConflictTypeHoliday, holiday.Holiday.ID(), holiday.TimeRange, overlapRange, proposedTimeRange))
+		}
+
+		if result.HasConflict {
+			conflicts[participant.ID()] = result
 		}
 	}
 
 	return conflicts
 }
 
-func (s *ConflictDetectionService) calculateOverlap(timeRange1, timeRange2 valueobjects.TimeRange) valueobjects.TimeRange {
-	start := timeRange1.StartTime()
-	if timeRange2.StartTime().After(start) {
-		start = timeRange2.StartTime()
-	}
-
-	end := timeRange1.EndTime()
-	if timeRange2.EndTime().Before(end) {
-		end = timeRange2.EndTime()
+// overlapConflict describes a source occupying sourceRange, which overlaps
+// the proposed time by overlapRange.
+func (s *ConflictDetectionService) overlapConflict(policy entities.ConflictPolicy, conflictType ConflictType, sourceID string, sourceRange, overlapRange, proposedTimeRange valueobjects.TimeRange) Conflict {
+	return Conflict{
+		Type:         conflictType,
+		SourceID:     sourceID,
+		TimeRange:    sourceRange,
+		OverlapRange: overlapRange,
+		Severity:     s.calculateSeverity(policy, proposedTimeRange, overlapRange),
 	}
-
-	overlapRange, _ := valueobjects.NewTimeRange(start, end)
-	return overlapRange
 }
 
-func (s *ConflictDetectionService) calculateSeverity(proposedTimeRange valueobjects.TimeRange, conflicts []ConflictingSlot) ConflictSeverity {
-	proposedDuration := proposedTimeRange.Duration()
-	maxOverlapDuration := proposedDuration * 0
-
-	for _, conflict := range conflicts {
-		overlapDuration := conflict.OverlapRange.Duration()
-		if overlapDuration > maxOverlapDuration {
-			maxOverlapDuration = overlapDuration
-		}
-	}
+// participantHolidays returns the participant's holidays overlapping
+// timeRange, in the participant's own timezone.
+func (s *ConflictDetectionService) participantHolidays(participant *entities.Participant, timeRange valueobjects.TimeRange) []HolidayPeriod {
+	return s.holidays.HolidaysOverlapping(participant.HolidayCalendars(), timeRange, participant.Timezone())
+}
 
-	overlapPercentage := float64(maxOverlapDuration) / float64(proposedDuration)
+func (s *ConflictDetectionService) calculateSeverity(policy entities.ConflictPolicy, proposedTimeRange valueobjects.TimeRange, overlapRange valueobjects.TimeRange) ConflictSeverity {
+	overlapPercentage := float64(overlapRange.Duration()) / float64(proposedTimeRange.Duration())
 
-	if overlapPercentage >= 0.8 {
+	if overlapPercentage >= policy.CriticalThreshold {
 		return SeverityCritical
-	} else if overlapPercentage >= 0.3 {
+	} else if overlapPercentage >= policy.MajorThreshold {
 		return SeverityMajor
 	}
 	return SeverityMinor
@@ -0,0 +1,202 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+func mustTimeRange(t *testing.T, start, end string) valueobjects.TimeRange {
+	t.Helper()
+	startTime, err := time.Parse(time.RFC3339, start)
+	require.NoError(t, err)
+	endTime, err := time.Parse(time.RFC3339, end)
+	require.NoError(t, err)
+	timeRange, err := valueobjects.NewTimeRange(startTime, endTime)
+	require.NoError(t, err)
+	return timeRange
+}
+
+// TestDetectConflictsReportsEveryConflict proposes a Monday 16:00-18:00 slot
+// that runs past working hours and overlaps both an appointment and a blocked
+// time; each is reported with its own source and severity.
+func TestDetectConflictsReportsEveryConflict(t *testing.T) {
+	schedule, err := entities.NewSchedule("alice", time.UTC, valueobjects.DefaultWorkingHours())
+	require.NoError(t, err)
+
+	appointment, err := entities.NewAppointment("Review", mustTimeRange(t, "2030-01-07T16:00:00Z", "2030-01-07T17:00:00Z"), entities.RequiredAttendees([]string{"alice"}), "")
+	require.NoError(t, err)
+	require.NoError(t, schedule.AddAppointment(appointment))
+	blocked := schedule.AddBlockedTime(mustTimeRange(t, "2030-01-07T16:30:00Z", "2030-01-07T18:00:00Z"), "gym")
+
+	detector := services.NewConflictDetectionService(nil)
+	result := detector.DetectConflicts(schedule, mustTimeRange(t, "2030-01-07T16:00:00Z", "2030-01-07T18:00:00Z"))
+
+	require.True(t, result.HasConflict)
+	require.Len(t, result.Conflicts, 3)
+	assert.Equal(t, services.SeverityCritical, result.Severity)
+
+	assert.Equal(t, services.ConflictTypeWorkingHours, result.Conflicts[0].Type)
+	assert.Equal(t, services.SeverityCritical, result.Conflicts[0].Severity)
+
+	assert.Equal(t, services.ConflictTypeAppointment, result.Conflicts[1].Type)
+	assert.Equal(t, appointment.ID(), result.Conflicts[1].SourceID)
+	assert.Equal(t, time.Hour, result.Conflicts[1].OverlapRange.Duration())
+	assert.Equal(t, services.SeverityMajor, result.Conflicts[1].Severity)
+
+	assert.Equal(t, services.ConflictTypeBlocked, result.Conflicts[2].Type)
+	assert.Equal(t, blocked.ID(), result.Conflicts[2].SourceID)
+	assert.Equal(t, 90*time.Minute, result.Conflicts[2].OverlapRange.Duration())
+	assert.Equal(t, services.SeverityMajor, result.Conflicts[2].Severity)
+}
+
+func TestDetectConflictsWithoutConflicts(t *testing.T) {
+	schedule, err := entities.NewSchedule("alice", time.UTC, valueobjects.DefaultWorkingHours())
+	require.NoError(t, err)
+
+	detector := services.NewConflictDetectionService(nil)
+	result := detector.DetectConflicts(schedule, mustTimeRange(t, "2030-01-07T10:00:00Z", "2030-01-07T11:00:00Z"))
+
+	assert.False(t, result.HasConflict)
+	assert.Empty(t, result.Conflicts)
+	assert.Empty(t, result.Severity)
+}
+
+// TestDetectConflictsFollowsSchedulePolicy books Monday 10:00-11:00 and
+// 13:00-14:00 and proposes times around them under policies with buffers, an
+// overlap tolerance, double-booking and a narrower set of busy statuses.
+func TestDetectConflictsFollowsSchedulePolicy(t *testing.T) {
+	newSchedule := func(t *testing.T, policy entities.ConflictPolicy) (*entities.Schedule, *entities.Appointment) {
+		schedule, err := entities.NewSchedule("alice", time.UTC, valueobjects.DefaultWorkingHours())
+		require.NoError(t, err)
+		require.NoError(t, schedule.SetConflictPolicy(policy))
+
+		appointment, err := entities.NewAppointment("Review", mustTimeRange(t, "2030-01-07T10:00:00Z", "2030-01-07T11:00:00Z"), entities.RequiredAttendees([]string{"alice"}), "")
+		require.NoError(t, err)
+		require.NoError(t, schedule.AddAppointment(appointment))
+
+		later, err := entities.NewAppointment("Planning", mustTimeRange(t, "2030-01-07T13:00:00Z", "2030-01-07T14:00:00Z"), entities.RequiredAttendees([]string{"alice"}), "")
+		require.NoError(t, err)
+		require.NoError(t, schedule.AddAppointment(later))
+		return schedule, appointment
+	}
+	detector := services.NewConflictDetectionService(nil)
+
+	t.Run("buffers", func(t *testing.T) {
+		policy := entities.DefaultConflictPolicy()
+		policy.PostBuffer = 15 * time.Minute
+		schedule, appointment := newSchedule(t, policy)
+
+		result := detector.DetectConflicts(schedule, mustTimeRange(t, "2030-01-07T11:10:00Z", "2030-01-07T12:00:00Z"))
+		require.Len(t, result.Conflicts, 1)
+		assert.Equal(t, services.ConflictTypeBuffer, result.Conflicts[0].Type)
+		assert.Equal(t, appointment.ID(), result.Conflicts[0].SourceID)
+		assert.Equal(t, services.SeverityMinor, result.Conflicts[0].Severity)
+
+		result = detector.DetectConflicts(schedule, mustTimeRange(t, "2030-01-07T11:15:00Z", "2030-01-07T12:00:00Z"))
+		assert.False(t, result.HasConflict)
+	})
+
+	t.Run("overlap tolerance", func(t *testing.T) {
+		policy := entities.DefaultConflictPolicy()
+		policy.OverlapTolerance = 5 * time.Minute
+		schedule, _ := newSchedule(t, policy)
+
+		result := detector.DetectConflicts(schedule, mustTimeRange(t, "2030-01-07T10:55:00Z", "2030-01-07T12:00:00Z"))
+		assert.False(t, result.HasConflict)
+
+		result = detector.DetectConflicts(schedule, mustTimeRange(t, "2030-01-07T10:50:00Z", "2030-01-07T12:00:00Z"))
+		require.Len(t, result.Conflicts, 1)
+		assert.Equal(t, services.ConflictTypeAppointment, result.Conflicts[0].Type)
+	})
+
+	t.Run("concurrent appointments", func(t *testing.T) {
+		policy := entities.DefaultConflictPolicy()
+		policy.MaxConcurrent = 2
+		schedule, appointment := newSchedule(t, policy)
+
+		result := detector.DetectConflicts(schedule, mustTimeRange(t, "2030-01-07T10:00:00Z", "2030-01-07T11:00:00Z"))
+		assert.False(t, result.HasConflict)
+
+		double, err := entities.NewAppointment("Standup", mustTimeRange(t, "2030-01-07T10:30:00Z", "2030-01-07T11:30:00Z"), entities.RequiredAttendees([]string{"alice"}), "")
+		require.NoError(t, err)
+		require.NoError(t, schedule.AddAppointment(double))
+
+		// 10:00-10:30 only overlaps one appointment; 10:30-11:00 overlaps both
+		result = detector.DetectConflicts(schedule, mustTimeRange(t, "2030-01-07T09:30:00Z", "2030-01-07T10:30:00Z"))
+		assert.False(t, result.HasConflict)
+
+		result = detector.DetectConflicts(schedule, mustTimeRange(t, "2030-01-07T10:00:00Z", "2030-01-07T11:00:00Z"))
+		require.Len(t, result.Conflicts, 2)
+		assert.Equal(t, appointment.ID(), result.Conflicts[0].SourceID)
+		assert.Equal(t, double.ID(), result.Conflicts[1].SourceID)
+	})
+
+	t.Run("busy statuses", func(t *testing.T) {
+		policy := entities.DefaultConflictPolicy()
+		policy.BusyStatuses = []entities.AppointmentStatus{entities.StatusConfirmed, entities.StatusInProgress}
+		schedule, _ := newSchedule(t, policy)
+
+		tentative, err := entities.NewTentativeAppointment("Hold", mustTimeRange(t, "2030-01-07T15:00:00Z", "2030-01-07T16:00:00Z"), entities.RequiredAttendees([]string{"alice"}), "")
+		require.NoError(t, err)
+		require.NoError(t, schedule.AddAppointment(tentative))
+
+		result := detector.DetectConflicts(schedule, mustTimeRange(t, "2030-01-07T15:00:00Z", "2030-01-07T16:00:00Z"))
+		assert.False(t, result.HasConflict)
+	})
+
+	t.Run("severity thresholds", func(t *testing.T) {
+		policy := entities.DefaultConflictPolicy()
+		policy.MajorThreshold = 0.1
+		policy.CriticalThreshold = 0.5
+		schedule, _ := newSchedule(t, policy)
+
+		result := detector.DetectConflicts(schedule, mustTimeRange(t, "2030-01-07T10:30:00Z", "2030-01-07T11:30:00Z"))
+		require.Len(t, result.Conflicts, 1)
+		assert.Equal(t, services.SeverityCritical, result.Conflicts[0].Severity)
+	})
+}
+
+// TestDetectConflictsReportsHolidays subscribes a Berlin schedule to a
+// calendar with 22 April 2030 as a holiday. The holiday is a whole local day,
+// so it starts at 22:00 UTC the evening before.
+func TestDetectConflictsReportsHolidays(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	easterMonday := entities.NewEasterHolidayRule("Ostermontag", 1)
+	calendar, err := entities.NewHolidayCalendar("DE", "Germany", []entities.HolidayRule{easterMonday})
+	require.NoError(t, err)
+	detector := services.NewConflictDetectionService(services.NewHolidayCalendarService([]*entities.HolidayCalendar{calendar}))
+
+	schedule, err := entities.NewSchedule("alice", berlin, valueobjects.DefaultWorkingHours())
+	require.NoError(t, err)
+
+	result := detector.DetectConflicts(schedule, mustTimeRange(t, "2030-04-22T08:00:00Z", "2030-04-22T09:00:00Z"))
+	assert.False(t, result.HasConflict, "not subscribed")
+
+	schedule.SetHolidayCalendars([]string{"de"})
+	result = detector.DetectConflicts(schedule, mustTimeRange(t, "2030-04-22T08:00:00Z", "2030-04-22T09:00:00Z"))
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, services.ConflictTypeHoliday, result.Conflicts[0].Type)
+	assert.Equal(t, services.SeverityCritical, result.Conflicts[0].Severity)
+
+	// Only the half hour after midnight in Berlin falls on the holiday
+	result = detector.DetectConflicts(schedule, mustTimeRange(t, "2030-04-21T21:30:00Z", "2030-04-21T22:30:00Z"))
+	var holiday *services.Conflict
+	for i := range result.Conflicts {
+		if result.Conflicts[i].Type == services.ConflictTypeHoliday {
+			holiday = &result.Conflicts[i]
+		}
+	}
+	require.NotNil(t, holiday)
+	assert.Equal(t, 30*time.Minute, holiday.OverlapRange.Duration())
+
+	result = detector.DetectConflicts(schedule, mustTimeRange(t, "2030-04-23T08:00:00Z", "2030-04-23T09:00:00Z"))
+	assert.False(t, result.HasConflict)
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// HolidayCalendarService holds the loaded holiday calendars by region code
+// and answers which holidays a set of subscriptions has in a time range.
+type HolidayCalendarService struct {
+	calendars map[string]*entities.HolidayCalendar
+}
+
+// NewHolidayCalendarService indexes calendars by region. A later calendar
+// replaces an earlier one with the same region, so user-supplied files can
+// override the bundled ones.
+func NewHolidayCalendarService(calendars []*entities.HolidayCalendar) *HolidayCalendarService {
+	byRegion := make(map[string]*entities.HolidayCalendar, len(calendars))
+	for _, calendar := range calendars {
+		byRegion[calendar.Region()] = calendar
+	}
+
+	return &HolidayCalendarService{
+		calendars: byRegion,
+	}
+}
+
+func (s *HolidayCalendarService) Calendar(region string) (*entities.HolidayCalendar, bool) {
+	if s == nil {
+		return nil, false
+	}
+
+	calendar, exists := s.calendars[entities.NormalizeRegionCode(region)]
+	return calendar, exists
+}
+
+// Calendars returns every loaded calendar ordered by region.
+func (s *HolidayCalendarService) Calendars() []*entities.HolidayCalendar {
+	if s == nil {
+		return nil
+	}
+
+	result := make([]*entities.HolidayCalendar, 0, len(s.calendars))
+	for _, calendar := range s.calendars {
+		result = append(result, calendar)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Region() < result[j].Region()
+	})
+	return result
+}
+
+// HolidayPeriod is a holiday together with the instants it covers in the
+// timezone it was evaluated in.
+type HolidayPeriod struct {
+	Holiday   valueobjects.Holiday
+	TimeRange valueobjects.TimeRange
+}
+
+// HolidaysOverlapping returns the holidays of the subscribed regions that
+// overlap timeRange, with each holiday taken as a whole local day in
+// location. Unknown regions are ignored.
+func (s *HolidayCalendarService) HolidaysOverlapping(regions []string, timeRange valueobjects.TimeRange, location *time.Location) []HolidayPeriod {
+	result := make([]HolidayPeriod, 0)
+	if s == nil || len(regions) == 0 {
+		return result
+	}
+
+	if location == nil {
+		location = time.UTC
+	}
+
+	start := timeRange.StartTime().In(location)
+	end := timeRange.EndTime().In(location)
+
+	for _, region := range regions {
+		calendar, exists := s.Calendar(region)
+		if !exists {
+			continue
+		}
+
+		// Noon names each local date without being moved by DST
+		last := time.Date(end.Year(), end.Month(), end.Day(), 12, 0, 0, 0, location)
+		for noon := time.Date(start.Year(), start.Month(), start.Day(), 12, 0, 0, 0, location); !noon.After(last); noon = noon.AddDate(0, 0, 1) {
+			holiday, ok := calendar.HolidayOn(noon.Date())
+			if !ok {
+				continue
+			}
+
+			period := holiday.TimeRangeIn(location)
+			if period.OverlapsWith(timeRange) {
+				result = append(result, HolidayPeriod{Holiday: holiday, TimeRange: period})
+			}
+		}
+	}
+
+	return result
+}
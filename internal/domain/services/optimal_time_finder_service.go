@@ -19,22 +19,24 @@ func NewOptimalTimeFinderService(conflictDetector *ConflictDetectionService) *Op
 }
 
 type TimeOption struct {
-	TimeRange     valueobjects.TimeRange
-	Score         float64
-	Reason        string
-	Participants  []string
-	Conflicts     int
+	TimeRange         valueobjects.TimeRange
+	Score             float64
+	Reason            string
+	Participants      []string
+	Conflicts         int
+	OptionalConflicts int // Optional participants who can't make it
 }
 
 type FindOptimalTimeRequest struct {
-	Participants     []*entities.Participant
-	Duration         valueobjects.Duration
-	PreferredStart   time.Time
-	PreferredEnd     time.Time
-	EarliestStart    time.Time
-	LatestEnd        time.Time
-	TimeSlotInterval time.Duration
-	MaxOptions       int
+	Participants         []*entities.Participant
+	OptionalParticipants []*entities.Participant // Welcome but not needed; never rule a time out
+	Duration             valueobjects.Duration
+	PreferredStart       time.Time
+	PreferredEnd         time.Time
+	EarliestStart        time.Time
+	LatestEnd            time.Time
+	TimeSlotInterval     time.Duration
+	MaxOptions           int
 }
 
 func (s *OptimalTimeFinderService) FindOptimalTimes(request FindOptimalTimeRequest) []TimeOption {
@@ -58,9 +60,12 @@ func (s *OptimalTimeFinderService) FindOptimalTimes(request FindOptimalTimeReque
 		current = current.Add(request.TimeSlotInterval)
 	}
 
-	// Sort by score (highest first)
+	// Sort by score (highest first), then by fewest optional conflicts
 	sort.Slice(options, func(i, j int) bool {
-		return options[i].Score > options[j].Score
+		if options[i].Score != options[j].Score {
+			return options[i].Score > options[j].Score
+		}
+		return options[i].OptionalConflicts < options[j].OptionalConflicts
 	})
 
 	// Limit results
@@ -81,21 +86,31 @@ func (s *OptimalTimeFinderService) evaluateTimeOption(timeRange valueobjects.Tim
 	availableParticipants := make([]string, 0)
 	conflictCount := 0
 
-	// Check each participant's availability
+	// Check each participant's availability; their holidays count as busy
 	for _, participant := range request.Participants {
-		if participant.IsAvailableAt(timeRange) {
+		if s.isParticipantFree(participant, timeRange) {
 			availableParticipants = append(availableParticipants, participant.ID())
 		} else {
 			conflictCount++
 		}
 	}
 
-	option.Participants = availableParticipants
-	option.Conflicts = conflictCount
-
 	// Calculate base score based on participant availability
 	participantScore := float64(len(availableParticipants)) / float64(len(request.Participants)) * 100
 
+	optionalConflicts := 0
+	for _, participant := range request.OptionalParticipants {
+		if s.isParticipantFree(participant, timeRange) {
+			availableParticipants = append(availableParticipants, participant.ID())
+		} else {
+			optionalConflicts++
+		}
+	}
+
+	option.Participants = availableParticipants
+	option.Conflicts = conflictCount
+	option.OptionalConflicts = optionalConflicts
+
 	// Bonus for preferred time range
 	preferredScore := 0.0
 	if !request.PreferredStart.IsZero() && !request.PreferredEnd.IsZero() {
@@ -120,12 +135,13 @@ func (s *OptimalTimeFinderService) evaluateTimeOption(timeRange valueobjects.Tim
 		}
 	}
 
-	// Penalty for conflicts
+	// Penalty for conflicts. Optional participants are left out of the score:
+	// FindOptimalTimes only uses them to break ties between equal scores.
 	conflictPenalty := float64(conflictCount) * 25.0
 
 	option.Score = participantScore + preferredScore + businessHoursScore - conflictPenalty
 
-	// Don't return options with too many conflicts
+	// Don't return options with too many conflicts among required participants
 	if float64(conflictCount)/float64(len(request.Participants)) > 0.5 {
 		option.Score = 0
 		option.Reason = "Too many conflicts"
@@ -134,9 +150,15 @@ func (s *OptimalTimeFinderService) evaluateTimeOption(timeRange valueobjects.Tim
 	return option
 }
 
+// isParticipantFree reports whether participant is available for all of
+// timeRange and it doesn't fall on one of their holidays.
+func (s *OptimalTimeFinderService) isParticipantFree(participant *entities.Participant, timeRange valueobjects.TimeRange) bool {
+	return participant.IsAvailableAt(timeRange) && len(s.conflictDetector.participantHolidays(participant, timeRange)) == 0
+}
+
 func (s *OptimalTimeFinderService) FindNextAvailableSlot(schedule *entities.Schedule, duration valueobjects.Duration, after time.Time) (valueobjects.TimeRange, bool) {
 	current := after
-	interval := 15 * time.Minute // 15-minute intervals
+	interval := 15 * time.Minute     // 15-minute intervals
 	maxSearch := 30 * 24 * time.Hour // Search for up to 30 days
 
 	for elapsed := time.Duration(0); elapsed < maxSearch; elapsed += interval {
@@ -157,3 +179,46 @@ func (s *OptimalTimeFinderService) FindNextAvailableSlot(schedule *entities.Sche
 
 	return valueobjects.TimeRange{}, false
 }
+
+// FindNearestAvailableSlots returns up to count times lasting as long as
+// proposed at which none of schedules has a conflict, nearest to proposed
+// first and the later one first on a tie. Times are tried every 15 minutes,
+// up to 30 days either side of proposed, and none start before notBefore.
+func (s *OptimalTimeFinderService) FindNearestAvailableSlots(schedules []*entities.Schedule, proposed valueobjects.TimeRange, count int, notBefore time.Time) []valueobjects.TimeRange {
+	result := make([]valueobjects.TimeRange, 0)
+	if count <= 0 {
+		return result
+	}
+
+	interval := 15 * time.Minute     // 15-minute intervals
+	maxSearch := 30 * 24 * time.Hour // Search for up to 30 days either side
+
+	for offset := interval; offset <= maxSearch; offset += interval {
+		for _, start := range []time.Time{proposed.StartTime().Add(offset), proposed.StartTime().Add(-offset)} {
+			if start.Before(notBefore) {
+				continue
+			}
+
+			timeRange, err := valueobjects.NewTimeRange(start, start.Add(proposed.Duration()))
+			if err != nil || !s.isFreeOnSchedules(schedules, timeRange) {
+				continue
+			}
+
+			result = append(result, timeRange)
+			if len(result) == count {
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
+func (s *OptimalTimeFinderService) isFreeOnSchedules(schedules []*entities.Schedule, timeRange valueobjects.TimeRange) bool {
+	for _, schedule := range schedules {
+		if s.conflictDetector.DetectConflicts(schedule, timeRange).HasConflict {
+			return false
+		}
+	}
+	return true
+}
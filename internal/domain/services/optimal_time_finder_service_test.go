@@ -0,0 +1,96 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// TestFindNearestAvailableSlots looks for an hour around a busy Monday
+// 15:00-16:00, with working hours ending at 17:00 and the morning blocked on
+// the other schedule.
+func TestFindNearestAvailableSlots(t *testing.T) {
+	alice, err := entities.NewSchedule("alice", time.UTC, valueobjects.DefaultWorkingHours())
+	require.NoError(t, err)
+	busy, err := entities.NewAppointment("Review", mustTimeRange(t, "2030-01-07T15:00:00Z", "2030-01-07T16:00:00Z"), entities.RequiredAttendees([]string{"alice"}), "")
+	require.NoError(t, err)
+	require.NoError(t, alice.AddAppointment(busy))
+
+	bob, err := entities.NewSchedule("bob", time.UTC, valueobjects.DefaultWorkingHours())
+	require.NoError(t, err)
+	bob.AddBlockedTime(mustTimeRange(t, "2030-01-07T09:00:00Z", "2030-01-07T14:30:00Z"), "offsite")
+
+	finder := services.NewOptimalTimeFinderService(services.NewConflictDetectionService(nil))
+	slots := finder.FindNearestAvailableSlots(
+		[]*entities.Schedule{alice, bob},
+		mustTimeRange(t, "2030-01-07T15:00:00Z", "2030-01-07T16:00:00Z"),
+		3,
+		time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	)
+
+	starts := make([]string, len(slots))
+	for i, slot := range slots {
+		starts[i] = slot.StartTime().Format("2006-01-02 15:04")
+		assert.Equal(t, time.Hour, slot.Duration())
+	}
+	assert.Equal(t, []string{"2030-01-07 16:00", "2030-01-08 09:00", "2030-01-08 09:15"}, starts)
+}
+
+func TestFindNearestAvailableSlotsNotBefore(t *testing.T) {
+	alice, err := entities.NewSchedule("alice", time.UTC, valueobjects.DefaultWorkingHours())
+	require.NoError(t, err)
+
+	finder := services.NewOptimalTimeFinderService(services.NewConflictDetectionService(nil))
+	slots := finder.FindNearestAvailableSlots(
+		[]*entities.Schedule{alice},
+		mustTimeRange(t, "2030-01-07T07:00:00Z", "2030-01-07T08:00:00Z"),
+		1,
+		time.Date(2030, 1, 7, 9, 30, 0, 0, time.UTC),
+	)
+
+	require.Len(t, slots, 1)
+	assert.Equal(t, time.Date(2030, 1, 7, 9, 30, 0, 0, time.UTC), slots[0].StartTime())
+}
+
+// TestFindOptimalTimesOptionalParticipants has alice free all morning and
+// carol, who is optional, free only from 10:00 to 11:00.
+func TestFindOptimalTimesOptionalParticipants(t *testing.T) {
+	alice, err := entities.NewParticipant("Alice", "alice@example.com", time.UTC)
+	require.NoError(t, err)
+	alice.AddAvailability(valueobjects.NewTimeSlot(mustTimeRange(t, "2030-01-07T09:00:00Z", "2030-01-07T12:00:00Z"), true, ""))
+
+	carol, err := entities.NewParticipant("Carol", "carol@example.com", time.UTC)
+	require.NoError(t, err)
+	carol.AddAvailability(valueobjects.NewTimeSlot(mustTimeRange(t, "2030-01-07T10:00:00Z", "2030-01-07T11:00:00Z"), true, ""))
+
+	duration, err := valueobjects.NewDuration(time.Hour)
+	require.NoError(t, err)
+	request := services.FindOptimalTimeRequest{
+		Participants:         []*entities.Participant{alice},
+		OptionalParticipants: []*entities.Participant{carol},
+		Duration:             duration,
+		EarliestStart:        time.Date(2030, 1, 7, 9, 0, 0, 0, time.UTC),
+		LatestEnd:            time.Date(2030, 1, 7, 11, 30, 0, 0, time.UTC),
+		TimeSlotInterval:     time.Hour,
+		MaxOptions:           5,
+	}
+	finder := services.NewOptimalTimeFinderService(services.NewConflictDetectionService(nil))
+
+	options := finder.FindOptimalTimes(request)
+	require.Len(t, options, 2)
+	assert.Equal(t, 10, options[0].TimeRange.StartTime().Hour(), "carol breaks the tie")
+	assert.Equal(t, 0, options[0].OptionalConflicts)
+	assert.Equal(t, 1, options[1].OptionalConflicts)
+	assert.Equal(t, options[0].Score, options[1].Score)
+
+	request.PreferredStart = time.Date(2030, 1, 7, 9, 0, 0, 0, time.UTC)
+	request.PreferredEnd = time.Date(2030, 1, 7, 10, 0, 0, 0, time.UTC)
+	options = finder.FindOptimalTimes(request)
+	require.Len(t, options, 2)
+	assert.Equal(t, 9, options[0].TimeRange.StartTime().Hour(), "the preferred time outweighs carol")
+}
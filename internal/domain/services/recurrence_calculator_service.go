@@ -1,16 +1,27 @@
 package services
 
 import (
-	"errors"
 	"time"
 
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
 	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 )
 
-type RecurrenceCalculatorService struct{}
+type RecurrenceCalculatorService struct {
+	localTimePolicy valueobjects.LocalTimePolicy
+}
+
+// NewRecurrenceCalculatorService takes the policy used for rules that don't
+// say how to resolve local times around DST changes.
+func NewRecurrenceCalculatorService(localTimePolicy valueobjects.LocalTimePolicy) *RecurrenceCalculatorService {
+	return &RecurrenceCalculatorService{
+		localTimePolicy: localTimePolicy.Or(valueobjects.DefaultLocalTimePolicy()),
+	}
+}
 
-func NewRecurrenceCalculatorService() *RecurrenceCalculatorService {
-	return &RecurrenceCalculatorService{}
+// LocalTimePolicy is the policy for rules that don't set their own.
+func (s *RecurrenceCalculatorService) LocalTimePolicy() valueobjects.LocalTimePolicy {
+	return s.localTimePolicy
 }
 
 type RecurrencePattern string
@@ -25,11 +36,33 @@ const (
 
 type RecurrenceRule struct {
 	Pattern    RecurrencePattern
-	Interval   int               // Every N days/weeks/months
-	DaysOfWeek []time.Weekday    // For weekly patterns
-	DayOfMonth int               // For monthly patterns
-	EndDate    *time.Time        // When to stop recurring
-	MaxCount   int               // Maximum number of occurrences
+	Interval   int            // Every N days/weeks/months
+	DaysOfWeek []time.Weekday // For weekly patterns
+	DayOfMonth int            // For monthly patterns
+	EndDate    *time.Time     // When to stop recurring
+	MaxCount   int            // Maximum number of occurrences
+
+	// RFC 5545 rule parts, used by PatternCustom rules (see ParseRRule)
+	Frequency  RecurrencePattern // FREQ: daily, weekly, monthly or yearly
+	ByDay      []WeekdayNum      // BYDAY
+	ByMonthDay []int             // BYMONTHDAY, negative counts from the end of the month
+	ByMonth    []time.Month      // BYMONTH
+	BySetPos   []int             // BYSETPOS, negative counts from the end of the period
+	WeekStart  *time.Weekday     // WKST, Monday when nil; also used by PatternWeekly
+
+	// Timezone whose wall clock occurrences keep, the base start's location
+	// when nil. LocalTimePolicy resolves wall-clock times that fall in a DST
+	// gap or overlap there; unset parts use the calculator's policy.
+	Timezone        *time.Location
+	LocalTimePolicy valueobjects.LocalTimePolicy
+}
+
+// WeekdayNum is a BYDAY entry: a weekday with an optional ordinal, such as
+// 2 for the second or -1 for the last one in the month or year. An ordinal
+// of 0 means every such weekday.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	Ordinal int
 }
 
 type RecurrenceResult struct {
@@ -38,11 +71,24 @@ type RecurrenceResult struct {
 	NextDate   *time.Time
 }
 
+// CalculateRecurrences expands rule from baseTimeRange. Occurrences are
+// computed on the wall clock of the rule's timezone, so a 09:00 meeting stays
+// at 09:00 local time across DST changes, and each local start is then
+// resolved to an instant with the local time policy. Occurrences whose start
+// is skipped by the policy don't count towards MaxCount. As in RFC 5545,
+// whole days of the base duration are added on the wall clock and the rest
+// as exact time.
 func (s *RecurrenceCalculatorService) CalculateRecurrences(baseTimeRange valueobjects.TimeRange, rule RecurrenceRule) (RecurrenceResult, error) {
 	if rule.Interval <= 0 {
-		return RecurrenceResult{}, errors.New("recurrence interval must be positive")
+		return RecurrenceResult{}, domainerrors.Invalid("recurrence interval must be positive")
 	}
 
+	location := rule.Timezone
+	if location == nil {
+		location = baseTimeRange.StartTime().Location()
+	}
+	policy := rule.LocalTimePolicy.Or(s.localTimePolicy)
+
 	result := RecurrenceResult{
 		TimeRanges: make([]valueobjects.TimeRange, 0),
 	}
@@ -51,42 +97,85 @@ func (s *RecurrenceCalculatorService) CalculateRecurrences(baseTimeRange valueob
 	result.TimeRanges = append(result.TimeRanges, baseTimeRange)
 	result.Count = 1
 
-	current := baseTimeRange.StartTime()
-	duration := baseTimeRange.Duration()
+	// Rules are stepped on wall-clock times, held in UTC so date arithmetic
+	// never crosses a DST change
+	anchor := wallClock(baseTimeRange.StartTime().In(location))
+	days, rest := splitDuration(anchor, wallClock(baseTimeRange.EndTime().In(location)), baseTimeRange.Duration())
+
+	wallRule := rule
+	if rule.EndDate != nil {
+		wallEnd := wallClock(rule.EndDate.In(location))
+		wallRule.EndDate = &wallEnd
+	}
+
+	current := anchor
 
 	for {
 		// Calculate next occurrence
-		next := s.calculateNextOccurrence(current, rule)
+		next := s.calculateNextOccurrence(anchor, current, wallRule)
 		if next.IsZero() {
 			break
 		}
+		current = next
 
 		// Check end conditions
-		if rule.EndDate != nil && next.After(*rule.EndDate) {
+		if wallRule.EndDate != nil && next.After(*wallRule.EndDate) {
+			break
+		}
+
+		start, ok := policy.Resolve(next, location)
+		if !ok {
+			continue
+		}
+
+		if rule.EndDate != nil && start.After(*rule.EndDate) {
 			break
 		}
 
 		if rule.MaxCount > 0 && result.Count >= rule.MaxCount {
-			result.NextDate = &next
+			result.NextDate = &start
 			break
 		}
 
 		// Create time range for this occurrence
-		endTime := next.Add(duration)
-		timeRange, err := valueobjects.NewTimeRange(next, endTime)
+		endTime := start.Add(rest)
+		if days > 0 {
+			endDay, _ := valueobjects.LocalTimePolicy{Gap: valueobjects.GapShiftForward, Ambiguity: policy.Ambiguity}.Resolve(next.AddDate(0, 0, days), location)
+			endTime = endDay.Add(rest)
+		}
+		timeRange, err := valueobjects.NewTimeRange(start, endTime)
 		if err != nil {
 			break
 		}
 
 		result.TimeRanges = append(result.TimeRanges, timeRange)
 		result.Count++
-		current = next
 	}
 
 	return result, nil
 }
 
-func (s *RecurrenceCalculatorService) calculateNextOccurrence(current time.Time, rule RecurrenceRule) time.Time {
+// wallClock is the local date and time of t, as the same reading in UTC.
+func wallClock(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	return time.Date(year, month, day, hour, minute, second, t.Nanosecond(), time.UTC)
+}
+
+// splitDuration splits the time from start to end, both wall-clock times,
+// into whole days and the remainder; exact is the real length of the base
+// occurrence, which the remainder falls back to when no whole day fits.
+func splitDuration(start, end time.Time, exact time.Duration) (int, time.Duration) {
+	days := int(end.Sub(start) / (24 * time.Hour))
+	if days <= 0 {
+		return 0, exact
+	}
+	return days, end.Sub(start.AddDate(0, 0, days))
+}
+
+// calculateNextOccurrence returns the first occurrence after current. anchor
+// is the start of the series, which custom rules align their periods to.
+func (s *RecurrenceCalculatorService) calculateNextOccurrence(anchor, current time.Time, rule RecurrenceRule) time.Time {
 	switch rule.Pattern {
 	case PatternDaily:
 		return current.AddDate(0, 0, rule.Interval)
@@ -95,7 +184,7 @@ func (s *RecurrenceCalculatorService) calculateNextOccurrence(current time.Time,
 		if len(rule.DaysOfWeek) == 0 {
 			return current.AddDate(0, 0, 7*rule.Interval)
 		}
-		return s.findNextWeekday(current, rule.DaysOfWeek, rule.Interval)
+		return s.findNextWeekday(anchor, current, rule)
 
 	case PatternMonthly:
 		if rule.DayOfMonth > 0 {
@@ -106,38 +195,44 @@ func (s *RecurrenceCalculatorService) calculateNextOccurrence(current time.Time,
 	case PatternYearly:
 		return current.AddDate(rule.Interval, 0, 0)
 
+	case PatternCustom:
+		return s.findNextCustomOccurrence(anchor, current, rule)
+
 	default:
 		return time.Time{}
 	}
 }
 
-func (s *RecurrenceCalculatorService) findNextWeekday(current time.Time, daysOfWeek []time.Weekday, interval int) time.Time {
-	currentWeekday := current.Weekday()
-
-	// Find next valid weekday in current week
-	for _, day := range daysOfWeek {
-		if day > currentWeekday {
-			daysUntil := int(day - currentWeekday)
-			return current.AddDate(0, 0, daysUntil)
-		}
+// findNextWeekday returns the first of the rule's days of week after current,
+// in a week a whole number of intervals from the anchor's week. Weeks start on
+// rule.WeekStart, so the order and repetition of DaysOfWeek don't matter, and
+// nothing before the anchor is returned. This is the custom rule
+// FREQ=WEEKLY;BYDAY=... with the same interval and week start.
+func (s *RecurrenceCalculatorService) findNextWeekday(anchor, current time.Time, rule RecurrenceRule) time.Time {
+	weekly := RecurrenceRule{
+		Pattern:   PatternCustom,
+		Interval:  rule.Interval,
+		EndDate:   rule.EndDate,
+		Frequency: PatternWeekly,
+		ByDay:     make([]WeekdayNum, 0, len(rule.DaysOfWeek)),
+		WeekStart: rule.WeekStart,
 	}
-
-	// No valid day in current week, go to next week(s)
-	weeksToAdd := interval
-	firstDayOfWeek := daysOfWeek[0]
-	daysUntil := int(firstDayOfWeek) + 7*weeksToAdd - int(currentWeekday)
-	if daysUntil <= 0 {
-		daysUntil += 7
+	for _, day := range rule.DaysOfWeek {
+		weekly.ByDay = append(weekly.ByDay, WeekdayNum{Weekday: day})
 	}
 
-	return current.AddDate(0, 0, daysUntil)
+	return s.findNextCustomOccurrence(anchor, current, weekly)
 }
 
+// findNextMonthlyDate returns dayOfMonth interval months after current's
+// month, or the last day of that month when it is shorter. This is the
+// meaning FormatRRule gives monthly rules past the 28th.
 func (s *RecurrenceCalculatorService) findNextMonthlyDate(current time.Time, dayOfMonth, interval int) time.Time {
-	nextMonth := current.AddDate(0, interval, 0)
-	year, month, _ := nextMonth.Date()
+	// Step from the first of the month, as adding months to the 31st rolls
+	// over shorter months
+	year, month, _ := current.Date()
+	year, month, _ = time.Date(year, month+time.Month(interval), 1, 0, 0, 0, 0, current.Location()).Date()
 
-	// Handle end-of-month cases
 	lastDayOfMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, current.Location()).Day()
 	if dayOfMonth > lastDayOfMonth {
 		dayOfMonth = lastDayOfMonth
@@ -146,21 +241,218 @@ func (s *RecurrenceCalculatorService) findNextMonthlyDate(current time.Time, day
 	return time.Date(year, month, dayOfMonth, current.Hour(), current.Minute(), current.Second(), current.Nanosecond(), current.Location())
 }
 
+// customSearchHorizon bounds how far ahead a custom rule is searched for its
+// next occurrence, so rules that can never match (e.g. 30 February) end.
+const customSearchHorizon = 400
+
+// findNextCustomOccurrence expands an RFC 5545 rule one period (day, week,
+// month or year) at a time. Periods are counted from the anchor's period in
+// steps of Interval; within a period the BYxxx parts expand or limit the
+// candidate dates as RFC 5545 section 3.3.10 describes, then BYSETPOS picks
+// from the sorted candidates. Occurrences keep the anchor's time of day.
+func (s *RecurrenceCalculatorService) findNextCustomOccurrence(anchor, current time.Time, rule RecurrenceRule) time.Time {
+	location := anchor.Location()
+	current = current.In(location)
+	anchorDate := civilDate(anchor)
+	horizon := current.AddDate(customSearchHorizon, 0, 0)
+
+	step := s.periodsBetween(anchorDate, civilDate(current), rule)
+	step -= step % rule.Interval
+
+	for ; ; step += rule.Interval {
+		periodStart := s.periodStart(anchorDate, step, rule)
+		if periodStart.After(civilDate(horizon)) {
+			return time.Time{}
+		}
+
+		if rule.EndDate != nil && periodStart.After(civilDate(rule.EndDate.In(location))) {
+			return time.Time{}
+		}
+
+		for _, date := range s.periodCandidates(anchorDate, periodStart, rule) {
+			occurrence := time.Date(date.Year(), date.Month(), date.Day(), anchor.Hour(), anchor.Minute(), anchor.Second(), anchor.Nanosecond(), location)
+			if occurrence.After(current) {
+				return occurrence
+			}
+		}
+	}
+}
+
+// civilDate is the local calendar date of t, as midnight UTC so dates can be
+// compared and stepped without DST getting in the way.
+func civilDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *RecurrenceCalculatorService) weekStart(date time.Time, rule RecurrenceRule) time.Time {
+	weekStart := time.Monday
+	if rule.WeekStart != nil {
+		weekStart = *rule.WeekStart
+	}
+	return date.AddDate(0, 0, -((int(date.Weekday()) - int(weekStart) + 7) % 7))
+}
+
+// periodsBetween counts whole frequency periods from the anchor's period to
+// the period containing date.
+func (s *RecurrenceCalculatorService) periodsBetween(anchor, date time.Time, rule RecurrenceRule) int {
+	switch rule.Frequency {
+	case PatternDaily:
+		return int(date.Sub(anchor).Hours() / 24)
+	case PatternWeekly:
+		return int(s.weekStart(date, rule).Sub(s.weekStart(anchor, rule)).Hours() / (24 * 7))
+	case PatternMonthly:
+		return (date.Year()-anchor.Year())*12 + int(date.Month()) - int(anchor.Month())
+	default:
+		return date.Year() - anchor.Year()
+	}
+}
+
+// periodStart is the first date of the period step periods after the anchor's.
+func (s *RecurrenceCalculatorService) periodStart(anchor time.Time, step int, rule RecurrenceRule) time.Time {
+	switch rule.Frequency {
+	case PatternDaily:
+		return anchor.AddDate(0, 0, step)
+	case PatternWeekly:
+		return s.weekStart(anchor, rule).AddDate(0, 0, 7*step)
+	case PatternMonthly:
+		return time.Date(anchor.Year(), anchor.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(anchor.Year()+step, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// periodCandidates returns the dates of the period matching the rule, in
+// order and after BYSETPOS.
+func (s *RecurrenceCalculatorService) periodCandidates(anchor, periodStart time.Time, rule RecurrenceRule) []time.Time {
+	var periodEnd time.Time
+	switch rule.Frequency {
+	case PatternDaily:
+		periodEnd = periodStart.AddDate(0, 0, 1)
+	case PatternWeekly:
+		periodEnd = periodStart.AddDate(0, 0, 7)
+	case PatternMonthly:
+		periodEnd = periodStart.AddDate(0, 1, 0)
+	default:
+		periodEnd = periodStart.AddDate(1, 0, 0)
+	}
+
+	// Without BYxxx parts to expand with, the anchor supplies the missing
+	// day: its weekday for weekly rules, its day of month for monthly ones
+	// and its month and day for yearly ones
+	byMonth := rule.ByMonth
+	byMonthDay := rule.ByMonthDay
+	byDay := rule.ByDay
+	if len(byDay) == 0 && len(byMonthDay) == 0 {
+		switch rule.Frequency {
+		case PatternWeekly:
+			byDay = []WeekdayNum{{Weekday: anchor.Weekday()}}
+		case PatternMonthly:
+			byMonthDay = []int{anchor.Day()}
+		case PatternYearly:
+			byMonthDay = []int{anchor.Day()}
+			if len(byMonth) == 0 {
+				byMonth = []time.Month{anchor.Month()}
+			}
+		}
+	}
+
+	candidates := make([]time.Time, 0)
+	for date := periodStart; date.Before(periodEnd); date = date.AddDate(0, 0, 1) {
+		if len(byMonth) > 0 && !containsMonth(byMonth, date.Month()) {
+			continue
+		}
+		if len(byMonthDay) > 0 && !matchesMonthDay(byMonthDay, date) {
+			continue
+		}
+		// Yearly BYDAY ordinals count within the year unless BYMONTH narrows
+		// them to the month
+		yearly := rule.Frequency == PatternYearly && len(rule.ByMonth) == 0
+		if len(byDay) > 0 && !matchesWeekday(byDay, date, yearly) {
+			continue
+		}
+		candidates = append(candidates, date)
+	}
+
+	if len(rule.BySetPos) == 0 {
+		return candidates
+	}
+
+	selected := make([]time.Time, 0, len(rule.BySetPos))
+	for i, date := range candidates {
+		for _, position := range rule.BySetPos {
+			if position == i+1 || position == i-len(candidates) {
+				selected = append(selected, date)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesMonthDay(monthDays []int, date time.Time) bool {
+	daysInMonth := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, monthDay := range monthDays {
+		if monthDay == date.Day() || monthDay == date.Day()-daysInMonth-1 {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesWeekday(weekdays []WeekdayNum, date time.Time, withinYear bool) bool {
+	day, last := date.Day(), time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if withinYear {
+		day, last = date.YearDay(), time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	}
+
+	for _, weekday := range weekdays {
+		if weekday.Weekday != date.Weekday() {
+			continue
+		}
+
+		switch {
+		case weekday.Ordinal == 0:
+			return true
+		case weekday.Ordinal > 0 && (day-1)/7+1 == weekday.Ordinal:
+			return true
+		case weekday.Ordinal < 0 && -((last-day)/7+1) == weekday.Ordinal:
+			return true
+		}
+	}
+	return false
+}
+
 func (s *RecurrenceCalculatorService) ValidateRecurrenceRule(rule RecurrenceRule) error {
 	if rule.Interval <= 0 {
-		return errors.New("interval must be positive")
+		return domainerrors.Invalid("interval must be positive")
+	}
+
+	if rule.Pattern == PatternCustom {
+		if err := validateCustomRule(rule); err != nil {
+			return err
+		}
 	}
 
 	if rule.Pattern == PatternWeekly && len(rule.DaysOfWeek) == 0 {
-		return errors.New("weekly pattern requires at least one day of week")
+		return domainerrors.Invalid("weekly pattern requires at least one day of week")
 	}
 
 	if rule.Pattern == PatternMonthly && rule.DayOfMonth < 1 {
-		return errors.New("monthly pattern requires valid day of month")
+		return domainerrors.Invalid("monthly pattern requires valid day of month")
 	}
 
 	if rule.EndDate != nil && rule.MaxCount > 0 {
-		return errors.New("cannot specify both end date and max count")
+		return domainerrors.Invalid("cannot specify both end date and max count")
 	}
 
 	return nil
@@ -0,0 +1,216 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+func weekdayPtr(day time.Weekday) *time.Weekday {
+	return &day
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// TestCalculateRecurrencesWeekly checks weekly expansion against the RFC 5545
+// section 3.8.5.3 examples, which start at 09:00 America/New_York, both
+// through ParseRRule and as built-in weekly rules.
+func TestCalculateRecurrencesWeekly(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		dtstart string // Local date of the first occurrence, at 09:00
+		rrule   string // Parsed when set, otherwise rule is used
+		rule    services.RecurrenceRule
+		want    []string // Local start times
+	}{
+		{
+			name:    "weekly for 10 occurrences across the end of DST",
+			dtstart: "1997-09-02",
+			rrule:   "FREQ=WEEKLY;COUNT=10",
+			want: []string{
+				"1997-09-02 09:00", "1997-09-09 09:00", "1997-09-16 09:00", "1997-09-23 09:00", "1997-09-30 09:00",
+				"1997-10-07 09:00", "1997-10-14 09:00", "1997-10-21 09:00", "1997-10-28 09:00", "1997-11-04 09:00",
+			},
+		},
+		{
+			name:    "weekly on Tuesday and Thursday for five weeks",
+			dtstart: "1997-09-02",
+			rrule:   "FREQ=WEEKLY;COUNT=10;WKST=SU;BYDAY=TU,TH",
+			want: []string{
+				"1997-09-02 09:00", "1997-09-04 09:00", "1997-09-09 09:00", "1997-09-11 09:00", "1997-09-16 09:00",
+				"1997-09-18 09:00", "1997-09-23 09:00", "1997-09-25 09:00", "1997-09-30 09:00", "1997-10-02 09:00",
+			},
+		},
+		{
+			name:    "every other week on Tuesday and Thursday for 8 occurrences",
+			dtstart: "1997-09-02",
+			rrule:   "FREQ=WEEKLY;INTERVAL=2;COUNT=8;WKST=SU;BYDAY=TU,TH",
+			want: []string{
+				"1997-09-02 09:00", "1997-09-04 09:00", "1997-09-16 09:00", "1997-09-18 09:00",
+				"1997-09-30 09:00", "1997-10-02 09:00", "1997-10-14 09:00", "1997-10-16 09:00",
+			},
+		},
+		{
+			name:    "every other week on Monday, Wednesday and Friday until 24 December",
+			dtstart: "1997-09-01",
+			rrule:   "FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR",
+			want: []string{
+				"1997-09-01 09:00", "1997-09-03 09:00", "1997-09-05 09:00", "1997-09-15 09:00", "1997-09-17 09:00",
+				"1997-09-19 09:00", "1997-09-29 09:00", "1997-10-01 09:00", "1997-10-03 09:00", "1997-10-13 09:00",
+				"1997-10-15 09:00", "1997-10-17 09:00", "1997-10-27 09:00", "1997-10-29 09:00", "1997-10-31 09:00",
+				"1997-11-10 09:00", "1997-11-12 09:00", "1997-11-14 09:00", "1997-11-24 09:00", "1997-11-26 09:00",
+				"1997-11-28 09:00", "1997-12-08 09:00", "1997-12-10 09:00", "1997-12-12 09:00", "1997-12-22 09:00",
+			},
+		},
+		{
+			name:    "week starting on Monday puts Sunday after Tuesday",
+			dtstart: "1997-08-05",
+			rrule:   "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			want:    []string{"1997-08-05 09:00", "1997-08-10 09:00", "1997-08-19 09:00", "1997-08-24 09:00"},
+		},
+		{
+			name:    "week starting on Sunday puts Sunday before Tuesday",
+			dtstart: "1997-08-05",
+			rrule:   "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			want:    []string{"1997-08-05 09:00", "1997-08-17 09:00", "1997-08-19 09:00", "1997-08-31 09:00"},
+		},
+		{
+			name:    "built-in rule every other week on unsorted, repeated days",
+			dtstart: "1997-09-01",
+			rule: services.RecurrenceRule{
+				Pattern:    services.PatternWeekly,
+				Interval:   2,
+				DaysOfWeek: []time.Weekday{time.Friday, time.Monday, time.Wednesday, time.Monday},
+				EndDate:    timePtr(time.Date(1997, 10, 4, 0, 0, 0, 0, time.UTC)),
+				WeekStart:  weekdayPtr(time.Sunday),
+			},
+			want: []string{
+				"1997-09-01 09:00", "1997-09-03 09:00", "1997-09-05 09:00", "1997-09-15 09:00", "1997-09-17 09:00",
+				"1997-09-19 09:00", "1997-09-29 09:00", "1997-10-01 09:00", "1997-10-03 09:00",
+			},
+		},
+		{
+			name:    "built-in rule every other week on Monday and Thursday",
+			dtstart: "1997-09-04",
+			rule: services.RecurrenceRule{
+				Pattern:    services.PatternWeekly,
+				Interval:   2,
+				DaysOfWeek: []time.Weekday{time.Thursday, time.Monday},
+				MaxCount:   5,
+			},
+			want: []string{"1997-09-04 09:00", "1997-09-15 09:00", "1997-09-18 09:00", "1997-09-29 09:00", "1997-10-02 09:00"},
+		},
+		{
+			name:    "built-in rule with Sunday in a Monday week",
+			dtstart: "1997-08-05",
+			rule: services.RecurrenceRule{
+				Pattern:    services.PatternWeekly,
+				Interval:   2,
+				DaysOfWeek: []time.Weekday{time.Sunday, time.Tuesday},
+				MaxCount:   4,
+			},
+			want: []string{"1997-08-05 09:00", "1997-08-10 09:00", "1997-08-19 09:00", "1997-08-24 09:00"},
+		},
+		{
+			name:    "start off the listed days is kept and nothing precedes it",
+			dtstart: "1997-09-03",
+			rrule:   "FREQ=WEEKLY;INTERVAL=2;COUNT=3;BYDAY=MO",
+			want:    []string{"1997-09-03 09:00", "1997-09-15 09:00", "1997-09-29 09:00"},
+		},
+	}
+
+	calculator := services.NewRecurrenceCalculatorService(valueobjects.DefaultLocalTimePolicy())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := time.ParseInLocation("2006-01-02", tt.dtstart, newYork)
+			require.NoError(t, err)
+			start := date.Add(9 * time.Hour)
+			base, err := valueobjects.NewTimeRange(start, start.Add(time.Hour))
+			require.NoError(t, err)
+
+			rule := tt.rule
+			if tt.rrule != "" {
+				rule, err = services.ParseRRule(tt.rrule, newYork)
+				require.NoError(t, err)
+			}
+
+			result, err := calculator.CalculateRecurrences(base, rule)
+			require.NoError(t, err)
+
+			got := make([]string, len(result.TimeRanges))
+			for i, occurrence := range result.TimeRanges {
+				got[i] = occurrence.StartTime().In(newYork).Format("2006-01-02 15:04")
+				assert.Equal(t, time.Hour, occurrence.Duration(), "occurrence %d", i)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, len(tt.want), result.Count)
+		})
+	}
+}
+
+// TestCalculateRecurrencesMonthlyPastThe28th checks that built-in monthly
+// rules past the 28th fall on the last day of shorter months, and that the
+// RRULE FormatRRule writes for them expands to the same dates.
+func TestCalculateRecurrencesMonthlyPastThe28th(t *testing.T) {
+	tests := []struct {
+		dayOfMonth int
+		want       []string
+	}{
+		{
+			dayOfMonth: 29,
+			want:       []string{"2027-12-29", "2028-01-29", "2028-02-29", "2028-03-29", "2028-04-29", "2028-05-29", "2028-06-29", "2028-07-29", "2028-08-29", "2028-09-29", "2028-10-29", "2028-11-29", "2028-12-29", "2029-01-29", "2029-02-28", "2029-03-29"},
+		},
+		{
+			dayOfMonth: 30,
+			want:       []string{"2027-12-30", "2028-01-30", "2028-02-29", "2028-03-30", "2028-04-30", "2028-05-30", "2028-06-30", "2028-07-30", "2028-08-30", "2028-09-30", "2028-10-30", "2028-11-30", "2028-12-30", "2029-01-30", "2029-02-28", "2029-03-30"},
+		},
+		{
+			dayOfMonth: 31,
+			want:       []string{"2027-12-31", "2028-01-31", "2028-02-29", "2028-03-31", "2028-04-30", "2028-05-31", "2028-06-30", "2028-07-31", "2028-08-31", "2028-09-30", "2028-10-31", "2028-11-30", "2028-12-31", "2029-01-31", "2029-02-28", "2029-03-31"},
+		},
+	}
+
+	calculator := services.NewRecurrenceCalculatorService(valueobjects.DefaultLocalTimePolicy())
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("day %d", tt.dayOfMonth), func(t *testing.T) {
+			start := time.Date(2027, 12, tt.dayOfMonth, 9, 0, 0, 0, time.UTC)
+			base, err := valueobjects.NewTimeRange(start, start.Add(time.Hour))
+			require.NoError(t, err)
+
+			builtIn := services.RecurrenceRule{
+				Pattern:    services.PatternMonthly,
+				Interval:   1,
+				DayOfMonth: tt.dayOfMonth,
+				MaxCount:   len(tt.want),
+			}
+
+			value, err := services.FormatRRule(builtIn)
+			require.NoError(t, err)
+			custom, err := services.ParseRRule(value, time.UTC)
+			require.NoError(t, err)
+
+			for name, rule := range map[string]services.RecurrenceRule{"built-in": builtIn, value: custom} {
+				result, err := calculator.CalculateRecurrences(base, rule)
+				require.NoError(t, err)
+
+				got := make([]string, len(result.TimeRanges))
+				for i, occurrence := range result.TimeRanges {
+					got[i] = occurrence.StartTime().Format("2006-01-02")
+				}
+				assert.Equal(t, tt.want, got, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+)
+
+const (
+	rruleDateTimeUTC = "20060102T150405Z"
+	rruleDateTime    = "20060102T150405"
+	rruleDate        = "20060102"
+)
+
+var rruleFrequencies = map[string]RecurrencePattern{
+	"DAILY":   PatternDaily,
+	"WEEKLY":  PatternWeekly,
+	"MONTHLY": PatternMonthly,
+	"YEARLY":  PatternYearly,
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// ParseRRule parses an RFC 5545 RRULE value such as
+// "FREQ=MONTHLY;BYDAY=-1FR;COUNT=6" into a PatternCustom rule. The "RRULE:"
+// prefix is optional. A floating or date-only UNTIL is read in location;
+// a date-only UNTIL includes the whole day.
+func ParseRRule(value string, location *time.Location) (RecurrenceRule, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return RecurrenceRule{}, domainerrors.Invalid("empty RRULE")
+	}
+
+	rule := RecurrenceRule{
+		Pattern:  PatternCustom,
+		Interval: 1,
+	}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(value, ";") {
+		name, val, found := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if !found || name == "" || val == "" {
+			return RecurrenceRule{}, domainerrors.Invalidf("invalid RRULE part: %q", part)
+		}
+
+		if seen[name] {
+			return RecurrenceRule{}, domainerrors.Invalidf("RRULE part %s given more than once", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			frequency, ok := rruleFrequencies[strings.ToUpper(val)]
+			if !ok {
+				return RecurrenceRule{}, domainerrors.Invalidf("unsupported RRULE FREQ: %s", val)
+			}
+			rule.Frequency = frequency
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(val)
+		case "COUNT":
+			rule.MaxCount, err = parsePositive(val)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseRRuleUntil(val, location)
+			rule.EndDate = &until
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseIntList(val, 1, 31)
+		case "BYMONTH":
+			var months []int
+			months, err = parseIntList(val, 1, 12)
+			for _, month := range months {
+				if month < 0 {
+					return RecurrenceRule{}, domainerrors.Invalidf("invalid BYMONTH: %s", val)
+				}
+				rule.ByMonth = append(rule.ByMonth, time.Month(month))
+			}
+		case "BYSETPOS":
+			rule.BySetPos, err = parseIntList(val, 1, 366)
+		case "WKST":
+			weekday, ok := rruleWeekdays[strings.ToUpper(val)]
+			if !ok {
+				return RecurrenceRule{}, domainerrors.Invalidf("invalid WKST: %s", val)
+			}
+			rule.WeekStart = &weekday
+		default:
+			return RecurrenceRule{}, domainerrors.Invalidf("unsupported RRULE part: %s", name)
+		}
+
+		if err != nil {
+			return RecurrenceRule{}, domainerrors.AtField(name, "invalid "+name, err)
+		}
+	}
+
+	if err := validateCustomRule(rule); err != nil {
+		return RecurrenceRule{}, err
+	}
+	return rule, nil
+}
+
+// FormatRRule writes rule as an RFC 5545 RRULE value, without the "RRULE:"
+// prefix. Built-in patterns are translated to the equivalent rule, so a
+// monthly rule on the 31st becomes BYMONTHDAY=28,29,30,31;BYSETPOS=-1 to keep
+// falling on the last day of shorter months. UNTIL is written in UTC.
+func FormatRRule(rule RecurrenceRule) (string, error) {
+	custom, err := customRule(rule)
+	if err != nil {
+		return "", err
+	}
+
+	frequency := ""
+	for name, pattern := range rruleFrequencies {
+		if pattern == custom.Frequency {
+			frequency = name
+		}
+	}
+	parts := []string{"FREQ=" + frequency}
+
+	if custom.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(custom.Interval))
+	}
+
+	if custom.MaxCount > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(custom.MaxCount))
+	}
+
+	if custom.EndDate != nil {
+		parts = append(parts, "UNTIL="+custom.EndDate.UTC().Format(rruleDateTimeUTC))
+	}
+
+	if len(custom.ByMonth) > 0 {
+		months := make([]int, len(custom.ByMonth))
+		for i, month := range custom.ByMonth {
+			months[i] = int(month)
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+
+	if len(custom.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(custom.ByMonthDay))
+	}
+
+	if len(custom.ByDay) > 0 {
+		days := make([]string, len(custom.ByDay))
+		for i, day := range custom.ByDay {
+			days[i] = formatWeekdayNum(day)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if len(custom.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(custom.BySetPos))
+	}
+
+	if custom.WeekStart != nil {
+		parts = append(parts, "WKST="+formatWeekdayNum(WeekdayNum{Weekday: *custom.WeekStart}))
+	}
+
+	return strings.Join(parts, ";"), nil
+}
+
+// customRule expresses a built-in pattern with RFC 5545 rule parts.
+func customRule(rule RecurrenceRule) (RecurrenceRule, error) {
+	if rule.Interval <= 0 {
+		return RecurrenceRule{}, domainerrors.Invalid("interval must be positive")
+	}
+
+	if rule.Pattern == PatternCustom {
+		return rule, validateCustomRule(rule)
+	}
+
+	custom := RecurrenceRule{
+		Pattern:   PatternCustom,
+		Interval:  rule.Interval,
+		EndDate:   rule.EndDate,
+		MaxCount:  rule.MaxCount,
+		Frequency: rule.Pattern,
+	}
+
+	switch rule.Pattern {
+	case PatternDaily, PatternYearly:
+	case PatternWeekly:
+		days := append([]time.Weekday(nil), rule.DaysOfWeek...)
+		sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+		for i, day := range days {
+			if i == 0 || day != days[i-1] {
+				custom.ByDay = append(custom.ByDay, WeekdayNum{Weekday: day})
+			}
+		}
+		custom.WeekStart = rule.WeekStart
+	case PatternMonthly:
+		if rule.DayOfMonth > 28 {
+			for day := 28; day <= rule.DayOfMonth; day++ {
+				custom.ByMonthDay = append(custom.ByMonthDay, day)
+			}
+			custom.BySetPos = []int{-1}
+		} else if rule.DayOfMonth > 0 {
+			custom.ByMonthDay = []int{rule.DayOfMonth}
+		}
+	default:
+		return RecurrenceRule{}, domainerrors.Invalidf("unsupported recurrence pattern: %s", rule.Pattern)
+	}
+
+	return custom, nil
+}
+
+// validateCustomRule applies the RFC 5545 constraints between rule parts.
+func validateCustomRule(rule RecurrenceRule) error {
+	if _, ok := map[RecurrencePattern]bool{PatternDaily: true, PatternWeekly: true, PatternMonthly: true, PatternYearly: true}[rule.Frequency]; !ok {
+		return domainerrors.Invalid("custom recurrence requires a daily, weekly, monthly or yearly frequency")
+	}
+
+	if rule.EndDate != nil && rule.MaxCount > 0 {
+		return domainerrors.Invalid("COUNT and UNTIL cannot both be given")
+	}
+
+	if rule.Frequency == PatternWeekly && len(rule.ByMonthDay) > 0 {
+		return domainerrors.Invalid("BYMONTHDAY cannot be used with a weekly frequency")
+	}
+
+	for _, day := range rule.ByDay {
+		if day.Ordinal != 0 && rule.Frequency != PatternMonthly && rule.Frequency != PatternYearly {
+			return domainerrors.Invalid("BYDAY ordinals are only allowed with monthly or yearly frequencies")
+		}
+		if day.Ordinal < -53 || day.Ordinal > 53 {
+			return domainerrors.Invalidf("BYDAY ordinal out of range: %d", day.Ordinal)
+		}
+	}
+
+	for _, monthDay := range rule.ByMonthDay {
+		if monthDay == 0 || monthDay < -31 || monthDay > 31 {
+			return domainerrors.Invalidf("BYMONTHDAY out of range: %d", monthDay)
+		}
+	}
+
+	for _, month := range rule.ByMonth {
+		if month < time.January || month > time.December {
+			return domainerrors.Invalidf("BYMONTH out of range: %d", month)
+		}
+	}
+
+	if len(rule.BySetPos) > 0 && len(rule.ByDay) == 0 && len(rule.ByMonthDay) == 0 && len(rule.ByMonth) == 0 {
+		return domainerrors.Invalid("BYSETPOS requires another BYxxx rule part")
+	}
+
+	for _, position := range rule.BySetPos {
+		if position == 0 || position < -366 || position > 366 {
+			return domainerrors.Invalidf("BYSETPOS out of range: %d", position)
+		}
+	}
+
+	return nil
+}
+
+func parseRRuleUntil(value string, location *time.Location) (time.Time, error) {
+	if until, err := time.Parse(rruleDateTimeUTC, value); err == nil {
+		return until, nil
+	}
+
+	if until, err := time.ParseInLocation(rruleDateTime, value, location); err == nil {
+		return until, nil
+	}
+
+	date, err := time.ParseInLocation(rruleDate, value, location)
+	if err != nil {
+		return time.Time{}, domainerrors.Invalid("expected YYYYMMDD, YYYYMMDDTHHMMSS or YYYYMMDDTHHMMSSZ: " + value)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, location), nil
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	result := make([]WeekdayNum, 0)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToUpper(strings.TrimSpace(item))
+		if len(item) < 2 {
+			return nil, domainerrors.Invalid("invalid weekday: " + item)
+		}
+
+		weekday, ok := rruleWeekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, domainerrors.Invalid("invalid weekday: " + item)
+		}
+
+		ordinal := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			var err error
+			ordinal, err = strconv.Atoi(strings.TrimPrefix(prefix, "+"))
+			if err != nil || ordinal == 0 {
+				return nil, domainerrors.Invalid("invalid weekday ordinal: " + item)
+			}
+		}
+		result = append(result, WeekdayNum{Weekday: weekday, Ordinal: ordinal})
+	}
+	return result, nil
+}
+
+// parseIntList parses comma-separated integers whose absolute value lies in
+// [min, max]; negative values are kept for the caller to interpret.
+func parseIntList(value string, min, max int) ([]int, error) {
+	result := make([]int, 0)
+	for _, item := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(item), "+"))
+		if err != nil {
+			return nil, domainerrors.Invalid("invalid number: " + item)
+		}
+
+		magnitude := number
+		if magnitude < 0 {
+			magnitude = -magnitude
+		}
+		if magnitude < min || magnitude > max {
+			return nil, domainerrors.Invalidf("%d out of range", number)
+		}
+		result = append(result, number)
+	}
+	return result, nil
+}
+
+func parsePositive(value string) (int, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		return 0, domainerrors.Invalid("must be a positive integer: " + value)
+	}
+	return number, nil
+}
+
+func formatWeekdayNum(day WeekdayNum) string {
+	name := strings.ToUpper(day.Weekday.String()[:2])
+	if day.Ordinal == 0 {
+		return name
+	}
+	return strconv.Itoa(day.Ordinal) + name
+}
+
+func joinInts(numbers []int) string {
+	items := make([]string, len(numbers))
+	for i, number := range numbers {
+		items[i] = strconv.Itoa(number)
+	}
+	return strings.Join(items, ",")
+}
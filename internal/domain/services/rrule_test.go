@@ -0,0 +1,289 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+func TestParseRRule(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		value string
+		want  services.RecurrenceRule
+	}{
+		{
+			name:  "last Friday of the month with prefix",
+			value: "RRULE:FREQ=MONTHLY;BYDAY=-1FR;COUNT=6",
+			want: services.RecurrenceRule{
+				Pattern:   services.PatternCustom,
+				Interval:  1,
+				MaxCount:  6,
+				Frequency: services.PatternMonthly,
+				ByDay:     []services.WeekdayNum{{Weekday: time.Friday, Ordinal: -1}},
+			},
+		},
+		{
+			name:  "last weekday of the month",
+			value: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			want: services.RecurrenceRule{
+				Pattern:   services.PatternCustom,
+				Interval:  1,
+				Frequency: services.PatternMonthly,
+				ByDay: []services.WeekdayNum{
+					{Weekday: time.Monday}, {Weekday: time.Tuesday}, {Weekday: time.Wednesday},
+					{Weekday: time.Thursday}, {Weekday: time.Friday},
+				},
+				BySetPos: []int{-1},
+			},
+		},
+		{
+			name:  "third to last day of the month",
+			value: "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=-3",
+			want: services.RecurrenceRule{
+				Pattern:    services.PatternCustom,
+				Interval:   2,
+				Frequency:  services.PatternMonthly,
+				ByMonthDay: []int{-3},
+			},
+		},
+		{
+			name:  "lowercase with explicit plus ordinal and week start",
+			value: "freq=yearly;byday=+2mo;bymonth=1;wkst=su",
+			want: services.RecurrenceRule{
+				Pattern:   services.PatternCustom,
+				Interval:  1,
+				Frequency: services.PatternYearly,
+				ByDay:     []services.WeekdayNum{{Weekday: time.Monday, Ordinal: 2}},
+				ByMonth:   []time.Month{time.January},
+				WeekStart: weekdayPtr(time.Sunday),
+			},
+		},
+		{
+			name:  "UTC UNTIL",
+			value: "FREQ=DAILY;UNTIL=20300301T140000Z",
+			want: services.RecurrenceRule{
+				Pattern:   services.PatternCustom,
+				Interval:  1,
+				Frequency: services.PatternDaily,
+				EndDate:   timePtr(time.Date(2030, 3, 1, 14, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:  "floating UNTIL is local",
+			value: "FREQ=DAILY;UNTIL=20300301T090000",
+			want: services.RecurrenceRule{
+				Pattern:   services.PatternCustom,
+				Interval:  1,
+				Frequency: services.PatternDaily,
+				EndDate:   timePtr(time.Date(2030, 3, 1, 9, 0, 0, 0, newYork)),
+			},
+		},
+		{
+			name:  "date-only UNTIL includes the day",
+			value: "FREQ=DAILY;UNTIL=20300301",
+			want: services.RecurrenceRule{
+				Pattern:   services.PatternCustom,
+				Interval:  1,
+				Frequency: services.PatternDaily,
+				EndDate:   timePtr(time.Date(2030, 3, 1, 23, 59, 59, 0, newYork)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := services.ParseRRule(tt.value, newYork)
+			require.NoError(t, err)
+
+			if tt.want.EndDate != nil {
+				require.NotNil(t, rule.EndDate)
+				assert.True(t, tt.want.EndDate.Equal(*rule.EndDate), "UNTIL %s", rule.EndDate)
+				tt.want.EndDate, rule.EndDate = nil, nil
+			}
+			assert.Equal(t, tt.want, rule)
+		})
+	}
+}
+
+func TestParseRRuleRejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "empty", value: ""},
+		{name: "no frequency", value: "COUNT=3"},
+		{name: "unsupported frequency", value: "FREQ=HOURLY"},
+		{name: "both UNTIL and COUNT", value: "FREQ=DAILY;COUNT=3;UNTIL=20300301T000000Z"},
+		{name: "repeated part", value: "FREQ=DAILY;COUNT=3;COUNT=4"},
+		{name: "unsupported part", value: "FREQ=DAILY;BYHOUR=9"},
+		{name: "part without value", value: "FREQ=DAILY;COUNT="},
+		{name: "zero interval", value: "FREQ=DAILY;INTERVAL=0"},
+		{name: "invalid UNTIL", value: "FREQ=DAILY;UNTIL=2030-03-01"},
+		{name: "invalid weekday", value: "FREQ=WEEKLY;BYDAY=XY"},
+		{name: "zero weekday ordinal", value: "FREQ=MONTHLY;BYDAY=0FR"},
+		{name: "weekday ordinal with weekly frequency", value: "FREQ=WEEKLY;BYDAY=-1FR"},
+		{name: "zero month day", value: "FREQ=MONTHLY;BYMONTHDAY=0"},
+		{name: "month day out of range", value: "FREQ=MONTHLY;BYMONTHDAY=-32"},
+		{name: "month day with weekly frequency", value: "FREQ=WEEKLY;BYMONTHDAY=1"},
+		{name: "month out of range", value: "FREQ=YEARLY;BYMONTH=13"},
+		{name: "negative month", value: "FREQ=YEARLY;BYMONTH=-1"},
+		{name: "set position alone", value: "FREQ=MONTHLY;BYSETPOS=1"},
+		{name: "zero set position", value: "FREQ=MONTHLY;BYDAY=MO;BYSETPOS=0"},
+		{name: "invalid week start", value: "FREQ=WEEKLY;WKST=XY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := services.ParseRRule(tt.value, time.UTC)
+			require.Error(t, err)
+			kind, _ := domainerrors.KindOf(err)
+			assert.Equal(t, domainerrors.KindValidation, kind)
+		})
+	}
+}
+
+// TestFormatRRuleRoundTrips formats parsed rules back to the same value, in
+// the order FormatRRule writes the parts.
+func TestFormatRRuleRoundTrips(t *testing.T) {
+	values := []string{
+		"FREQ=DAILY",
+		"FREQ=DAILY;INTERVAL=3;COUNT=10",
+		"FREQ=WEEKLY;UNTIL=20300301T140000Z;BYDAY=TU,TH;WKST=SU",
+		"FREQ=MONTHLY;COUNT=6;BYDAY=-1FR",
+		"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+		"FREQ=MONTHLY;BYMONTHDAY=-3,1",
+		"FREQ=MONTHLY;BYMONTHDAY=28,29,30,31;BYSETPOS=-1",
+		"FREQ=YEARLY;BYMONTH=1,7;BYDAY=2MO",
+	}
+
+	for _, value := range values {
+		t.Run(value, func(t *testing.T) {
+			rule, err := services.ParseRRule(value, time.UTC)
+			require.NoError(t, err)
+
+			formatted, err := services.FormatRRule(rule)
+			require.NoError(t, err)
+			assert.Equal(t, value, formatted)
+		})
+	}
+}
+
+func TestFormatRRuleBuiltInPatterns(t *testing.T) {
+	tests := []struct {
+		name string
+		rule services.RecurrenceRule
+		want string
+	}{
+		{
+			name: "daily",
+			rule: services.RecurrenceRule{Pattern: services.PatternDaily, Interval: 2, MaxCount: 5},
+			want: "FREQ=DAILY;INTERVAL=2;COUNT=5",
+		},
+		{
+			name: "weekly on unsorted, repeated days",
+			rule: services.RecurrenceRule{Pattern: services.PatternWeekly, Interval: 1, DaysOfWeek: []time.Weekday{time.Friday, time.Monday, time.Friday}},
+			want: "FREQ=WEEKLY;BYDAY=MO,FR",
+		},
+		{
+			name: "monthly on the 15th",
+			rule: services.RecurrenceRule{Pattern: services.PatternMonthly, Interval: 1, DayOfMonth: 15},
+			want: "FREQ=MONTHLY;BYMONTHDAY=15",
+		},
+		{
+			name: "monthly on the 30th",
+			rule: services.RecurrenceRule{Pattern: services.PatternMonthly, Interval: 1, DayOfMonth: 30},
+			want: "FREQ=MONTHLY;BYMONTHDAY=28,29,30;BYSETPOS=-1",
+		},
+		{
+			name: "yearly until a date",
+			rule: services.RecurrenceRule{Pattern: services.PatternYearly, Interval: 1, EndDate: timePtr(time.Date(2035, 1, 1, 0, 0, 0, 0, time.UTC))},
+			want: "FREQ=YEARLY;UNTIL=20350101T000000Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := services.FormatRRule(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatRRuleRejectsBothUntilAndCount(t *testing.T) {
+	rule := services.RecurrenceRule{
+		Pattern:   services.PatternCustom,
+		Interval:  1,
+		Frequency: services.PatternDaily,
+		MaxCount:  3,
+		EndDate:   timePtr(time.Date(2030, 3, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	_, err := services.FormatRRule(rule)
+	require.Error(t, err)
+	kind, _ := domainerrors.KindOf(err)
+	assert.Equal(t, domainerrors.KindValidation, kind)
+}
+
+// TestCalculateRecurrencesCustomMonthly expands the monthly rule parts whose
+// positions count from the end of the month.
+func TestCalculateRecurrencesCustomMonthly(t *testing.T) {
+	tests := []struct {
+		name    string
+		dtstart string
+		rrule   string
+		want    []string
+	}{
+		{
+			name:    "last Friday",
+			dtstart: "2030-01-25",
+			rrule:   "FREQ=MONTHLY;COUNT=4;BYDAY=-1FR",
+			want:    []string{"2030-01-25", "2030-02-22", "2030-03-29", "2030-04-26"},
+		},
+		{
+			name:    "last weekday",
+			dtstart: "2030-01-31",
+			rrule:   "FREQ=MONTHLY;COUNT=4;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			want:    []string{"2030-01-31", "2030-02-28", "2030-03-29", "2030-04-30"},
+		},
+		{
+			name:    "second to last day",
+			dtstart: "2030-01-30",
+			rrule:   "FREQ=MONTHLY;COUNT=4;BYMONTHDAY=-2",
+			want:    []string{"2030-01-30", "2030-02-27", "2030-03-30", "2030-04-29"},
+		},
+	}
+
+	calculator := services.NewRecurrenceCalculatorService(valueobjects.DefaultLocalTimePolicy())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := time.Parse("2006-01-02", tt.dtstart)
+			require.NoError(t, err)
+			start := date.Add(9 * time.Hour)
+			base, err := valueobjects.NewTimeRange(start, start.Add(time.Hour))
+			require.NoError(t, err)
+
+			rule, err := services.ParseRRule(tt.rrule, time.UTC)
+			require.NoError(t, err)
+
+			result, err := calculator.CalculateRecurrences(base, rule)
+			require.NoError(t, err)
+
+			got := make([]string, len(result.TimeRanges))
+			for i, occurrence := range result.TimeRanges {
+				got[i] = occurrence.StartTime().Format("2006-01-02")
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
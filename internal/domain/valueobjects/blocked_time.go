@@ -0,0 +1,42 @@
+package valueobjects
+
+import (
+	"github.com/google/uuid"
+)
+
+// BlockedTime is a period on a schedule that cannot be booked, with the
+// reason it was blocked.
+type BlockedTime struct {
+	id        string
+	timeRange TimeRange
+	reason    string
+}
+
+func NewBlockedTime(timeRange TimeRange, reason string) BlockedTime {
+	return BlockedTime{
+		id:        uuid.New().String(),
+		timeRange: timeRange,
+		reason:    reason,
+	}
+}
+
+// RestoreBlockedTime rebuilds a blocked time with a previously assigned ID.
+func RestoreBlockedTime(id string, timeRange TimeRange, reason string) BlockedTime {
+	return BlockedTime{
+		id:        id,
+		timeRange: timeRange,
+		reason:    reason,
+	}
+}
+
+func (bt BlockedTime) ID() string {
+	return bt.id
+}
+
+func (bt BlockedTime) TimeRange() TimeRange {
+	return bt.timeRange
+}
+
+func (bt BlockedTime) Reason() string {
+	return bt.reason
+}
@@ -1,8 +1,9 @@
 package valueobjects
 
 import (
-	"errors"
 	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
 )
 
 type Duration struct {
@@ -11,7 +12,7 @@ type Duration struct {
 
 func NewDuration(d time.Duration) (Duration, error) {
 	if d <= 0 {
-		return Duration{}, errors.New("duration must be positive")
+		return Duration{}, domainerrors.Invalid("duration must be positive")
 	}
 	
 	return Duration{value: d}, nil
@@ -36,7 +37,7 @@ func (d Duration) Add(other Duration) Duration {
 func (d Duration) Subtract(other Duration) (Duration, error) {
 	result := d.value - other.value
 	if result <= 0 {
-		return Duration{}, errors.New("resulting duration would be negative or zero")
+		return Duration{}, domainerrors.Invalid("resulting duration would be negative or zero")
 	}
 	return Duration{value: result}, nil
 }
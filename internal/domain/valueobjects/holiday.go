@@ -0,0 +1,52 @@
+package valueobjects
+
+import (
+	"time"
+)
+
+// Holiday is a non-working day of a holiday calendar. It is a local date,
+// so it covers a different instant range in each timezone.
+type Holiday struct {
+	region string
+	date   string
+	name   string
+}
+
+func NewHoliday(region string, date time.Time, name string) Holiday {
+	return Holiday{
+		region: region,
+		date:   date.Format(DateLayout),
+		name:   name,
+	}
+}
+
+// ID identifies the holiday across calendars, e.g. "DE/2024-12-25".
+func (h Holiday) ID() string {
+	return h.region + "/" + h.date
+}
+
+func (h Holiday) Region() string {
+	return h.region
+}
+
+// Date is the local date of the holiday (DateLayout).
+func (h Holiday) Date() string {
+	return h.date
+}
+
+func (h Holiday) Name() string {
+	return h.name
+}
+
+// TimeRangeIn is the whole local day of the holiday in location, from
+// midnight to the following midnight.
+func (h Holiday) TimeRangeIn(location *time.Location) TimeRange {
+	if location == nil {
+		location = time.UTC
+	}
+
+	date, _ := time.Parse(DateLayout, h.date)
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+	end := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, location)
+	return TimeRange{startTime: start, endTime: end}
+}
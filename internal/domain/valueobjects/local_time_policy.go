@@ -0,0 +1,134 @@
+package valueobjects
+
+import (
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+)
+
+// GapPolicy decides what happens to a local time that does not exist because
+// the clocks jump forward, such as 02:30 on the day DST starts in New York.
+type GapPolicy string
+
+const (
+	// GapShiftForward moves the time forward by the length of the gap, so
+	// 02:30 becomes 03:30. This is how RFC 5545 reads such times.
+	GapShiftForward GapPolicy = "shift_forward"
+	// GapNextValid moves the time to the end of the gap, so 02:30 becomes 03:00.
+	GapNextValid GapPolicy = "next_valid"
+	// GapSkip leaves the time out.
+	GapSkip GapPolicy = "skip"
+)
+
+// AmbiguityPolicy decides which instant a local time that happens twice,
+// because the clocks go back, refers to.
+type AmbiguityPolicy string
+
+const (
+	// AmbiguityEarlier takes the first of the two, still on the summer
+	// offset. This is how RFC 5545 reads such times.
+	AmbiguityEarlier AmbiguityPolicy = "earlier"
+	// AmbiguityLater takes the second of the two.
+	AmbiguityLater AmbiguityPolicy = "later"
+)
+
+// LocalTimePolicy resolves wall-clock times in a timezone to instants,
+// including times that don't exist or exist twice around a DST change. The
+// zero value uses the RFC 5545 defaults.
+type LocalTimePolicy struct {
+	Gap       GapPolicy
+	Ambiguity AmbiguityPolicy
+}
+
+func DefaultLocalTimePolicy() LocalTimePolicy {
+	return LocalTimePolicy{Gap: GapShiftForward, Ambiguity: AmbiguityEarlier}
+}
+
+// NewLocalTimePolicy validates the policy names. Empty names are left
+// unset, so Or can fill them from a configured policy.
+func NewLocalTimePolicy(gap, ambiguity string) (LocalTimePolicy, error) {
+	var policy LocalTimePolicy
+
+	switch GapPolicy(gap) {
+	case "":
+	case GapShiftForward, GapNextValid, GapSkip:
+		policy.Gap = GapPolicy(gap)
+	default:
+		return LocalTimePolicy{}, domainerrors.Invalid("invalid DST gap policy: " + gap + " (expected shift_forward, next_valid or skip)")
+	}
+
+	switch AmbiguityPolicy(ambiguity) {
+	case "":
+	case AmbiguityEarlier, AmbiguityLater:
+		policy.Ambiguity = AmbiguityPolicy(ambiguity)
+	default:
+		return LocalTimePolicy{}, domainerrors.Invalid("invalid DST ambiguity policy: " + ambiguity + " (expected earlier or later)")
+	}
+
+	return policy, nil
+}
+
+// Or fills the unset parts of p from fallback.
+func (p LocalTimePolicy) Or(fallback LocalTimePolicy) LocalTimePolicy {
+	if p.Gap == "" {
+		p.Gap = fallback.Gap
+	}
+	if p.Ambiguity == "" {
+		p.Ambiguity = fallback.Ambiguity
+	}
+	return p
+}
+
+// Resolve returns the instant at which clocks in location show wall, whose
+// own location is ignored. It returns false when wall falls in a gap and
+// the gap policy is to skip it.
+func (p LocalTimePolicy) Resolve(wall time.Time, location *time.Location) (time.Time, bool) {
+	p = p.Or(DefaultLocalTimePolicy())
+
+	year, month, day := wall.Date()
+	hour, minute, second := wall.Clock()
+	naive := time.Date(year, month, day, hour, minute, second, wall.Nanosecond(), time.UTC)
+
+	// The offsets a day either side cover any single transition near wall
+	_, offsetBefore := naive.Add(-24 * time.Hour).In(location).Zone()
+	_, offsetAfter := naive.Add(24 * time.Hour).In(location).Zone()
+
+	candidates := make([]time.Time, 0, 2)
+	for _, offset := range []int{offsetBefore, offsetAfter} {
+		instant := naive.Add(-time.Duration(offset) * time.Second)
+		if _, actual := instant.In(location).Zone(); actual != offset {
+			continue
+		}
+		if len(candidates) == 0 || !candidates[0].Equal(instant) {
+			candidates = append(candidates, instant)
+		}
+	}
+
+	switch {
+	case len(candidates) == 2:
+		earlier, later := candidates[0], candidates[1]
+		if later.Before(earlier) {
+			earlier, later = later, earlier
+		}
+		if p.Ambiguity == AmbiguityLater {
+			return later.In(location), true
+		}
+		return earlier.In(location), true
+
+	case len(candidates) == 1:
+		return candidates[0].In(location), true
+	}
+
+	// In a gap: reading wall with the offset from before the gap lands as
+	// far past the gap's end as wall is past its start
+	shifted := naive.Add(-time.Duration(offsetBefore) * time.Second).In(location)
+	switch p.Gap {
+	case GapSkip:
+		return time.Time{}, false
+	case GapNextValid:
+		gapEnd, _ := shifted.ZoneBounds()
+		return gapEnd.In(location), true
+	default:
+		return shifted, true
+	}
+}
@@ -0,0 +1,120 @@
+package valueobjects_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// TestLocalTimePolicyResolve resolves wall-clock times around the 2030 DST
+// changes in New York: clocks jump from 02:00 to 03:00 on March 10 and fall
+// back from 02:00 to 01:00 on November 3.
+func TestLocalTimePolicyResolve(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		policy valueobjects.LocalTimePolicy
+		wall   time.Time
+		want   string // In UTC, empty when the time is skipped
+	}{
+		{
+			name:   "summer time",
+			policy: valueobjects.DefaultLocalTimePolicy(),
+			wall:   time.Date(2030, 6, 1, 9, 0, 0, 0, time.UTC),
+			want:   "2030-06-01 13:00",
+		},
+		{
+			name:   "winter time",
+			policy: valueobjects.DefaultLocalTimePolicy(),
+			wall:   time.Date(2030, 12, 1, 9, 0, 0, 0, time.UTC),
+			want:   "2030-12-01 14:00",
+		},
+		{
+			name:   "location of wall is ignored",
+			policy: valueobjects.DefaultLocalTimePolicy(),
+			wall:   time.Date(2030, 6, 1, 9, 0, 0, 0, tokyo),
+			want:   "2030-06-01 13:00",
+		},
+		{
+			name:   "just before the gap",
+			policy: valueobjects.LocalTimePolicy{Gap: valueobjects.GapSkip},
+			wall:   time.Date(2030, 3, 10, 1, 59, 0, 0, time.UTC),
+			want:   "2030-03-10 06:59",
+		},
+		{
+			name:   "end of the gap",
+			policy: valueobjects.LocalTimePolicy{Gap: valueobjects.GapSkip},
+			wall:   time.Date(2030, 3, 10, 3, 0, 0, 0, time.UTC),
+			want:   "2030-03-10 07:00",
+		},
+		{
+			name:   "in the gap, shift forward",
+			policy: valueobjects.LocalTimePolicy{Gap: valueobjects.GapShiftForward},
+			wall:   time.Date(2030, 3, 10, 2, 30, 0, 0, time.UTC),
+			want:   "2030-03-10 07:30", // 03:30 EDT
+		},
+		{
+			name:   "in the gap, next valid",
+			policy: valueobjects.LocalTimePolicy{Gap: valueobjects.GapNextValid},
+			wall:   time.Date(2030, 3, 10, 2, 30, 0, 0, time.UTC),
+			want:   "2030-03-10 07:00", // 03:00 EDT
+		},
+		{
+			name:   "in the gap, skip",
+			policy: valueobjects.LocalTimePolicy{Gap: valueobjects.GapSkip},
+			wall:   time.Date(2030, 3, 10, 2, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "in the gap, default",
+			policy: valueobjects.LocalTimePolicy{},
+			wall:   time.Date(2030, 3, 10, 2, 30, 0, 0, time.UTC),
+			want:   "2030-03-10 07:30",
+		},
+		{
+			name:   "ambiguous, earlier",
+			policy: valueobjects.LocalTimePolicy{Ambiguity: valueobjects.AmbiguityEarlier},
+			wall:   time.Date(2030, 11, 3, 1, 30, 0, 0, time.UTC),
+			want:   "2030-11-03 05:30", // 01:30 EDT
+		},
+		{
+			name:   "ambiguous, later",
+			policy: valueobjects.LocalTimePolicy{Ambiguity: valueobjects.AmbiguityLater},
+			wall:   time.Date(2030, 11, 3, 1, 30, 0, 0, time.UTC),
+			want:   "2030-11-03 06:30", // 01:30 EST
+		},
+		{
+			name:   "ambiguous, default",
+			policy: valueobjects.LocalTimePolicy{},
+			wall:   time.Date(2030, 11, 3, 1, 30, 0, 0, time.UTC),
+			want:   "2030-11-03 05:30",
+		},
+		{
+			name:   "after the overlap",
+			policy: valueobjects.LocalTimePolicy{Ambiguity: valueobjects.AmbiguityLater},
+			wall:   time.Date(2030, 11, 3, 2, 0, 0, 0, time.UTC),
+			want:   "2030-11-03 07:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.policy.Resolve(tt.wall, newYork)
+			if tt.want == "" {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, newYork, got.Location())
+			assert.Equal(t, tt.want, got.UTC().Format("2006-01-02 15:04"))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package valueobjects
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+)
+
+// MetadataType is the type of a metadata value.
+type MetadataType string
+
+const (
+	MetadataString  MetadataType = "string"
+	MetadataNumber  MetadataType = "number"
+	MetadataBoolean MetadataType = "boolean"
+)
+
+const (
+	maxMetadataEntries     = 50
+	maxMetadataValueLength = 1024
+)
+
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// MetadataValue is a string, number or boolean attached to an entity under a
+// key, for integrations to record things like ticket IDs.
+type MetadataValue struct {
+	kind    MetadataType
+	text    string
+	number  float64
+	boolean bool
+}
+
+func NewStringMetadata(value string) (MetadataValue, error) {
+	if len(value) > maxMetadataValueLength {
+		return MetadataValue{}, domainerrors.Invalid("metadata value cannot be longer than " + strconv.Itoa(maxMetadataValueLength) + " characters")
+	}
+	return MetadataValue{kind: MetadataString, text: value}, nil
+}
+
+func NewNumberMetadata(value float64) MetadataValue {
+	return MetadataValue{kind: MetadataNumber, number: value}
+}
+
+func NewBooleanMetadata(value bool) MetadataValue {
+	return MetadataValue{kind: MetadataBoolean, boolean: value}
+}
+
+// ParseMetadataValue converts a decoded JSON value. Objects, arrays and null
+// are rejected.
+func ParseMetadataValue(value interface{}) (MetadataValue, error) {
+	switch v := value.(type) {
+	case string:
+		return NewStringMetadata(v)
+	case float64:
+		return NewNumberMetadata(v), nil
+	case bool:
+		return NewBooleanMetadata(v), nil
+	}
+	return MetadataValue{}, domainerrors.Invalid("metadata values must be strings, numbers or booleans")
+}
+
+func (v MetadataValue) Type() MetadataType {
+	return v.kind
+}
+
+// Value is the value as a string, float64 or bool, ready to encode as JSON.
+func (v MetadataValue) Value() interface{} {
+	switch v.kind {
+	case MetadataNumber:
+		return v.number
+	case MetadataBoolean:
+		return v.boolean
+	default:
+		return v.text
+	}
+}
+
+func (v MetadataValue) String() string {
+	switch v.kind {
+	case MetadataNumber:
+		return strconv.FormatFloat(v.number, 'g', -1, 64)
+	case MetadataBoolean:
+		return strconv.FormatBool(v.boolean)
+	default:
+		return v.text
+	}
+}
+
+func (v MetadataValue) Equal(other MetadataValue) bool {
+	return v == other
+}
+
+// Metadata is a set of typed values by key.
+type Metadata map[string]MetadataValue
+
+// Validate checks that there are at most 50 entries and that every key is 1
+// to 64 letters, digits, '_', '.' or '-', starting with a letter or digit.
+func (m Metadata) Validate() error {
+	if len(m) > maxMetadataEntries {
+		return domainerrors.Invalid("metadata cannot have more than " + strconv.Itoa(maxMetadataEntries) + " entries")
+	}
+	for key := range m {
+		if !metadataKeyPattern.MatchString(key) {
+			return domainerrors.Invalid("invalid metadata key: " + key)
+		}
+	}
+	return nil
+}
+
+// Keys returns the keys in sorted order.
+func (m Metadata) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m Metadata) Equal(other Metadata) bool {
+	if len(m) != len(other) {
+		return false
+	}
+	for key, value := range m {
+		if otherValue, ok := other[key]; !ok || !value.Equal(otherValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a copy that can be changed independently.
+func (m Metadata) Clone() Metadata {
+	clone := make(Metadata, len(m))
+	for key, value := range m {
+		clone[key] = value
+	}
+	return clone
+}
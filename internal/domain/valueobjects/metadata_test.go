@@ -0,0 +1,75 @@
+package valueobjects_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+func TestMetadataValidate(t *testing.T) {
+	entries := func(n int) valueobjects.Metadata {
+		metadata := make(valueobjects.Metadata, n)
+		for i := 0; i < n; i++ {
+			metadata[fmt.Sprintf("key-%d", i)] = valueobjects.NewBooleanMetadata(true)
+		}
+		return metadata
+	}
+
+	tests := []struct {
+		name     string
+		metadata valueobjects.Metadata
+		valid    bool
+	}{
+		{name: "empty", metadata: valueobjects.Metadata{}, valid: true},
+		{name: "50 entries", metadata: entries(50), valid: true},
+		{name: "51 entries", metadata: entries(51)},
+		{name: "dotted key", metadata: valueobjects.Metadata{"crm.ticket_id": valueobjects.NewNumberMetadata(1)}, valid: true},
+		{name: "64 character key", metadata: valueobjects.Metadata{strings.Repeat("k", 64): valueobjects.NewNumberMetadata(1)}, valid: true},
+		{name: "65 character key", metadata: valueobjects.Metadata{strings.Repeat("k", 65): valueobjects.NewNumberMetadata(1)}},
+		{name: "empty key", metadata: valueobjects.Metadata{"": valueobjects.NewNumberMetadata(1)}},
+		{name: "key starting with a separator", metadata: valueobjects.Metadata{"_ticket": valueobjects.NewNumberMetadata(1)}},
+		{name: "key with a space", metadata: valueobjects.Metadata{"ticket id": valueobjects.NewNumberMetadata(1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.metadata.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestParseMetadataValue(t *testing.T) {
+	value, err := valueobjects.ParseMetadataValue("OPS-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobjects.MetadataString, value.Type())
+	assert.Equal(t, "OPS-1", value.Value())
+
+	value, err = valueobjects.ParseMetadataValue(2.5)
+	require.NoError(t, err)
+	assert.Equal(t, valueobjects.MetadataNumber, value.Type())
+	assert.Equal(t, "2.5", value.String())
+
+	value, err = valueobjects.ParseMetadataValue(true)
+	require.NoError(t, err)
+	assert.Equal(t, valueobjects.MetadataBoolean, value.Type())
+	assert.Equal(t, true, value.Value())
+
+	_, err = valueobjects.ParseMetadataValue(strings.Repeat("v", 1024))
+	assert.NoError(t, err)
+	_, err = valueobjects.ParseMetadataValue(strings.Repeat("v", 1025))
+	assert.Error(t, err, "values are at most 1024 characters")
+
+	for _, invalid := range []interface{}{nil, []interface{}{"a"}, map[string]interface{}{"a": 1}} {
+		_, err = valueobjects.ParseMetadataValue(invalid)
+		assert.Error(t, err, "%v", invalid)
+	}
+}
@@ -1,8 +1,9 @@
 package valueobjects
 
 import (
-	"errors"
 	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
 )
 
 type TimeRange struct {
@@ -12,11 +13,11 @@ type TimeRange struct {
 
 func NewTimeRange(startTime, endTime time.Time) (TimeRange, error) {
 	if endTime.Before(startTime) {
-		return TimeRange{}, errors.New("end time cannot be before start time")
+		return TimeRange{}, domainerrors.Invalid("end time cannot be before start time")
 	}
 	
 	if startTime.Equal(endTime) {
-		return TimeRange{}, errors.New("start time and end time cannot be equal")
+		return TimeRange{}, domainerrors.Invalid("start time and end time cannot be equal")
 	}
 	
 	return TimeRange{
@@ -48,3 +49,28 @@ func (tr TimeRange) Contains(other TimeRange) bool {
 func (tr TimeRange) IsWithin(other TimeRange) bool {
 	return !other.startTime.After(tr.startTime) && !other.endTime.Before(tr.endTime)
 }
+
+// Overlap returns the time tr and other share, and false when they don't
+// overlap.
+func (tr TimeRange) Overlap(other TimeRange) (TimeRange, bool) {
+	if !tr.OverlapsWith(other) {
+		return TimeRange{}, false
+	}
+
+	overlap := tr
+	if other.startTime.After(overlap.startTime) {
+		overlap.startTime = other.startTime
+	}
+	if other.endTime.Before(overlap.endTime) {
+		overlap.endTime = other.endTime
+	}
+	return overlap, true
+}
+
+// Extend widens tr by before at the start and after at the end.
+func (tr TimeRange) Extend(before, after time.Duration) TimeRange {
+	return TimeRange{
+		startTime: tr.startTime.Add(-before),
+		endTime:   tr.endTime.Add(after),
+	}
+}
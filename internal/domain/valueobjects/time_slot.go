@@ -20,6 +20,16 @@ func NewTimeSlot(timeRange TimeRange, isAvailable bool, resourceID string) TimeS
 	}
 }
 
+// RestoreTimeSlot rebuilds a time slot with a previously assigned ID.
+func RestoreTimeSlot(id string, timeRange TimeRange, isAvailable bool, resourceID string) TimeSlot {
+	return TimeSlot{
+		id:          id,
+		timeRange:   timeRange,
+		isAvailable: isAvailable,
+		resourceID:  resourceID,
+	}
+}
+
 func (ts TimeSlot) ID() string {
 	return ts.id
 }
@@ -0,0 +1,234 @@
+package valueobjects
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+)
+
+// DateLayout is the format of the local dates working-hours overrides are
+// keyed by.
+const DateLayout = "2006-01-02"
+
+const minutesPerDay = 24 * 60
+
+// ClockTime is a wall-clock time of day in minutes after midnight. 24:00 is
+// allowed so an interval can run to the end of the day.
+type ClockTime int
+
+func ParseClockTime(value string) (ClockTime, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(value, "%d:%d", &hour, &minute); err != nil || len(value) != 5 {
+		return 0, domainerrors.Invalid("invalid time of day, expected HH:MM: " + value)
+	}
+
+	if hour < 0 || minute < 0 || minute > 59 || hour*60+minute > minutesPerDay {
+		return 0, domainerrors.Invalid("time of day out of range: " + value)
+	}
+	return ClockTime(hour*60 + minute), nil
+}
+
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+// on returns the instant the clock time falls on for the given local date.
+func (c ClockTime) on(year int, month time.Month, day int, location *time.Location) time.Time {
+	return time.Date(year, month, day, int(c)/60, int(c)%60, 0, 0, location)
+}
+
+type WorkingInterval struct {
+	start ClockTime
+	end   ClockTime
+}
+
+func NewWorkingInterval(start, end ClockTime) (WorkingInterval, error) {
+	if end <= start {
+		return WorkingInterval{}, domainerrors.Invalid("working interval must end after it starts: " + start.String() + "-" + end.String())
+	}
+	return WorkingInterval{start: start, end: end}, nil
+}
+
+func (wi WorkingInterval) Start() ClockTime {
+	return wi.start
+}
+
+func (wi WorkingInterval) End() ClockTime {
+	return wi.end
+}
+
+// WorkingHours is a weekly template of working intervals in wall-clock time,
+// plus overrides that replace the template on specific local dates. An
+// override without intervals marks a day off.
+type WorkingHours struct {
+	weekly    map[time.Weekday][]WorkingInterval
+	overrides map[string][]WorkingInterval
+}
+
+func NewWorkingHours(weekly map[time.Weekday][]WorkingInterval) (WorkingHours, error) {
+	wh := WorkingHours{
+		weekly:    make(map[time.Weekday][]WorkingInterval, len(weekly)),
+		overrides: make(map[string][]WorkingInterval),
+	}
+
+	for weekday, intervals := range weekly {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return WorkingHours{}, domainerrors.Invalidf("invalid weekday: %d", weekday)
+		}
+
+		normalized, err := normalizeIntervals(intervals)
+		if err != nil {
+			return WorkingHours{}, domainerrors.Invalid(strings.ToLower(weekday.String()) + ": " + err.Error())
+		}
+		if len(normalized) > 0 {
+			wh.weekly[weekday] = normalized
+		}
+	}
+
+	return wh, nil
+}
+
+// DefaultWorkingHours is Monday to Friday, 09:00 to 17:00.
+func DefaultWorkingHours() WorkingHours {
+	day := []WorkingInterval{{start: 9 * 60, end: 17 * 60}}
+	wh, _ := NewWorkingHours(map[time.Weekday][]WorkingInterval{
+		time.Monday:    day,
+		time.Tuesday:   day,
+		time.Wednesday: day,
+		time.Thursday:  day,
+		time.Friday:    day,
+	})
+	return wh
+}
+
+// Weekly returns the template intervals for each weekday that has any.
+func (wh WorkingHours) Weekly() map[time.Weekday][]WorkingInterval {
+	result := make(map[time.Weekday][]WorkingInterval, len(wh.weekly))
+	for weekday, intervals := range wh.weekly {
+		result[weekday] = append([]WorkingInterval(nil), intervals...)
+	}
+	return result
+}
+
+// Overrides returns the dated overrides keyed by local date (DateLayout).
+func (wh WorkingHours) Overrides() map[string][]WorkingInterval {
+	result := make(map[string][]WorkingInterval, len(wh.overrides))
+	for date, intervals := range wh.overrides {
+		result[date] = append([]WorkingInterval(nil), intervals...)
+	}
+	return result
+}
+
+// WithOverride returns a copy whose intervals on date replace the weekly
+// template. No intervals makes the date a day off.
+func (wh WorkingHours) WithOverride(date string, intervals []WorkingInterval) (WorkingHours, error) {
+	if _, err := time.Parse(DateLayout, date); err != nil {
+		return WorkingHours{}, domainerrors.Invalid("invalid override date, expected YYYY-MM-DD: " + date)
+	}
+
+	normalized, err := normalizeIntervals(intervals)
+	if err != nil {
+		return WorkingHours{}, domainerrors.Invalid(date + ": " + err.Error())
+	}
+
+	result := wh.copy()
+	result.overrides[date] = normalized
+	return result, nil
+}
+
+// WithoutOverride returns a copy in which date follows the weekly template.
+func (wh WorkingHours) WithoutOverride(date string) WorkingHours {
+	result := wh.copy()
+	delete(result.overrides, date)
+	return result
+}
+
+func (wh WorkingHours) HasOverride(date string) bool {
+	_, exists := wh.overrides[date]
+	return exists
+}
+
+// IntervalsOn returns the working intervals for a local date, taking
+// overrides into account.
+func (wh WorkingHours) IntervalsOn(year int, month time.Month, day int) []WorkingInterval {
+	date := time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+	if intervals, exists := wh.overrides[date.Format(DateLayout)]; exists {
+		return intervals
+	}
+	return wh.weekly[date.Weekday()]
+}
+
+// Covers reports whether timeRange lies entirely within working time, with
+// the template interpreted as wall-clock time in location. Intervals that
+// touch, including across midnight, count as continuous.
+func (wh WorkingHours) Covers(timeRange TimeRange, location *time.Location) bool {
+	if location == nil {
+		location = time.UTC
+	}
+
+	start := timeRange.StartTime().In(location)
+	end := timeRange.EndTime().In(location)
+
+	// Walk the local dates the range touches, extending covered while each
+	// interval picks up where the previous one left off. Noon is never
+	// skipped by a DST transition, so it names each date safely.
+	last := time.Date(end.Year(), end.Month(), end.Day(), 12, 0, 0, 0, location)
+	covered := start
+	for noon := time.Date(start.Year(), start.Month(), start.Day(), 12, 0, 0, 0, location); !noon.After(last); noon = noon.AddDate(0, 0, 1) {
+		year, month, day := noon.Date()
+		for _, interval := range wh.IntervalsOn(year, month, day) {
+			from := interval.start.on(year, month, day, location)
+			to := interval.end.on(year, month, day, location)
+			if !from.After(covered) && to.After(covered) {
+				covered = to
+			}
+		}
+
+		if !covered.Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+func (wh WorkingHours) copy() WorkingHours {
+	return WorkingHours{
+		weekly:    wh.Weekly(),
+		overrides: wh.Overrides(),
+	}
+}
+
+// normalizeIntervals sorts intervals and rejects overlapping ones.
+func normalizeIntervals(intervals []WorkingInterval) ([]WorkingInterval, error) {
+	sorted := append([]WorkingInterval(nil), intervals...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].start < sorted[j].start
+	})
+
+	for i, interval := range sorted {
+		if interval.end <= interval.start {
+			return nil, domainerrors.Invalid("working interval must end after it starts")
+		}
+		if i > 0 && interval.start < sorted[i-1].end {
+			return nil, domainerrors.Invalid("working intervals overlap: " + sorted[i-1].start.String() + "-" + sorted[i-1].end.String() + " and " + interval.start.String() + "-" + interval.end.String())
+		}
+	}
+
+	if sorted == nil {
+		sorted = make([]WorkingInterval, 0)
+	}
+	return sorted, nil
+}
+
+// ParseWeekday accepts English weekday names in any case, e.g. "monday".
+func ParseWeekday(name string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), name) {
+			return weekday, nil
+		}
+	}
+	return 0, domainerrors.Invalid("invalid weekday: " + name)
+}
@@ -0,0 +1,129 @@
+package valueobjects_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// intervals parses pairs of HH:MM clock times into working intervals.
+func intervals(t *testing.T, clockTimes ...string) []valueobjects.WorkingInterval {
+	t.Helper()
+
+	result := make([]valueobjects.WorkingInterval, 0, len(clockTimes)/2)
+	for i := 0; i+1 < len(clockTimes); i += 2 {
+		start, err := valueobjects.ParseClockTime(clockTimes[i])
+		require.NoError(t, err)
+		end, err := valueobjects.ParseClockTime(clockTimes[i+1])
+		require.NoError(t, err)
+		interval, err := valueobjects.NewWorkingInterval(start, end)
+		require.NoError(t, err)
+		result = append(result, interval)
+	}
+	return result
+}
+
+// TestWorkingHoursCovers evaluates a weekly template with overrides in New
+// York around the DST change on 2030-03-10, when clocks jump from 02:00 to
+// 03:00. March 12 is a day off and March 13 has shorter hours.
+func TestWorkingHoursCovers(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	weekday := intervals(t, "09:00", "12:00", "13:00", "17:30")
+	workingHours, err := valueobjects.NewWorkingHours(map[time.Weekday][]valueobjects.WorkingInterval{
+		time.Monday:    weekday,
+		time.Tuesday:   weekday,
+		time.Wednesday: weekday,
+		time.Thursday:  weekday,
+		time.Friday:    intervals(t, "09:00", "13:00"),
+		time.Saturday:  intervals(t, "20:00", "24:00"),
+		time.Sunday:    intervals(t, "00:00", "04:00"),
+	})
+	require.NoError(t, err)
+
+	workingHours, err = workingHours.WithOverride("2030-03-12", nil)
+	require.NoError(t, err)
+	workingHours, err = workingHours.WithOverride("2030-03-13", intervals(t, "10:00", "11:00"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		day   int // Of March 2030
+		start string
+		end   string
+		want  bool
+	}{
+		{name: "morning before DST", day: 4, start: "09:00", end: "12:00", want: true},
+		{name: "end of day before DST", day: 4, start: "16:30", end: "17:30", want: true},
+		{name: "end of day after DST", day: 11, start: "16:30", end: "17:30", want: true},
+		{name: "past the end of day after DST", day: 11, start: "17:00", end: "18:00", want: false},
+		{name: "over lunch", day: 11, start: "11:00", end: "13:30", want: false},
+		{name: "Friday afternoon", day: 15, start: "12:00", end: "13:30", want: false},
+		{name: "across midnight and the DST gap", day: 9, start: "23:00", end: "27:30", want: true},
+		{name: "past the Sunday interval", day: 10, start: "03:30", end: "04:30", want: false},
+		{name: "day off", day: 12, start: "10:00", end: "11:00", want: false},
+		{name: "within shorter hours", day: 13, start: "10:00", end: "11:00", want: true},
+		{name: "outside shorter hours", day: 13, start: "13:00", end: "14:00", want: false},
+		{name: "template after the overrides", day: 14, start: "13:00", end: "14:00", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Hours past 24 run into the next day
+			at := func(clock string) time.Time {
+				var hour, minute int
+				_, err := fmt.Sscanf(clock, "%d:%d", &hour, &minute)
+				require.NoError(t, err)
+				return time.Date(2030, time.March, tt.day+hour/24, hour%24, minute, 0, 0, newYork)
+			}
+
+			timeRange, err := valueobjects.NewTimeRange(at(tt.start), at(tt.end))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, workingHours.Covers(timeRange, newYork))
+		})
+	}
+}
+
+func TestWorkingHoursOverrides(t *testing.T) {
+	workingHours := valueobjects.DefaultWorkingHours()
+	assert.Equal(t, intervals(t, "09:00", "17:00"), workingHours.IntervalsOn(2030, time.March, 12))
+
+	withOverride, err := workingHours.WithOverride("2030-03-12", intervals(t, "13:00", "15:00", "08:00", "10:00"))
+	require.NoError(t, err)
+	assert.True(t, withOverride.HasOverride("2030-03-12"))
+	assert.Equal(t, intervals(t, "08:00", "10:00", "13:00", "15:00"), withOverride.IntervalsOn(2030, time.March, 12), "intervals are sorted")
+	assert.False(t, workingHours.HasOverride("2030-03-12"), "the original is left as it was")
+
+	withoutOverride := withOverride.WithoutOverride("2030-03-12")
+	assert.False(t, withoutOverride.HasOverride("2030-03-12"))
+	assert.Equal(t, intervals(t, "09:00", "17:00"), withoutOverride.IntervalsOn(2030, time.March, 12))
+
+	_, err = workingHours.WithOverride("12/03/2030", nil)
+	assert.Error(t, err)
+
+	_, err = workingHours.WithOverride("2030-03-12", intervals(t, "09:00", "12:00", "11:00", "13:00"))
+	assert.Error(t, err, "overlapping intervals")
+
+	_, err = valueobjects.NewWorkingHours(map[time.Weekday][]valueobjects.WorkingInterval{
+		time.Monday: intervals(t, "09:00", "12:00", "09:30", "10:00"),
+	})
+	assert.Error(t, err, "overlapping intervals")
+}
+
+func TestParseClockTime(t *testing.T) {
+	for _, value := range []string{"00:00", "09:30", "23:59", "24:00"} {
+		clock, err := valueobjects.ParseClockTime(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, value, clock.String())
+	}
+
+	for _, value := range []string{"9:00", "24:01", "12:60", "-1:00", "noon", ""} {
+		_, err := valueobjects.ParseClockTime(value)
+		assert.Error(t, err, value)
+	}
+}
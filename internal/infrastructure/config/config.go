@@ -7,9 +7,12 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Logging  LoggingConfig
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Holidays   HolidayConfig
+	Recurrence RecurrenceConfig
+	Scheduling SchedulingConfig
+	Logging    LoggingConfig
 }
 
 type ServerConfig struct {
@@ -21,13 +24,33 @@ type ServerConfig struct {
 }
 
 type DatabaseConfig struct {
-	Type     string // "memory", "postgres", "mysql"
+	Type     string // "memory", "postgres", "bolt"
 	Host     string
 	Port     string
 	User     string
 	Password string
 	Name     string
 	SSLMode  string
+	Path     string // Database file for embedded backends
+}
+
+type HolidayConfig struct {
+	Path string // Extra holiday calendar file or directory, on top of the bundled ones
+}
+
+type RecurrenceConfig struct {
+	Horizon        time.Duration // How far ahead appointment series occurrences are booked
+	ExtendInterval time.Duration // How often series are booked further ahead as time passes
+
+	// Default resolution of local times that don't exist ("shift_forward",
+	// "next_valid" or "skip") or exist twice ("earlier" or "later") when a
+	// DST change falls on a recurrence
+	GapPolicy       string
+	AmbiguityPolicy string
+}
+
+type SchedulingConfig struct {
+	ConflictAlternatives int // How many conflict-free times to suggest when booking fails on a conflict
 }
 
 type LoggingConfig struct {
@@ -52,6 +75,19 @@ func Load() *Config {
 			Password: getEnv("DB_PASSWORD", ""),
 			Name:     getEnv("DB_NAME", "appointment_calculator"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Path:     getEnv("DB_PATH", "data/appointments.db"),
+		},
+		Holidays: HolidayConfig{
+			Path: getEnv("HOLIDAY_CALENDARS_PATH", ""),
+		},
+		Recurrence: RecurrenceConfig{
+			Horizon:         getDurationEnv("SERIES_HORIZON", 365*24*time.Hour),
+			ExtendInterval:  getDurationEnv("SERIES_EXTEND_INTERVAL", 24*time.Hour),
+			GapPolicy:       getEnv("DST_GAP_POLICY", "shift_forward"),
+			AmbiguityPolicy: getEnv("DST_AMBIGUITY_POLICY", "earlier"),
+		},
+		Scheduling: SchedulingConfig{
+			ConflictAlternatives: getIntEnv("CONFLICT_ALTERNATIVES", 3),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
@@ -68,6 +104,19 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+func getIntEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	if number, err := strconv.Atoi(value); err == nil {
+		return number
+	}
+
+	return defaultValue
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	value := os.Getenv(key)
 	if value == "" {
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS participant_availability;
+DROP TABLE IF EXISTS participants;
+DROP TABLE IF EXISTS schedule_blocked_times;
+DROP TABLE IF EXISTS schedule_appointments;
+DROP TABLE IF EXISTS schedules;
+DROP TABLE IF EXISTS appointment_attendees;
+DROP TABLE IF EXISTS appointments;
@@ -0,0 +1,59 @@
+CREATE TABLE appointments (
+    id         TEXT PRIMARY KEY,
+    title      TEXT NOT NULL,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time   TIMESTAMPTZ NOT NULL,
+    location   TEXT NOT NULL DEFAULT '',
+    status     TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE appointment_attendees (
+    appointment_id TEXT NOT NULL REFERENCES appointments (id) ON DELETE CASCADE,
+    participant_id TEXT NOT NULL,
+    position       INTEGER NOT NULL,
+    PRIMARY KEY (appointment_id, participant_id)
+);
+
+CREATE INDEX idx_appointment_attendees_participant ON appointment_attendees (participant_id);
+
+CREATE TABLE schedules (
+    id                  TEXT PRIMARY KEY,
+    owner_id            TEXT NOT NULL UNIQUE,
+    timezone            TEXT NOT NULL,
+    working_hours_start TIMESTAMPTZ NOT NULL,
+    working_hours_end   TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE schedule_appointments (
+    schedule_id    TEXT NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    appointment_id TEXT NOT NULL REFERENCES appointments (id) ON DELETE CASCADE,
+    PRIMARY KEY (schedule_id, appointment_id)
+);
+
+CREATE TABLE schedule_blocked_times (
+    schedule_id TEXT NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    position    INTEGER NOT NULL,
+    start_time  TIMESTAMPTZ NOT NULL,
+    end_time    TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (schedule_id, position)
+);
+
+CREATE TABLE participants (
+    id       TEXT PRIMARY KEY,
+    name     TEXT NOT NULL,
+    email    TEXT NOT NULL UNIQUE,
+    timezone TEXT NOT NULL
+);
+
+CREATE TABLE participant_availability (
+    id             TEXT PRIMARY KEY,
+    participant_id TEXT NOT NULL REFERENCES participants (id) ON DELETE CASCADE,
+    start_time     TIMESTAMPTZ NOT NULL,
+    end_time       TIMESTAMPTZ NOT NULL,
+    is_available   BOOLEAN NOT NULL,
+    resource_id    TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_participant_availability_participant ON participant_availability (participant_id);
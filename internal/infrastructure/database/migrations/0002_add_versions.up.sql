@@ -0,0 +1,2 @@
+ALTER TABLE appointments ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE schedules ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
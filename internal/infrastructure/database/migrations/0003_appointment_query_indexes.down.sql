@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_appointments_status;
+DROP INDEX IF EXISTS idx_appointments_created_at;
+DROP INDEX IF EXISTS idx_appointments_start_time;
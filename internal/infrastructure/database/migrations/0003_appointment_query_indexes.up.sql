@@ -0,0 +1,3 @@
+CREATE INDEX idx_appointments_start_time ON appointments (start_time, id);
+CREATE INDEX idx_appointments_created_at ON appointments (created_at, id);
+CREATE INDEX idx_appointments_status ON appointments (status);
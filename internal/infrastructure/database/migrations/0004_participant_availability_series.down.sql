@@ -0,0 +1 @@
+DROP TABLE participant_availability_series;
@@ -0,0 +1,12 @@
+CREATE TABLE participant_availability_series (
+    id             TEXT PRIMARY KEY,
+    participant_id TEXT NOT NULL REFERENCES participants (id) ON DELETE CASCADE,
+    start_time     TIMESTAMPTZ NOT NULL,
+    end_time       TIMESTAMPTZ NOT NULL,
+    pattern        TEXT NOT NULL,
+    interval_count INTEGER NOT NULL,
+    end_date       TIMESTAMPTZ,
+    exceptions     JSONB NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX idx_participant_availability_series_participant ON participant_availability_series (participant_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_schedule_blocked_times_id;
+
+ALTER TABLE schedule_blocked_times
+    DROP COLUMN reason,
+    DROP COLUMN id;
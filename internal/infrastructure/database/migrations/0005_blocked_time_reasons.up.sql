@@ -0,0 +1,9 @@
+ALTER TABLE schedule_blocked_times
+    ADD COLUMN id TEXT,
+    ADD COLUMN reason TEXT NOT NULL DEFAULT '';
+
+UPDATE schedule_blocked_times SET id = md5(schedule_id || '/' || position);
+
+ALTER TABLE schedule_blocked_times ALTER COLUMN id SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_schedule_blocked_times_id ON schedule_blocked_times (id);
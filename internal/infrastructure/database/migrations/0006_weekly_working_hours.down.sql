@@ -0,0 +1,10 @@
+-- The weekly template cannot be expressed as a single absolute range, so
+-- rolled back schedules get a fixed window that covers all time.
+ALTER TABLE schedules
+    ADD COLUMN working_hours_start TIMESTAMPTZ NOT NULL DEFAULT '1970-01-01T00:00:00Z',
+    ADD COLUMN working_hours_end   TIMESTAMPTZ NOT NULL DEFAULT '9999-12-31T00:00:00Z';
+
+ALTER TABLE schedules
+    ALTER COLUMN working_hours_start DROP DEFAULT,
+    ALTER COLUMN working_hours_end DROP DEFAULT,
+    DROP COLUMN working_hours;
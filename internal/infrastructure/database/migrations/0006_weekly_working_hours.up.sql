@@ -0,0 +1,12 @@
+ALTER TABLE schedules
+    ADD COLUMN working_hours JSONB NOT NULL DEFAULT '{"weekly": {
+        "monday":    [{"start": "09:00", "end": "17:00"}],
+        "tuesday":   [{"start": "09:00", "end": "17:00"}],
+        "wednesday": [{"start": "09:00", "end": "17:00"}],
+        "thursday":  [{"start": "09:00", "end": "17:00"}],
+        "friday":    [{"start": "09:00", "end": "17:00"}]
+    }}';
+
+ALTER TABLE schedules
+    DROP COLUMN working_hours_start,
+    DROP COLUMN working_hours_end;
@@ -0,0 +1,2 @@
+ALTER TABLE participants DROP COLUMN holiday_calendars;
+ALTER TABLE schedules DROP COLUMN holiday_calendars;
@@ -0,0 +1,2 @@
+ALTER TABLE schedules ADD COLUMN holiday_calendars TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE participants ADD COLUMN holiday_calendars TEXT[] NOT NULL DEFAULT '{}';
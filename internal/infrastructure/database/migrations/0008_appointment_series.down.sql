@@ -0,0 +1 @@
+DROP TABLE appointment_series;
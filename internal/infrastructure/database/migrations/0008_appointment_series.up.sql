@@ -0,0 +1,17 @@
+CREATE TABLE appointment_series (
+    id         TEXT PRIMARY KEY,
+    title      TEXT NOT NULL,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time   TIMESTAMPTZ NOT NULL,
+    attendees  TEXT[] NOT NULL,
+    location   TEXT NOT NULL DEFAULT '',
+    rrule      TEXT NOT NULL,
+    timezone   TEXT NOT NULL,
+    exdates    JSONB NOT NULL DEFAULT '[]',
+    overrides  JSONB NOT NULL DEFAULT '[]',
+    horizon    TIMESTAMPTZ NOT NULL,
+    status     TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    version    INTEGER NOT NULL DEFAULT 1
+);
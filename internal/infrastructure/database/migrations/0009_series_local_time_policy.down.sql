@@ -0,0 +1,3 @@
+ALTER TABLE appointment_series
+    DROP COLUMN dst_gap_policy,
+    DROP COLUMN dst_ambiguity_policy;
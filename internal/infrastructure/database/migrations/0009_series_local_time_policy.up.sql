@@ -0,0 +1,3 @@
+ALTER TABLE appointment_series
+    ADD COLUMN dst_gap_policy TEXT NOT NULL DEFAULT 'shift_forward',
+    ADD COLUMN dst_ambiguity_policy TEXT NOT NULL DEFAULT 'earlier';
@@ -0,0 +1,7 @@
+ALTER TABLE appointment_series
+    DROP COLUMN attendee_details;
+
+ALTER TABLE appointment_attendees
+    DROP COLUMN role,
+    DROP COLUMN response,
+    DROP COLUMN responded_at;
@@ -0,0 +1,7 @@
+ALTER TABLE appointment_attendees
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'required',
+    ADD COLUMN response TEXT NOT NULL DEFAULT 'needs-action',
+    ADD COLUMN responded_at TIMESTAMPTZ;
+
+ALTER TABLE appointment_series
+    ADD COLUMN attendee_details JSONB;
@@ -0,0 +1,6 @@
+UPDATE appointment_series SET status = 'scheduled' WHERE status = 'confirmed';
+UPDATE appointments SET status = 'scheduled' WHERE status IN ('tentative', 'confirmed', 'in-progress');
+UPDATE appointments SET status = 'completed' WHERE status = 'no-show';
+
+ALTER TABLE appointments
+    DROP COLUMN status_history;
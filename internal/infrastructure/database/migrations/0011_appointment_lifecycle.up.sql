@@ -0,0 +1,5 @@
+ALTER TABLE appointments
+    ADD COLUMN status_history JSONB NOT NULL DEFAULT '[]';
+
+UPDATE appointments SET status = 'confirmed' WHERE status = 'scheduled';
+UPDATE appointment_series SET status = 'confirmed' WHERE status = 'scheduled';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_appointments_metadata;
+DROP INDEX IF EXISTS idx_appointments_tags;
+
+ALTER TABLE appointments
+    DROP COLUMN metadata,
+    DROP COLUMN tags,
+    DROP COLUMN description;
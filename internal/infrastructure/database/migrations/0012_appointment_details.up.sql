@@ -0,0 +1,7 @@
+ALTER TABLE appointments
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_appointments_tags ON appointments USING GIN (tags);
+CREATE INDEX idx_appointments_metadata ON appointments USING GIN (metadata);
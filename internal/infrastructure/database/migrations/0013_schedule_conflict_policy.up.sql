@@ -0,0 +1 @@
+ALTER TABLE schedules ADD COLUMN conflict_policy JSONB NOT NULL DEFAULT '{}';
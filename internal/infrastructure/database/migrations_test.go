@@ -0,0 +1,135 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	// Versions are applied in order, one migration each, starting at 1
+	for i, migration := range migrations {
+		assert.Equal(t, i+1, migration.Version, migration.Name)
+		assert.NotEmpty(t, migration.up, migration.Name)
+		assert.NotEmpty(t, migration.down, migration.Name)
+	}
+	assert.Equal(t, "initial_schema", migrations[0].Name)
+}
+
+// openMigrationTestDB connects to the database in POSTGRES_TEST_DSN with a
+// fresh, empty schema first on the search path, skipping the test when it
+// isn't set. The schema is dropped afterwards.
+func openMigrationTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("migrations_test_%d", time.Now().UnixNano())
+	_, err = admin.Exec(`CREATE SCHEMA ` + schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`) })
+
+	// lib/pq passes unknown settings on to the server
+	if strings.Contains(dsn, "://") {
+		parsed, err := url.Parse(dsn)
+		require.NoError(t, err)
+		query := parsed.Query()
+		query.Set("search_path", schema)
+		parsed.RawQuery = query.Encode()
+		dsn = parsed.String()
+	} else {
+		dsn += " search_path=" + schema
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func tableExists(t *testing.T, db *sql.DB, table string) bool {
+	t.Helper()
+
+	var exists bool
+	require.NoError(t, db.QueryRow(`SELECT to_regclass($1) IS NOT NULL`, table).Scan(&exists))
+	return exists
+}
+
+func TestMigratorStatusIsReadOnly(t *testing.T) {
+	db := openMigrationTestDB(t)
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+
+	err = migrator.EnsureCurrent()
+	assert.ErrorIs(t, err, ErrSchemaOutOfDate)
+	assert.ErrorContains(t, err, "not been migrated")
+
+	statuses, err := migrator.Status()
+	require.NoError(t, err)
+	require.Len(t, statuses, len(migrator.migrations))
+	for _, status := range statuses {
+		assert.False(t, status.Applied, status.Name)
+	}
+	assert.False(t, tableExists(t, db, "schema_migrations"), "checking the status doesn't migrate")
+
+	_, err = migrator.Up()
+	require.NoError(t, err)
+	assert.NoError(t, migrator.EnsureCurrent())
+
+	// A rollback leaves the last migration pending
+	last := migrator.migrations[len(migrator.migrations)-1]
+	_, err = migrator.Down(1)
+	require.NoError(t, err)
+	err = migrator.EnsureCurrent()
+	assert.ErrorIs(t, err, ErrSchemaOutOfDate)
+	assert.ErrorContains(t, err, fmt.Sprintf("pending migrations %04d_%s", last.Version, last.Name))
+}
+
+func TestMigratorRollsBackFailedMigration(t *testing.T) {
+	db := openMigrationTestDB(t)
+	migrator := &Migrator{db: db, migrations: []Migration{
+		{Version: 1, Name: "first", up: `CREATE TABLE first (id TEXT)`, down: `DROP TABLE first`},
+		{Version: 2, Name: "second", up: `CREATE TABLE second (id TEXT); SELECT * FROM missing`, down: `DROP TABLE second`},
+		{Version: 3, Name: "third", up: `CREATE TABLE third (id TEXT)`, down: `DROP TABLE third`},
+	}}
+
+	applied, err := migrator.Up()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "migration 0002_second failed")
+	require.Len(t, applied, 1)
+	assert.Equal(t, 1, applied[0].Version)
+
+	// The failed migration left nothing behind, and the ones after it weren't run
+	assert.True(t, tableExists(t, db, "first"))
+	assert.False(t, tableExists(t, db, "second"))
+	assert.False(t, tableExists(t, db, "third"))
+
+	statuses, err := migrator.Status()
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+	assert.False(t, statuses[2].Applied)
+
+	err = migrator.EnsureCurrent()
+	assert.ErrorIs(t, err, ErrSchemaOutOfDate)
+	assert.ErrorContains(t, err, "pending migrations 0002_second, 0003_third")
+}
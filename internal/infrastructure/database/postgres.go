@@ -0,0 +1,52 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+
+	_ "github.com/lib/pq"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/config"
+)
+
+func OpenPostgres(cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", PostgresDSN(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+
+	return db, nil
+}
+
+// PostgresDSN builds a postgres:// URL from cfg. Every part is escaped, so
+// passwords and names may contain spaces, quotes, '@' or '/'.
+func PostgresDSN(cfg config.DatabaseConfig) string {
+	dsn := url.URL{
+		Scheme: "postgres",
+		Host:   cfg.Host,
+		Path:   "/" + cfg.Name,
+	}
+
+	if cfg.Port != "" {
+		dsn.Host = net.JoinHostPort(cfg.Host, cfg.Port)
+	}
+
+	switch {
+	case cfg.Password != "":
+		dsn.User = url.UserPassword(cfg.User, cfg.Password)
+	case cfg.User != "":
+		dsn.User = url.User(cfg.User)
+	}
+
+	if cfg.SSLMode != "" {
+		dsn.RawQuery = url.Values{"sslmode": {cfg.SSLMode}}.Encode()
+	}
+
+	return dsn.String()
+}
@@ -0,0 +1,50 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/config"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/database"
+)
+
+// TestPostgresDSN checks the URL through lib/pq's own parser, which turns it
+// into the key=value form the driver connects with.
+func TestPostgresDSN(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.DatabaseConfig
+		want string
+	}{
+		{
+			name: "plain",
+			cfg:  config.DatabaseConfig{Host: "localhost", Port: "5432", User: "postgres", Password: "postgres", Name: "appointments", SSLMode: "disable"},
+			want: "dbname='appointments' host='localhost' password='postgres' port='5432' sslmode='disable' user='postgres'",
+		},
+		{
+			name: "special characters",
+			cfg:  config.DatabaseConfig{Host: "db.internal", Port: "5432", User: "app user", Password: `p@ss w'o/rd:?#% sslmode=require`, Name: "my db", SSLMode: "verify-full"},
+			want: `dbname='my db' host='db.internal' password='p@ss w\'o/rd:?#% sslmode=require' port='5432' sslmode='verify-full' user='app user'`,
+		},
+		{
+			name: "IPv6 host",
+			cfg:  config.DatabaseConfig{Host: "::1", Port: "5433", User: "app", Name: "appointments"},
+			want: "dbname='appointments' host='::1' port='5433' user='app'",
+		},
+		{
+			name: "defaults left to the driver",
+			cfg:  config.DatabaseConfig{Host: "localhost"},
+			want: "host='localhost'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := pq.ParseURL(database.PostgresDSN(tt.cfg))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, parsed)
+		})
+	}
+}
@@ -1,26 +1,38 @@
 package dependency
 
 import (
+	"database/sql"
+	"fmt"
+
 	"github.com/visiab/appointment-calculator/internal/application/usecases"
 	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 	"github.com/visiab/appointment-calculator/internal/infrastructure/config"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/database"
 	"github.com/visiab/appointment-calculator/internal/infrastructure/repositories"
 	infraServices "github.com/visiab/appointment-calculator/internal/infrastructure/services"
 	"github.com/visiab/appointment-calculator/internal/interfaces/http/controllers"
 	"github.com/visiab/appointment-calculator/internal/interfaces/http/presenters"
+	bolt "go.etcd.io/bbolt"
 )
 
 type Container struct {
 	Config *config.Config
 
+	sqlDB  *sql.DB
+	boltDB *bolt.DB
+
 	// Repositories
-	AppointmentRepo  usecases.AppointmentRepository
-	ScheduleRepo     usecases.ScheduleRepository
-	ParticipantRepo  usecases.ParticipantRepository
+	AppointmentRepo usecases.AppointmentRepository
+	ScheduleRepo    usecases.ScheduleRepository
+	ParticipantRepo usecases.ParticipantRepository
+	SeriesRepo      usecases.AppointmentSeriesRepository
+	UnitOfWork      usecases.UnitOfWork
 
 	// Domain Services
-	ConflictDetector    *services.ConflictDetectionService
-	OptimalTimeFinder   *services.OptimalTimeFinderService
+	HolidayCalendars     *services.HolidayCalendarService
+	ConflictDetector     *services.ConflictDetectionService
+	OptimalTimeFinder    *services.OptimalTimeFinderService
 	RecurrenceCalculator *services.RecurrenceCalculatorService
 
 	// Infrastructure Services
@@ -28,31 +40,64 @@ type Container struct {
 	TimezoneService     *infraServices.TimezoneService
 
 	// Use Cases
-	CreateAppointmentUseCase         *usecases.CreateAppointmentUseCase
-	UpdateAppointmentUseCase         *usecases.UpdateAppointmentUseCase
-	FindAvailableTimeSlotsUseCase    *usecases.FindAvailableTimeSlotsUseCase
+	CreateAppointmentUseCase              *usecases.CreateAppointmentUseCase
+	UpdateAppointmentUseCase              *usecases.UpdateAppointmentUseCase
+	GetAppointmentUseCase                 *usecases.GetAppointmentUseCase
+	RespondToAppointmentUseCase           *usecases.RespondToAppointmentUseCase
+	ChangeAppointmentStatusUseCase        *usecases.ChangeAppointmentStatusUseCase
+	ListAppointmentsUseCase               *usecases.ListAppointmentsUseCase
+	CreateAppointmentSeriesUseCase        *usecases.CreateAppointmentSeriesUseCase
+	GetAppointmentSeriesUseCase           *usecases.GetAppointmentSeriesUseCase
+	UpdateAppointmentSeriesUseCase        *usecases.UpdateAppointmentSeriesUseCase
+	ExtendAppointmentSeriesUseCase        *usecases.ExtendAppointmentSeriesUseCase
+	PreviewRecurrenceUseCase              *usecases.PreviewRecurrenceUseCase
+	FindAvailableTimeSlotsUseCase         *usecases.FindAvailableTimeSlotsUseCase
+	CreateParticipantUseCase              *usecases.CreateParticipantUseCase
+	GetParticipantUseCase                 *usecases.GetParticipantUseCase
+	UpdateParticipantUseCase              *usecases.UpdateParticipantUseCase
+	DeleteParticipantUseCase              *usecases.DeleteParticipantUseCase
+	AddAvailabilityUseCase                *usecases.AddAvailabilityUseCase
+	GetAvailabilityUseCase                *usecases.GetAvailabilityUseCase
+	RemoveAvailabilityUseCase             *usecases.RemoveAvailabilityUseCase
+	CreateScheduleUseCase                 *usecases.CreateScheduleUseCase
+	GetScheduleUseCase                    *usecases.GetScheduleUseCase
+	UpdateWorkingHoursUseCase             *usecases.UpdateWorkingHoursUseCase
+	AddBlockedTimeUseCase                 *usecases.AddBlockedTimeUseCase
+	ListBlockedTimesUseCase               *usecases.ListBlockedTimesUseCase
+	RemoveBlockedTimeUseCase              *usecases.RemoveBlockedTimeUseCase
+	UpdateScheduleHolidayCalendarsUseCase *usecases.UpdateScheduleHolidayCalendarsUseCase
+	UpdateConflictPolicyUseCase           *usecases.UpdateConflictPolicyUseCase
+	ListHolidayCalendarsUseCase           *usecases.ListHolidayCalendarsUseCase
+	ListHolidaysUseCase                   *usecases.ListHolidaysUseCase
 
 	// Presenters
 	AppointmentPresenter *presenters.AppointmentPresenter
 	SchedulePresenter    *presenters.SchedulePresenter
 
 	// Controllers
-	AppointmentController *controllers.AppointmentController
-	ScheduleController    *controllers.ScheduleController
-	ParticipantController *controllers.ParticipantController
+	AppointmentController       *controllers.AppointmentController
+	AppointmentSeriesController *controllers.AppointmentSeriesController
+	RecurrenceController        *controllers.RecurrenceController
+	ScheduleController          *controllers.ScheduleController
+	ParticipantController       *controllers.ParticipantController
+	HolidayController           *controllers.HolidayController
 }
 
-func NewContainer() *Container {
-	cfg := config.Load()
+func NewContainer(cfg *config.Config) (*Container, error) {
 	c := &Container{
 		Config: cfg,
 	}
 
 	// Initialize repositories
-	c.initRepositories()
+	if err := c.initRepositories(); err != nil {
+		return nil, err
+	}
 
 	// Initialize domain services
-	c.initDomainServices()
+	if err := c.initDomainServices(); err != nil {
+		c.Close()
+		return nil, err
+	}
 
 	// Initialize infrastructure services
 	c.initInfrastructureServices()
@@ -66,21 +111,94 @@ func NewContainer() *Container {
 	// Initialize controllers
 	c.initControllers()
 
-	return c
+	return c, nil
+}
+
+// Close releases the database connection, if any.
+func (c *Container) Close() error {
+	if c.sqlDB != nil {
+		return c.sqlDB.Close()
+	}
+	if c.boltDB != nil {
+		return c.boltDB.Close()
+	}
+	return nil
 }
 
-func (c *Container) initRepositories() {
-	// For now, use in-memory repositories
-	// In production, this would be configurable based on config.Database.Type
-	c.AppointmentRepo = repositories.NewMemoryAppointmentRepository()
-	c.ScheduleRepo = repositories.NewMemoryScheduleRepository()
-	c.ParticipantRepo = repositories.NewMemoryParticipantRepository()
+func (c *Container) initRepositories() error {
+	switch c.Config.Database.Type {
+	case "memory", "":
+		appointmentRepo := repositories.NewMemoryAppointmentRepository()
+		scheduleRepo := repositories.NewMemoryScheduleRepository(appointmentRepo)
+		seriesRepo := repositories.NewMemoryAppointmentSeriesRepository()
+		c.AppointmentRepo = appointmentRepo
+		c.ScheduleRepo = scheduleRepo
+		c.ParticipantRepo = repositories.NewMemoryParticipantRepository()
+		c.SeriesRepo = seriesRepo
+		c.UnitOfWork = repositories.NewMemoryUnitOfWork(appointmentRepo, scheduleRepo, seriesRepo)
+
+	case "postgres":
+		db, err := database.OpenPostgres(c.Config.Database)
+		if err != nil {
+			return err
+		}
+
+		// Schema changes are applied by cmd/migrate; refuse to run against
+		// an older schema rather than fail on the first query
+		migrator, err := database.NewMigrator(db)
+		if err != nil {
+			db.Close()
+			return err
+		}
+
+		if err := migrator.EnsureCurrent(); err != nil {
+			db.Close()
+			return fmt.Errorf("%w (run `go run ./cmd/migrate up`)", err)
+		}
+
+		c.sqlDB = db
+		c.AppointmentRepo = repositories.NewPostgresAppointmentRepository(db)
+		c.ScheduleRepo = repositories.NewPostgresScheduleRepository(db)
+		c.ParticipantRepo = repositories.NewPostgresParticipantRepository(db)
+		c.SeriesRepo = repositories.NewPostgresAppointmentSeriesRepository(db)
+		c.UnitOfWork = repositories.NewPostgresUnitOfWork(db)
+
+	case "bolt":
+		db, err := database.OpenBolt(c.Config.Database.Path)
+		if err != nil {
+			return err
+		}
+
+		c.boltDB = db
+		c.AppointmentRepo = repositories.NewBoltAppointmentRepository(db)
+		c.ScheduleRepo = repositories.NewBoltScheduleRepository(db)
+		c.ParticipantRepo = repositories.NewBoltParticipantRepository(db)
+		c.SeriesRepo = repositories.NewBoltAppointmentSeriesRepository(db)
+		c.UnitOfWork = repositories.NewBoltUnitOfWork(db)
+
+	default:
+		return fmt.Errorf("unsupported database type: %s", c.Config.Database.Type)
+	}
+
+	return nil
 }
 
-func (c *Container) initDomainServices() {
-	c.ConflictDetector = services.NewConflictDetectionService()
+func (c *Container) initDomainServices() error {
+	calendars, err := infraServices.LoadHolidayCalendars(c.Config.Holidays.Path)
+	if err != nil {
+		return fmt.Errorf("failed to load holiday calendars: %w", err)
+	}
+
+	c.HolidayCalendars = services.NewHolidayCalendarService(calendars)
+	c.ConflictDetector = services.NewConflictDetectionService(c.HolidayCalendars)
 	c.OptimalTimeFinder = services.NewOptimalTimeFinderService(c.ConflictDetector)
-	c.RecurrenceCalculator = services.NewRecurrenceCalculatorService()
+
+	localTimePolicy, err := valueobjects.NewLocalTimePolicy(c.Config.Recurrence.GapPolicy, c.Config.Recurrence.AmbiguityPolicy)
+	if err != nil {
+		return fmt.Errorf("invalid recurrence configuration: %w", err)
+	}
+	c.RecurrenceCalculator = services.NewRecurrenceCalculatorService(localTimePolicy)
+	return nil
 }
 
 func (c *Container) initInfrastructureServices() {
@@ -90,23 +208,95 @@ func (c *Container) initInfrastructureServices() {
 
 func (c *Container) initUseCases() {
 	c.CreateAppointmentUseCase = usecases.NewCreateAppointmentUseCase(
-		c.AppointmentRepo,
-		c.ScheduleRepo,
+		c.UnitOfWork,
 		c.NotificationGateway,
 		c.ConflictDetector,
+		c.OptimalTimeFinder,
+		c.Config.Scheduling.ConflictAlternatives,
 	)
 
 	c.UpdateAppointmentUseCase = usecases.NewUpdateAppointmentUseCase(
-		c.AppointmentRepo,
-		c.ScheduleRepo,
+		c.UnitOfWork,
+		c.NotificationGateway,
+		c.ConflictDetector,
+		c.OptimalTimeFinder,
+		c.Config.Scheduling.ConflictAlternatives,
+	)
+
+	c.RespondToAppointmentUseCase = usecases.NewRespondToAppointmentUseCase(
+		c.UnitOfWork,
+		c.NotificationGateway,
+		c.ConflictDetector,
+	)
+
+	c.ChangeAppointmentStatusUseCase = usecases.NewChangeAppointmentStatusUseCase(
+		c.UnitOfWork,
+		c.NotificationGateway,
+		c.ConflictDetector,
+	)
+
+	c.GetAppointmentUseCase = usecases.NewGetAppointmentUseCase(c.AppointmentRepo)
+	c.ListAppointmentsUseCase = usecases.NewListAppointmentsUseCase(c.AppointmentRepo)
+
+	c.CreateAppointmentSeriesUseCase = usecases.NewCreateAppointmentSeriesUseCase(
+		c.UnitOfWork,
+		c.NotificationGateway,
+		c.ConflictDetector,
+		c.RecurrenceCalculator,
+		c.TimezoneService,
+		c.Config.Recurrence.Horizon,
+	)
+
+	c.GetAppointmentSeriesUseCase = usecases.NewGetAppointmentSeriesUseCase(c.SeriesRepo, c.AppointmentRepo, c.RecurrenceCalculator)
+
+	c.UpdateAppointmentSeriesUseCase = usecases.NewUpdateAppointmentSeriesUseCase(
+		c.UnitOfWork,
+		c.NotificationGateway,
+		c.ConflictDetector,
+		c.RecurrenceCalculator,
+		c.Config.Recurrence.Horizon,
+	)
+
+	c.ExtendAppointmentSeriesUseCase = usecases.NewExtendAppointmentSeriesUseCase(
+		c.UnitOfWork,
 		c.NotificationGateway,
 		c.ConflictDetector,
+		c.RecurrenceCalculator,
+		c.Config.Recurrence.Horizon,
+	)
+
+	c.PreviewRecurrenceUseCase = usecases.NewPreviewRecurrenceUseCase(
+		c.ScheduleRepo,
+		c.ConflictDetector,
+		c.RecurrenceCalculator,
+		c.TimezoneService,
 	)
 
 	c.FindAvailableTimeSlotsUseCase = usecases.NewFindAvailableTimeSlotsUseCase(
 		c.ParticipantRepo,
 		c.OptimalTimeFinder,
+		c.RecurrenceCalculator,
 	)
+
+	c.CreateParticipantUseCase = usecases.NewCreateParticipantUseCase(c.ParticipantRepo, c.TimezoneService, c.HolidayCalendars)
+	c.GetParticipantUseCase = usecases.NewGetParticipantUseCase(c.ParticipantRepo)
+	c.UpdateParticipantUseCase = usecases.NewUpdateParticipantUseCase(c.ParticipantRepo, c.TimezoneService, c.HolidayCalendars)
+	c.DeleteParticipantUseCase = usecases.NewDeleteParticipantUseCase(c.ParticipantRepo)
+	c.AddAvailabilityUseCase = usecases.NewAddAvailabilityUseCase(c.ParticipantRepo, c.RecurrenceCalculator)
+	c.GetAvailabilityUseCase = usecases.NewGetAvailabilityUseCase(c.ParticipantRepo, c.RecurrenceCalculator)
+	c.RemoveAvailabilityUseCase = usecases.NewRemoveAvailabilityUseCase(c.ParticipantRepo, c.RecurrenceCalculator)
+
+	c.CreateScheduleUseCase = usecases.NewCreateScheduleUseCase(c.UnitOfWork, c.TimezoneService, c.HolidayCalendars)
+	c.GetScheduleUseCase = usecases.NewGetScheduleUseCase(c.ScheduleRepo)
+	c.UpdateWorkingHoursUseCase = usecases.NewUpdateWorkingHoursUseCase(c.UnitOfWork)
+	c.AddBlockedTimeUseCase = usecases.NewAddBlockedTimeUseCase(c.UnitOfWork)
+	c.ListBlockedTimesUseCase = usecases.NewListBlockedTimesUseCase(c.ScheduleRepo)
+	c.RemoveBlockedTimeUseCase = usecases.NewRemoveBlockedTimeUseCase(c.UnitOfWork)
+	c.UpdateScheduleHolidayCalendarsUseCase = usecases.NewUpdateScheduleHolidayCalendarsUseCase(c.UnitOfWork, c.HolidayCalendars)
+	c.UpdateConflictPolicyUseCase = usecases.NewUpdateConflictPolicyUseCase(c.UnitOfWork)
+
+	c.ListHolidayCalendarsUseCase = usecases.NewListHolidayCalendarsUseCase(c.HolidayCalendars)
+	c.ListHolidaysUseCase = usecases.NewListHolidaysUseCase(c.HolidayCalendars)
 }
 
 func (c *Container) initPresenters() {
@@ -118,11 +308,47 @@ func (c *Container) initControllers() {
 	c.AppointmentController = controllers.NewAppointmentController(
 		c.CreateAppointmentUseCase,
 		c.UpdateAppointmentUseCase,
+		c.RespondToAppointmentUseCase,
+		c.ChangeAppointmentStatusUseCase,
+		c.GetAppointmentUseCase,
+		c.ListAppointmentsUseCase,
+		c.AppointmentPresenter,
+	)
+
+	c.AppointmentSeriesController = controllers.NewAppointmentSeriesController(
+		c.CreateAppointmentSeriesUseCase,
+		c.GetAppointmentSeriesUseCase,
+		c.UpdateAppointmentSeriesUseCase,
+		c.AppointmentPresenter,
 	)
 
 	c.ScheduleController = controllers.NewScheduleController(
 		c.FindAvailableTimeSlotsUseCase,
+		c.CreateScheduleUseCase,
+		c.GetScheduleUseCase,
+		c.UpdateWorkingHoursUseCase,
+		c.AddBlockedTimeUseCase,
+		c.ListBlockedTimesUseCase,
+		c.RemoveBlockedTimeUseCase,
+		c.UpdateScheduleHolidayCalendarsUseCase,
+		c.UpdateConflictPolicyUseCase,
+		c.SchedulePresenter,
 	)
 
-	c.ParticipantController = controllers.NewParticipantController()
+	c.ParticipantController = controllers.NewParticipantController(
+		c.CreateParticipantUseCase,
+		c.GetParticipantUseCase,
+		c.UpdateParticipantUseCase,
+		c.DeleteParticipantUseCase,
+		c.AddAvailabilityUseCase,
+		c.GetAvailabilityUseCase,
+		c.RemoveAvailabilityUseCase,
+	)
+
+	c.RecurrenceController = controllers.NewRecurrenceController(c.PreviewRecurrenceUseCase)
+
+	c.HolidayController = controllers.NewHolidayController(
+		c.ListHolidayCalendarsUseCase,
+		c.ListHolidaysUseCase,
+	)
 }
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+// filterAppointments applies an AppointmentQuery in process for the backends
+// that cannot express it natively.
+func filterAppointments(appointments []*entities.Appointment, query usecases.AppointmentQuery) ([]*entities.Appointment, int) {
+	matched := make([]*entities.Appointment, 0)
+	for _, appointment := range appointments {
+		if matchesAppointmentQuery(appointment, query) {
+			matched = append(matched, appointment)
+		}
+	}
+	total := len(matched)
+
+	less := func(a, b *entities.Appointment) bool {
+		keyA, keyB := query.SortBy.Key(a), query.SortBy.Key(b)
+		if keyA != keyB {
+			return keyA < keyB
+		}
+		return a.ID() < b.ID()
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if query.Descending {
+			return less(matched[j], matched[i])
+		}
+		return less(matched[i], matched[j])
+	})
+
+	if query.After != nil {
+		start := sort.Search(len(matched), func(i int) bool {
+			key, id := query.SortBy.Key(matched[i]), matched[i].ID()
+			if query.Descending {
+				return key < query.After.Key || (key == query.After.Key && id < query.After.ID)
+			}
+			return key > query.After.Key || (key == query.After.Key && id > query.After.ID)
+		})
+		matched = matched[start:]
+	}
+
+	if query.Offset > 0 {
+		if query.Offset >= len(matched) {
+			return []*entities.Appointment{}, total
+		}
+		matched = matched[query.Offset:]
+	}
+
+	if query.Limit > 0 && len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, total
+}
+
+func matchesAppointmentQuery(appointment *entities.Appointment, query usecases.AppointmentQuery) bool {
+	if query.ParticipantID != "" {
+		found := false
+		for _, attendee := range appointment.AttendeeIDs() {
+			if attendee == query.ParticipantID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(query.Statuses) > 0 {
+		found := false
+		for _, status := range query.Statuses {
+			if appointment.Status() == status {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for _, tag := range query.Tags {
+		if !appointment.HasTag(tag) {
+			return false
+		}
+	}
+
+	for _, key := range query.MetadataKeys {
+		if _, ok := appointment.Metadata()[key]; !ok {
+			return false
+		}
+	}
+
+	timeRange := appointment.TimeRange()
+	if !query.From.IsZero() && !timeRange.EndTime().After(query.From) {
+		return false
+	}
+	if !query.To.IsZero() && !timeRange.StartTime().Before(query.To) {
+		return false
+	}
+
+	if query.Location != "" && !strings.EqualFold(appointment.Location(), query.Location) {
+		return false
+	}
+
+	if query.TitleSearch != "" && !strings.Contains(strings.ToLower(appointment.Title()), strings.ToLower(query.TitleSearch)) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+// attendeeRecord is the JSON form of an attendee, shared by the bolt records
+// and the postgres series attendee_details column.
+type attendeeRecord struct {
+	ParticipantID string     `json:"participant_id"`
+	Role          string     `json:"role"`
+	Response      string     `json:"response"`
+	RespondedAt   *time.Time `json:"responded_at,omitempty"`
+}
+
+// UnmarshalJSON also accepts a bare participant ID, which is how attendees
+// were stored before they had roles.
+func (r *attendeeRecord) UnmarshalJSON(data []byte) error {
+	var participantID string
+	if err := json.Unmarshal(data, &participantID); err == nil {
+		*r = attendeeRecord{
+			ParticipantID: participantID,
+			Role:          string(entities.RoleRequired),
+			Response:      string(entities.ResponseNeedsAction),
+		}
+		return nil
+	}
+
+	type plain attendeeRecord
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func newAttendeeRecords(attendees []entities.Attendee) []attendeeRecord {
+	result := make([]attendeeRecord, 0, len(attendees))
+	for _, attendee := range attendees {
+		result = append(result, attendeeRecord{
+			ParticipantID: attendee.ParticipantID(),
+			Role:          string(attendee.Role()),
+			Response:      string(attendee.Response()),
+			RespondedAt:   attendee.RespondedAt(),
+		})
+	}
+	return result
+}
+
+func toAttendees(records []attendeeRecord) []entities.Attendee {
+	result := make([]entities.Attendee, 0, len(records))
+	for _, record := range records {
+		result = append(result, entities.RestoreAttendee(
+			record.ParticipantID,
+			entities.AttendeeRole(record.Role),
+			entities.ResponseStatus(record.Response),
+			record.RespondedAt,
+		))
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/database"
+	bolt "go.etcd.io/bbolt"
+)
+
+type BoltAppointmentRepository struct {
+	boltStore
+}
+
+func NewBoltAppointmentRepository(db *bolt.DB) *BoltAppointmentRepository {
+	return &BoltAppointmentRepository{
+		boltStore: boltStore{db: db},
+	}
+}
+
+func (r *BoltAppointmentRepository) Save(appointment *entities.Appointment) error {
+	return r.update(func(tx *bolt.Tx) error {
+		return r.write(tx.Bucket(database.AppointmentsBucket), appointment)
+	})
+}
+
+func (r *BoltAppointmentRepository) FindByID(id string) (*entities.Appointment, error) {
+	var appointment *entities.Appointment
+	err := r.view(func(tx *bolt.Tx) error {
+		data := tx.Bucket(database.AppointmentsBucket).Get([]byte(id))
+		if data == nil {
+			return usecases.ErrAppointmentNotFound
+		}
+
+		var err error
+		appointment, err = decodeAppointment(data)
+		return err
+	})
+	return appointment, err
+}
+
+func (r *BoltAppointmentRepository) FindByParticipant(participantID string) ([]*entities.Appointment, error) {
+	all, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*entities.Appointment
+	for _, appointment := range all {
+		for _, attendee := range appointment.AttendeeIDs() {
+			if attendee == participantID {
+				result = append(result, appointment)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *BoltAppointmentRepository) Update(appointment *entities.Appointment) error {
+	return r.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(database.AppointmentsBucket)
+		if bucket.Get([]byte(appointment.ID())) == nil {
+			return usecases.ErrAppointmentNotFound
+		}
+		return r.write(bucket, appointment)
+	})
+}
+
+func (r *BoltAppointmentRepository) Delete(id string) error {
+	return r.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(database.AppointmentsBucket)
+		if bucket.Get([]byte(id)) == nil {
+			return usecases.ErrAppointmentNotFound
+		}
+		return bucket.Delete([]byte(id))
+	})
+}
+
+func (r *BoltAppointmentRepository) FindAll() ([]*entities.Appointment, error) {
+	result := make([]*entities.Appointment, 0)
+	err := r.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(database.AppointmentsBucket).ForEach(func(_, data []byte) error {
+			appointment, err := decodeAppointment(data)
+			if err != nil {
+				return err
+			}
+			result = append(result, appointment)
+			return nil
+		})
+	})
+	return result, err
+}
+
+// Query filters in process since bolt has no secondary indexes.
+func (r *BoltAppointmentRepository) Query(query usecases.AppointmentQuery) ([]*entities.Appointment, int, error) {
+	all, err := r.FindAll()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	page, total := filterAppointments(all, query)
+	return page, total, nil
+}
+
+// write stores the appointment if it is based on the stored version.
+func (r *BoltAppointmentRepository) write(bucket *bolt.Bucket, appointment *entities.Appointment) error {
+	current, err := storedVersion(bucket, appointment.ID())
+	if err != nil {
+		return err
+	}
+
+	if current != appointment.Version() {
+		return usecases.ErrVersionConflict
+	}
+
+	appointment.IncrementVersion()
+	return putJSON(bucket, appointment.ID(), newAppointmentRecord(appointment))
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/database"
+	bolt "go.etcd.io/bbolt"
+)
+
+type BoltAppointmentSeriesRepository struct {
+	boltStore
+}
+
+func NewBoltAppointmentSeriesRepository(db *bolt.DB) *BoltAppointmentSeriesRepository {
+	return &BoltAppointmentSeriesRepository{
+		boltStore: boltStore{db: db},
+	}
+}
+
+// Save stores the series if it is based on the stored version.
+func (r *BoltAppointmentSeriesRepository) Save(series *entities.AppointmentSeries) error {
+	return r.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(database.SeriesBucket)
+		current, err := storedVersion(bucket, series.ID())
+		if err != nil {
+			return err
+		}
+
+		if current != series.Version() {
+			return usecases.ErrVersionConflict
+		}
+
+		series.IncrementVersion()
+		return putJSON(bucket, series.ID(), newAppointmentSeriesRecord(series))
+	})
+}
+
+func (r *BoltAppointmentSeriesRepository) FindByID(id string) (*entities.AppointmentSeries, error) {
+	var series *entities.AppointmentSeries
+	err := r.view(func(tx *bolt.Tx) error {
+		data := tx.Bucket(database.SeriesBucket).Get([]byte(id))
+		if data == nil {
+			return usecases.ErrAppointmentSeriesNotFound
+		}
+
+		var record appointmentSeriesRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return err
+		}
+
+		var err error
+		series, err = record.toEntity()
+		return err
+	})
+	return series, err
+}
+
+// FindIDsToExtend filters in process since bolt has no secondary indexes.
+func (r *BoltAppointmentSeriesRepository) FindIDsToExtend(until time.Time) ([]string, error) {
+	result := make([]string, 0)
+	err := r.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(database.SeriesBucket).ForEach(func(_, data []byte) error {
+			var record appointmentSeriesRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				return err
+			}
+
+			if record.Status != string(entities.StatusCancelled) && record.Horizon.Before(until) {
+				result = append(result, record.ID)
+			}
+			return nil
+		})
+	})
+	return result, err
+}
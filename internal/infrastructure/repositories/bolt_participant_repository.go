@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/database"
+	bolt "go.etcd.io/bbolt"
+)
+
+type BoltParticipantRepository struct {
+	boltStore
+}
+
+func NewBoltParticipantRepository(db *bolt.DB) *BoltParticipantRepository {
+	return &BoltParticipantRepository{
+		boltStore: boltStore{db: db},
+	}
+}
+
+func (r *BoltParticipantRepository) FindByID(id string) (*entities.Participant, error) {
+	var participant *entities.Participant
+	err := r.view(func(tx *bolt.Tx) error {
+		data := tx.Bucket(database.ParticipantsBucket).Get([]byte(id))
+		if data == nil {
+			return usecases.ErrParticipantNotFound
+		}
+
+		var err error
+		participant, err = decodeParticipant(data)
+		return err
+	})
+	return participant, err
+}
+
+func (r *BoltParticipantRepository) FindByIDs(ids []string) ([]*entities.Participant, error) {
+	result := make([]*entities.Participant, 0, len(ids))
+	err := r.view(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(database.ParticipantsBucket)
+		for _, id := range ids {
+			data := bucket.Get([]byte(id))
+			if data == nil {
+				continue
+			}
+
+			participant, err := decodeParticipant(data)
+			if err != nil {
+				return err
+			}
+			result = append(result, participant)
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (r *BoltParticipantRepository) Save(participant *entities.Participant) error {
+	return r.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(database.ParticipantsBucket)
+		if err := checkEmailAvailable(bucket, participant); err != nil {
+			return err
+		}
+		return putJSON(bucket, participant.ID(), newParticipantRecord(participant))
+	})
+}
+
+func (r *BoltParticipantRepository) FindByEmail(email string) (*entities.Participant, error) {
+	all, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, participant := range all {
+		if participant.Email() == email {
+			return participant, nil
+		}
+	}
+	return nil, usecases.ErrParticipantNotFound
+}
+
+func (r *BoltParticipantRepository) Update(participant *entities.Participant) error {
+	return r.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(database.ParticipantsBucket)
+		if bucket.Get([]byte(participant.ID())) == nil {
+			return usecases.ErrParticipantNotFound
+		}
+		if err := checkEmailAvailable(bucket, participant); err != nil {
+			return err
+		}
+		return putJSON(bucket, participant.ID(), newParticipantRecord(participant))
+	})
+}
+
+func (r *BoltParticipantRepository) Delete(id string) error {
+	return r.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(database.ParticipantsBucket)
+		if bucket.Get([]byte(id)) == nil {
+			return usecases.ErrParticipantNotFound
+		}
+		return bucket.Delete([]byte(id))
+	})
+}
+
+func (r *BoltParticipantRepository) FindAll() ([]*entities.Participant, error) {
+	result := make([]*entities.Participant, 0)
+	err := r.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(database.ParticipantsBucket).ForEach(func(_, data []byte) error {
+			participant, err := decodeParticipant(data)
+			if err != nil {
+				return err
+			}
+			result = append(result, participant)
+			return nil
+		})
+	})
+	return result, err
+}
+
+// checkEmailAvailable mirrors the unique email constraint of the SQL schema.
+func checkEmailAvailable(bucket *bolt.Bucket, participant *entities.Participant) error {
+	return bucket.ForEach(func(key, data []byte) error {
+		if string(key) == participant.ID() {
+			return nil
+		}
+
+		var record participantRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return err
+		}
+		if record.Email == participant.Email() {
+			return fmt.Errorf("%w: %s", usecases.ErrDuplicateEmail, participant.Email())
+		}
+		return nil
+	})
+}
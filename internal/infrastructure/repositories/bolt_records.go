@@ -0,0 +1,435 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/database"
+	bolt "go.etcd.io/bbolt"
+)
+
+// The bolt repositories store each aggregate as a JSON document keyed by ID.
+// Schedules reference appointments by ID so an appointment has exactly one
+// stored copy.
+
+type timeRangeRecord struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+type appointmentRecord struct {
+	ID          string                   `json:"id"`
+	Title       string                   `json:"title"`
+	Description string                   `json:"description,omitempty"`
+	TimeRange   timeRangeRecord          `json:"time_range"`
+	Attendees   []attendeeRecord         `json:"attendees"`
+	Location    string                   `json:"location"`
+	Tags        []string                 `json:"tags,omitempty"`
+	Metadata    map[string]interface{}   `json:"metadata,omitempty"`
+	Status      string                   `json:"status"`
+	History     []statusTransitionRecord `json:"status_history,omitempty"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	Version     int                      `json:"version"`
+}
+
+type appointmentSeriesRecord struct {
+	ID         string                     `json:"id"`
+	Title      string                     `json:"title"`
+	TimeRange  timeRangeRecord            `json:"time_range"`
+	Attendees  []attendeeRecord           `json:"attendees"`
+	Location   string                     `json:"location"`
+	Rule       string                     `json:"rrule"`
+	Timezone   string                     `json:"timezone"`
+	GapPolicy  string                     `json:"dst_gap_policy,omitempty"`
+	Ambiguity  string                     `json:"dst_ambiguity_policy,omitempty"`
+	Exceptions []time.Time                `json:"exdates"`
+	Overrides  []occurrenceOverrideRecord `json:"overrides"`
+	Horizon    time.Time                  `json:"horizon"`
+	Status     string                     `json:"status"`
+	CreatedAt  time.Time                  `json:"created_at"`
+	UpdatedAt  time.Time                  `json:"updated_at"`
+	Version    int                        `json:"version"`
+}
+
+type scheduleRecord struct {
+	ID             string               `json:"id"`
+	OwnerID        string               `json:"owner_id"`
+	Timezone       string               `json:"timezone"`
+	WorkingHours   workingHoursRecord   `json:"working_hours"`
+	Holidays       []string             `json:"holiday_calendars,omitempty"`
+	AppointmentIDs []string             `json:"appointment_ids"`
+	BlockedTimes   []blockedTimeRecord  `json:"blocked_times"`
+	ConflictPolicy conflictPolicyRecord `json:"conflict_policy"`
+	Version        int                  `json:"version"`
+}
+
+// blockedTimeRecord keeps the start_time/end_time keys of the time range at
+// the top level, so records written before blocked times had IDs still decode.
+type blockedTimeRecord struct {
+	ID string `json:"id"`
+	timeRangeRecord
+	Reason string `json:"reason,omitempty"`
+}
+
+type timeSlotRecord struct {
+	ID          string          `json:"id"`
+	TimeRange   timeRangeRecord `json:"time_range"`
+	IsAvailable bool            `json:"is_available"`
+	ResourceID  string          `json:"resource_id"`
+}
+
+type availabilitySeriesRecord struct {
+	ID         string          `json:"id"`
+	TimeRange  timeRangeRecord `json:"time_range"`
+	Pattern    string          `json:"pattern"`
+	Interval   int             `json:"interval"`
+	EndDate    *time.Time      `json:"end_date,omitempty"`
+	Exceptions []time.Time     `json:"exceptions,omitempty"`
+}
+
+type participantRecord struct {
+	ID                 string                     `json:"id"`
+	Name               string                     `json:"name"`
+	Email              string                     `json:"email"`
+	Timezone           string                     `json:"timezone"`
+	Availability       []timeSlotRecord           `json:"availability"`
+	AvailabilitySeries []availabilitySeriesRecord `json:"availability_series,omitempty"`
+	Holidays           []string                   `json:"holiday_calendars,omitempty"`
+}
+
+func newTimeRangeRecord(timeRange valueobjects.TimeRange) timeRangeRecord {
+	return timeRangeRecord{
+		StartTime: timeRange.StartTime(),
+		EndTime:   timeRange.EndTime(),
+	}
+}
+
+func (r timeRangeRecord) toTimeRange() (valueobjects.TimeRange, error) {
+	return valueobjects.NewTimeRange(r.StartTime, r.EndTime)
+}
+
+func newAppointmentRecord(appointment *entities.Appointment) appointmentRecord {
+	return appointmentRecord{
+		ID:          appointment.ID(),
+		Title:       appointment.Title(),
+		Description: appointment.Description(),
+		TimeRange:   newTimeRangeRecord(appointment.TimeRange()),
+		Attendees:   newAttendeeRecords(appointment.Attendees()),
+		Location:    appointment.Location(),
+		Tags:        appointment.Tags(),
+		Metadata:    newMetadataRecord(appointment.Metadata()),
+		Status:      string(appointment.Status()),
+		History:     newStatusTransitionRecords(appointment.StatusHistory()),
+		CreatedAt:   appointment.CreatedAt(),
+		UpdatedAt:   appointment.UpdatedAt(),
+		Version:     appointment.Version(),
+	}
+}
+
+func (r appointmentRecord) toEntity() (*entities.Appointment, error) {
+	timeRange, err := r.TimeRange.toTimeRange()
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := toMetadata(r.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.RestoreAppointment(
+		r.ID,
+		r.Title,
+		r.Description,
+		timeRange,
+		toAttendees(r.Attendees),
+		r.Location,
+		restoreTags(r.Tags),
+		metadata,
+		restoreStatus(r.Status),
+		toStatusHistory(r.History),
+		r.CreatedAt,
+		r.UpdatedAt,
+		r.Version,
+	), nil
+}
+
+func newAppointmentSeriesRecord(series *entities.AppointmentSeries) appointmentSeriesRecord {
+	return appointmentSeriesRecord{
+		ID:         series.ID(),
+		Title:      series.Title(),
+		TimeRange:  newTimeRangeRecord(series.TimeRange()),
+		Attendees:  newAttendeeRecords(series.Attendees()),
+		Location:   series.Location(),
+		Rule:       series.Rule(),
+		Timezone:   series.Timezone().String(),
+		GapPolicy:  string(series.LocalTimePolicy().Gap),
+		Ambiguity:  string(series.LocalTimePolicy().Ambiguity),
+		Exceptions: series.Exceptions(),
+		Overrides:  newOccurrenceOverrideRecords(series.Overrides()),
+		Horizon:    series.Horizon(),
+		Status:     string(series.Status()),
+		CreatedAt:  series.CreatedAt(),
+		UpdatedAt:  series.UpdatedAt(),
+		Version:    series.Version(),
+	}
+}
+
+func (r appointmentSeriesRecord) toEntity() (*entities.AppointmentSeries, error) {
+	timeRange, err := r.TimeRange.toTimeRange()
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	// Series stored before the policy was recorded get the defaults
+	localTime, err := valueobjects.NewLocalTimePolicy(r.GapPolicy, r.Ambiguity)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := toOccurrenceOverrides(r.Overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	exceptions := r.Exceptions
+	if exceptions == nil {
+		exceptions = make([]time.Time, 0)
+	}
+
+	return entities.RestoreAppointmentSeries(
+		r.ID,
+		r.Title,
+		timeRange,
+		toAttendees(r.Attendees),
+		r.Location,
+		r.Rule,
+		location,
+		localTime,
+		exceptions,
+		overrides,
+		r.Horizon,
+		restoreStatus(r.Status),
+		r.CreatedAt,
+		r.UpdatedAt,
+		r.Version,
+	), nil
+}
+
+func newScheduleRecord(schedule *entities.Schedule) scheduleRecord {
+	record := scheduleRecord{
+		ID:             schedule.ID(),
+		OwnerID:        schedule.OwnerID(),
+		Timezone:       schedule.Timezone().String(),
+		WorkingHours:   newWorkingHoursRecord(schedule.WorkingHours()),
+		Holidays:       schedule.HolidayCalendars(),
+		AppointmentIDs: make([]string, 0, len(schedule.Appointments())),
+		BlockedTimes:   make([]blockedTimeRecord, 0, len(schedule.BlockedTimes())),
+		ConflictPolicy: newConflictPolicyRecord(schedule.ConflictPolicy()),
+		Version:        schedule.Version(),
+	}
+
+	for _, appointment := range schedule.Appointments() {
+		record.AppointmentIDs = append(record.AppointmentIDs, appointment.ID())
+	}
+
+	for _, blocked := range schedule.BlockedTimes() {
+		record.BlockedTimes = append(record.BlockedTimes, blockedTimeRecord{
+			ID:              blocked.ID(),
+			timeRangeRecord: newTimeRangeRecord(blocked.TimeRange()),
+			Reason:          blocked.Reason(),
+		})
+	}
+
+	return record
+}
+
+func (r scheduleRecord) toEntity(tx *bolt.Tx) (*entities.Schedule, error) {
+	location, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	workingHours, err := r.WorkingHours.toWorkingHours()
+	if err != nil {
+		return nil, err
+	}
+
+	appointments := make([]*entities.Appointment, 0, len(r.AppointmentIDs))
+	bucket := tx.Bucket(database.AppointmentsBucket)
+	for _, id := range r.AppointmentIDs {
+		data := bucket.Get([]byte(id))
+		if data == nil {
+			// The appointment was deleted after being scheduled
+			continue
+		}
+
+		appointment, err := decodeAppointment(data)
+		if err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, appointment)
+	}
+
+	blockedTimes := make([]valueobjects.BlockedTime, 0, len(r.BlockedTimes))
+	for i, blocked := range r.BlockedTimes {
+		timeRange, err := blocked.toTimeRange()
+		if err != nil {
+			return nil, err
+		}
+
+		id := blocked.ID
+		if id == "" {
+			id = uuid.NewSHA1(uuid.NameSpaceURL, []byte(fmt.Sprintf("%s/blocked-times/%d", r.ID, i))).String()
+		}
+		blockedTimes = append(blockedTimes, valueobjects.RestoreBlockedTime(id, timeRange, blocked.Reason))
+	}
+
+	return entities.RestoreSchedule(r.ID, r.OwnerID, location, workingHours, orEmpty(r.Holidays), appointments, blockedTimes, r.ConflictPolicy.toConflictPolicy(), r.Version), nil
+}
+
+func newParticipantRecord(participant *entities.Participant) participantRecord {
+	record := participantRecord{
+		ID:           participant.ID(),
+		Name:         participant.Name(),
+		Email:        participant.Email(),
+		Timezone:     participant.Timezone().String(),
+		Availability: make([]timeSlotRecord, 0, len(participant.Availability())),
+		Holidays:     participant.HolidayCalendars(),
+	}
+
+	for _, slot := range participant.Availability() {
+		record.Availability = append(record.Availability, timeSlotRecord{
+			ID:          slot.ID(),
+			TimeRange:   newTimeRangeRecord(slot.TimeRange()),
+			IsAvailable: slot.IsAvailable(),
+			ResourceID:  slot.ResourceID(),
+		})
+	}
+
+	for _, series := range participant.AvailabilitySeries() {
+		record.AvailabilitySeries = append(record.AvailabilitySeries, availabilitySeriesRecord{
+			ID:         series.ID(),
+			TimeRange:  newTimeRangeRecord(series.TimeRange()),
+			Pattern:    series.Pattern(),
+			Interval:   series.Interval(),
+			EndDate:    series.EndDate(),
+			Exceptions: series.Exceptions(),
+		})
+	}
+
+	return record
+}
+
+func (r participantRecord) toEntity() (*entities.Participant, error) {
+	location, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	availability := make([]valueobjects.TimeSlot, 0, len(r.Availability))
+	for _, slot := range r.Availability {
+		timeRange, err := slot.TimeRange.toTimeRange()
+		if err != nil {
+			return nil, err
+		}
+		availability = append(availability, valueobjects.RestoreTimeSlot(slot.ID, timeRange, slot.IsAvailable, slot.ResourceID))
+	}
+
+	series := make([]entities.AvailabilitySeries, 0, len(r.AvailabilitySeries))
+	for _, s := range r.AvailabilitySeries {
+		timeRange, err := s.TimeRange.toTimeRange()
+		if err != nil {
+			return nil, err
+		}
+
+		exceptions := s.Exceptions
+		if exceptions == nil {
+			exceptions = make([]time.Time, 0)
+		}
+		series = append(series, entities.RestoreAvailabilitySeries(s.ID, timeRange, s.Pattern, s.Interval, s.EndDate, exceptions))
+	}
+
+	return entities.RestoreParticipant(r.ID, r.Name, r.Email, location, availability, series, orEmpty(r.Holidays)), nil
+}
+
+func decodeAppointment(data []byte) (*entities.Appointment, error) {
+	var record appointmentRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	return record.toEntity()
+}
+
+func decodeParticipant(data []byte) (*entities.Participant, error) {
+	var record participantRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	return record.toEntity()
+}
+
+// boltStore runs repository operations in their own transaction, or in the
+// unit of work's transaction when tx is set.
+type boltStore struct {
+	db *bolt.DB
+	tx *bolt.Tx
+}
+
+func (s boltStore) view(fn func(tx *bolt.Tx) error) error {
+	if s.tx != nil {
+		return fn(s.tx)
+	}
+	return s.db.View(fn)
+}
+
+func (s boltStore) update(fn func(tx *bolt.Tx) error) error {
+	if s.tx != nil {
+		return fn(s.tx)
+	}
+	return s.db.Update(fn)
+}
+
+// storedVersion reads the version of the JSON document stored under id, or
+// 0 when there is none.
+func storedVersion(bucket *bolt.Bucket, id string) (int, error) {
+	data := bucket.Get([]byte(id))
+	if data == nil {
+		return 0, nil
+	}
+
+	var record struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return 0, err
+	}
+	return record.Version, nil
+}
+
+func putJSON(bucket *bolt.Bucket, id string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(id), data)
+}
+
+// orEmpty keeps optional lists non-nil for records written before the field
+// existed.
+func orEmpty(values []string) []string {
+	if values == nil {
+		return make([]string, 0)
+	}
+	return values
+}
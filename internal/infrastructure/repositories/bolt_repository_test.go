@@ -0,0 +1,325 @@
+package repositories_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/database"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/repositories"
+	bolt "go.etcd.io/bbolt"
+)
+
+// openBoltTestDB opens a fresh bolt database in a temporary directory.
+func openBoltTestDB(t *testing.T) *bolt.DB {
+	t.Helper()
+
+	db, err := database.OpenBolt(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestBoltParticipantRepository(t *testing.T) {
+	repo := repositories.NewBoltParticipantRepository(openBoltTestDB(t))
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	start := time.Date(2030, 3, 18, 9, 0, 0, 0, berlin)
+
+	participant, err := entities.NewParticipant("Alice", "alice@example.com", berlin)
+	require.NoError(t, err)
+	participant.SetHolidayCalendars([]string{"DE"})
+	slot := valueobjects.NewTimeSlot(mustTimeRange(t, start, time.Hour), true, "")
+	participant.AddAvailability(slot)
+	series, err := entities.NewAvailabilitySeries(mustTimeRange(t, start.Add(2*time.Hour), 2*time.Hour), "weekly", 2, nil)
+	require.NoError(t, err)
+	participant.AddAvailabilitySeries(series)
+
+	require.NoError(t, repo.Save(participant))
+
+	stored, err := repo.FindByID(participant.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", stored.Name())
+	assert.Equal(t, "alice@example.com", stored.Email())
+	assert.Equal(t, "Europe/Berlin", stored.Timezone().String())
+	assert.Equal(t, []string{"DE"}, stored.HolidayCalendars())
+
+	require.Len(t, stored.Availability(), 1)
+	assert.Equal(t, slot.ID(), stored.Availability()[0].ID())
+	assert.True(t, start.Equal(stored.Availability()[0].TimeRange().StartTime()))
+
+	require.Len(t, stored.AvailabilitySeries(), 1)
+	assert.Equal(t, series.ID(), stored.AvailabilitySeries()[0].ID())
+	assert.Equal(t, "weekly", stored.AvailabilitySeries()[0].Pattern())
+	assert.Equal(t, 2, stored.AvailabilitySeries()[0].Interval())
+
+	byEmail, err := repo.FindByEmail("alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, participant.ID(), byEmail.ID())
+
+	require.NoError(t, repo.Delete(participant.ID()))
+	_, err = repo.FindByID(participant.ID())
+	assert.ErrorIs(t, err, usecases.ErrParticipantNotFound)
+}
+
+func TestBoltAppointmentRepository(t *testing.T) {
+	repo := repositories.NewBoltAppointmentRepository(openBoltTestDB(t))
+
+	start := time.Date(2030, 3, 18, 9, 0, 0, 0, time.UTC)
+	ownerID, optionalID := uuid.NewString(), uuid.NewString()
+	organizer, err := entities.NewAttendee(ownerID, entities.RoleOrganizer)
+	require.NoError(t, err)
+	optional, err := entities.NewAttendee(optionalID, entities.RoleOptional)
+	require.NoError(t, err)
+
+	appointment, err := entities.NewAppointment("Review", mustTimeRange(t, start, time.Hour), []entities.Attendee{organizer, optional}, "Room 1")
+	require.NoError(t, err)
+	require.NoError(t, appointment.Describe("Quarterly numbers"))
+	require.NoError(t, appointment.SetTags([]string{"finance", "quarterly"}))
+	ticket, err := valueobjects.NewStringMetadata("FIN-12")
+	require.NoError(t, err)
+	require.NoError(t, appointment.SetMetadata(valueobjects.Metadata{
+		"ticket":   ticket,
+		"priority": valueobjects.NewNumberMetadata(2),
+		"billable": valueobjects.NewBooleanMetadata(true),
+	}))
+	require.NoError(t, appointment.Respond(optionalID, entities.ResponseDeclined))
+	require.NoError(t, appointment.Cancel(ownerID, "Moved"))
+	require.NoError(t, appointment.Reopen(ownerID, "Back on"))
+
+	require.NoError(t, repo.Save(appointment))
+
+	stored, err := repo.FindByID(appointment.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Review", stored.Title())
+	assert.Equal(t, "Quarterly numbers", stored.Description())
+	assert.Equal(t, "Room 1", stored.Location())
+	assert.True(t, start.Equal(stored.TimeRange().StartTime()))
+	assert.Equal(t, []string{"finance", "quarterly"}, stored.Tags())
+	assert.True(t, appointment.Metadata().Equal(stored.Metadata()))
+	assert.Equal(t, appointment.Status(), stored.Status())
+	assert.Equal(t, appointment.Version(), stored.Version())
+
+	require.Len(t, stored.Attendees(), 2)
+	assert.Equal(t, ownerID, stored.OrganizerID())
+	storedOptional, ok := stored.Attendee(optionalID)
+	require.True(t, ok)
+	assert.Equal(t, entities.RoleOptional, storedOptional.Role())
+	assert.Equal(t, entities.ResponseDeclined, storedOptional.Response())
+	assert.NotNil(t, storedOptional.RespondedAt())
+
+	require.Len(t, stored.StatusHistory(), 2)
+	for i, transition := range appointment.StatusHistory() {
+		assert.Equal(t, transition.From(), stored.StatusHistory()[i].From())
+		assert.Equal(t, transition.To(), stored.StatusHistory()[i].To())
+		assert.Equal(t, transition.Reason(), stored.StatusHistory()[i].Reason())
+	}
+
+	// A copy loaded before another save is stale
+	stale, err := repo.FindByID(appointment.ID())
+	require.NoError(t, err)
+	stored.Reschedule(mustTimeRange(t, start.Add(time.Hour), time.Hour))
+	require.NoError(t, repo.Save(stored))
+	assert.ErrorIs(t, repo.Save(stale), usecases.ErrVersionConflict)
+
+	byParticipant, err := repo.FindByParticipant(optionalID)
+	require.NoError(t, err)
+	require.Len(t, byParticipant, 1)
+	assert.True(t, start.Add(time.Hour).Equal(byParticipant[0].TimeRange().StartTime()))
+
+	require.NoError(t, repo.Delete(appointment.ID()))
+	_, err = repo.FindByID(appointment.ID())
+	assert.ErrorIs(t, err, usecases.ErrAppointmentNotFound)
+	assert.ErrorIs(t, repo.Delete(appointment.ID()), usecases.ErrAppointmentNotFound)
+}
+
+func TestBoltScheduleRepository(t *testing.T) {
+	db := openBoltTestDB(t)
+	appointments := repositories.NewBoltAppointmentRepository(db)
+	repo := repositories.NewBoltScheduleRepository(db)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	start := time.Date(2030, 3, 18, 9, 0, 0, 0, berlin)
+	ownerID := uuid.NewString()
+
+	workingHours, err := valueobjects.DefaultWorkingHours().WithOverride("2030-03-19", nil)
+	require.NoError(t, err)
+	schedule, err := entities.NewSchedule(ownerID, berlin, workingHours)
+	require.NoError(t, err)
+	schedule.SetHolidayCalendars([]string{"DE", "US"})
+	policy := entities.ConflictPolicy{
+		PreBuffer:         10 * time.Minute,
+		PostBuffer:        5 * time.Minute,
+		OverlapTolerance:  time.Minute,
+		MaxConcurrent:     2,
+		BusyStatuses:      []entities.AppointmentStatus{entities.StatusConfirmed},
+		BusyResponses:     []entities.ResponseStatus{entities.ResponseAccepted},
+		MajorThreshold:    0.5,
+		CriticalThreshold: 0.9,
+	}
+	require.NoError(t, schedule.SetConflictPolicy(policy))
+	blocked := schedule.AddBlockedTime(mustTimeRange(t, start.Add(3*time.Hour), time.Hour), "Dentist")
+	appointment, err := entities.NewAppointment("Review", mustTimeRange(t, start, time.Hour), entities.RequiredAttendees([]string{ownerID}), "")
+	require.NoError(t, err)
+	require.NoError(t, appointments.Save(appointment))
+	require.NoError(t, schedule.AddAppointment(appointment))
+
+	require.NoError(t, repo.Save(schedule))
+
+	stored, err := repo.FindByOwnerID(ownerID)
+	require.NoError(t, err)
+	assert.Equal(t, schedule.ID(), stored.ID())
+	assert.Equal(t, "Europe/Berlin", stored.Timezone().String())
+	assert.Equal(t, workingHours.Weekly(), stored.WorkingHours().Weekly())
+	assert.Equal(t, workingHours.Overrides(), stored.WorkingHours().Overrides())
+	assert.Equal(t, []string{"DE", "US"}, stored.HolidayCalendars())
+	assert.Equal(t, policy, stored.ConflictPolicy())
+
+	require.Len(t, stored.BlockedTimes(), 1)
+	assert.Equal(t, blocked.ID(), stored.BlockedTimes()[0].ID())
+	assert.Equal(t, "Dentist", stored.BlockedTimes()[0].Reason())
+	require.Len(t, stored.Appointments(), 1)
+	assert.Equal(t, appointment.ID(), stored.Appointments()[0].ID())
+
+	stale, err := repo.FindByID(schedule.ID())
+	require.NoError(t, err)
+	require.NoError(t, stored.RemoveBlockedTime(blocked.ID()))
+	require.NoError(t, repo.Save(stored))
+	assert.ErrorIs(t, repo.Save(stale), usecases.ErrVersionConflict)
+
+	stored, err = repo.FindByOwnerID(ownerID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.BlockedTimes())
+
+	require.NoError(t, repo.Delete(schedule.ID()))
+	_, err = repo.FindByOwnerID(ownerID)
+	assert.ErrorIs(t, err, usecases.ErrScheduleNotFound)
+}
+
+func TestBoltScheduleRepositoryDefaultsLegacyConflictPolicy(t *testing.T) {
+	db := openBoltTestDB(t)
+	repo := repositories.NewBoltScheduleRepository(db)
+
+	// A record from before schedules had conflict policies
+	legacy := `{"id":"schedule-1","owner_id":"alice","timezone":"UTC","working_hours":{},"appointment_ids":[],"blocked_times":[],"version":1}`
+	require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(database.SchedulesBucket).Put([]byte("schedule-1"), []byte(legacy))
+	}))
+
+	stored, err := repo.FindByID("schedule-1")
+	require.NoError(t, err)
+	assert.Equal(t, entities.DefaultConflictPolicy(), stored.ConflictPolicy())
+
+	// Once saved the policy is stored, even if it has no busy statuses
+	policy := entities.DefaultConflictPolicy()
+	policy.BusyStatuses = []entities.AppointmentStatus{}
+	require.NoError(t, stored.SetConflictPolicy(policy))
+	require.NoError(t, repo.Save(stored))
+
+	stored, err = repo.FindByID("schedule-1")
+	require.NoError(t, err)
+	assert.Empty(t, stored.ConflictPolicy().BusyStatuses)
+}
+
+func TestBoltAppointmentSeriesRepository(t *testing.T) {
+	repo := repositories.NewBoltAppointmentSeriesRepository(openBoltTestDB(t))
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	start := time.Date(2030, 3, 4, 9, 0, 0, 0, newYork)
+	localTime := valueobjects.LocalTimePolicy{Gap: valueobjects.GapSkip, Ambiguity: valueobjects.AmbiguityLater}
+	organizer, err := entities.NewAttendee(uuid.NewString(), entities.RoleOrganizer)
+	require.NoError(t, err)
+	optional, err := entities.NewAttendee(uuid.NewString(), entities.RoleOptional)
+	require.NoError(t, err)
+
+	series, err := entities.NewAppointmentSeries("Standup", mustTimeRange(t, start, 15*time.Minute), []entities.Attendee{organizer, optional}, "Room 1", "FREQ=DAILY", newYork, localTime)
+	require.NoError(t, err)
+	series.SetHorizon(start.AddDate(0, 0, 7))
+	series.Exclude(start.AddDate(0, 0, 1))
+	title := "Planning"
+	series.SetOverride(entities.NewOccurrenceOverride(start.AddDate(0, 0, 2), nil, &title, nil))
+	require.NoError(t, repo.Save(series))
+
+	stored, err := repo.FindByID(series.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Standup", stored.Title())
+	assert.Equal(t, "Room 1", stored.Location())
+	assert.Equal(t, "FREQ=DAILY", stored.Rule())
+	assert.Equal(t, "America/New_York", stored.Timezone().String())
+	assert.Equal(t, localTime, stored.LocalTimePolicy())
+	assert.True(t, start.AddDate(0, 0, 7).Equal(stored.Horizon()))
+	assert.True(t, stored.IsExcluded(start.AddDate(0, 0, 1)))
+	override, ok := stored.Override(start.AddDate(0, 0, 2))
+	require.True(t, ok)
+	require.NotNil(t, override.Title())
+	assert.Equal(t, "Planning", *override.Title())
+	assert.Nil(t, override.TimeRange())
+
+	require.Len(t, stored.Attendees(), 2)
+	assert.Equal(t, entities.RoleOrganizer, stored.Attendees()[0].Role())
+	assert.Equal(t, entities.RoleOptional, stored.Attendees()[1].Role())
+
+	stale, err := repo.FindByID(series.ID())
+	require.NoError(t, err)
+	require.NoError(t, stored.Retitle("Daily standup"))
+	require.NoError(t, repo.Save(stored))
+	assert.ErrorIs(t, repo.Save(stale), usecases.ErrVersionConflict)
+
+	ids, err := repo.FindIDsToExtend(start.AddDate(0, 0, 8))
+	require.NoError(t, err)
+	assert.Equal(t, []string{series.ID()}, ids)
+
+	ids, err = repo.FindIDsToExtend(start.AddDate(0, 0, 6))
+	require.NoError(t, err)
+	assert.Empty(t, ids, "the horizon is far enough")
+
+	stored.Cancel()
+	require.NoError(t, repo.Save(stored))
+	ids, err = repo.FindIDsToExtend(start.AddDate(0, 0, 8))
+	require.NoError(t, err)
+	assert.Empty(t, ids, "cancelled series aren't extended")
+
+	_, err = repo.FindByID(uuid.NewString())
+	assert.ErrorIs(t, err, usecases.ErrAppointmentSeriesNotFound)
+}
+
+func TestBoltUnitOfWorkRollsBack(t *testing.T) {
+	db := openBoltTestDB(t)
+	appointments := repositories.NewBoltAppointmentRepository(db)
+	schedules := repositories.NewBoltScheduleRepository(db)
+
+	ownerID := uuid.NewString()
+	appointment, err := entities.NewAppointment("Review", mustTimeRange(t, time.Date(2030, 3, 18, 9, 0, 0, 0, time.UTC), time.Hour), entities.RequiredAttendees([]string{ownerID}), "")
+	require.NoError(t, err)
+	schedule, err := entities.NewSchedule(ownerID, time.UTC, valueobjects.DefaultWorkingHours())
+	require.NoError(t, err)
+	require.NoError(t, schedules.Save(schedule))
+
+	failure := errors.New("rolled back")
+	err = repositories.NewBoltUnitOfWork(db).Do(func(repos usecases.TxRepositories) error {
+		require.NoError(t, repos.Appointments.Save(appointment))
+		stored, err := repos.Schedules.FindByOwnerID(ownerID)
+		require.NoError(t, err)
+		require.NoError(t, stored.AddAppointment(appointment))
+		require.NoError(t, repos.Schedules.Save(stored))
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	_, err = appointments.FindByID(appointment.ID())
+	assert.ErrorIs(t, err, usecases.ErrAppointmentNotFound)
+	stored, err := schedules.FindByOwnerID(ownerID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.Appointments())
+	assert.Equal(t, schedule.Version(), stored.Version())
+}
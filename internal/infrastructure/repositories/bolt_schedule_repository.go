@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"encoding/json"
+
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/database"
+	bolt "go.etcd.io/bbolt"
+)
+
+type BoltScheduleRepository struct {
+	boltStore
+}
+
+func NewBoltScheduleRepository(db *bolt.DB) *BoltScheduleRepository {
+	return &BoltScheduleRepository{
+		boltStore: boltStore{db: db},
+	}
+}
+
+func (r *BoltScheduleRepository) FindByOwnerID(ownerID string) (*entities.Schedule, error) {
+	var schedule *entities.Schedule
+	err := r.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(database.SchedulesBucket).ForEach(func(_, data []byte) error {
+			if schedule != nil {
+				return nil
+			}
+
+			var record scheduleRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				return err
+			}
+			if record.OwnerID != ownerID {
+				return nil
+			}
+
+			var err error
+			schedule, err = record.toEntity(tx)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if schedule == nil {
+		return nil, usecases.ErrScheduleNotFound
+	}
+	return schedule, nil
+}
+
+func (r *BoltScheduleRepository) Save(schedule *entities.Schedule) error {
+	return r.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(database.SchedulesBucket)
+		current, err := storedVersion(bucket, schedule.ID())
+		if err != nil {
+			return err
+		}
+
+		if current != schedule.Version() {
+			return usecases.ErrVersionConflict
+		}
+
+		schedule.IncrementVersion()
+		return putJSON(bucket, schedule.ID(), newScheduleRecord(schedule))
+	})
+}
+
+func (r *BoltScheduleRepository) FindByID(id string) (*entities.Schedule, error) {
+	var schedule *entities.Schedule
+	err := r.view(func(tx *bolt.Tx) error {
+		data := tx.Bucket(database.SchedulesBucket).Get([]byte(id))
+		if data == nil {
+			return usecases.ErrScheduleNotFound
+		}
+
+		var record scheduleRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return err
+		}
+
+		var err error
+		schedule, err = record.toEntity(tx)
+		return err
+	})
+	return schedule, err
+}
+
+func (r *BoltScheduleRepository) Delete(id string) error {
+	return r.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(database.SchedulesBucket)
+		if bucket.Get([]byte(id)) == nil {
+			return usecases.ErrScheduleNotFound
+		}
+		return bucket.Delete([]byte(id))
+	})
+}
+
+func (r *BoltScheduleRepository) FindAll() ([]*entities.Schedule, error) {
+	result := make([]*entities.Schedule, 0)
+	err := r.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(database.SchedulesBucket).ForEach(func(_, data []byte) error {
+			var record scheduleRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				return err
+			}
+
+			schedule, err := record.toEntity(tx)
+			if err != nil {
+				return err
+			}
+			result = append(result, schedule)
+			return nil
+		})
+	})
+	return result, err
+}
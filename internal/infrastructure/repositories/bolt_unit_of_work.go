@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	bolt "go.etcd.io/bbolt"
+)
+
+type BoltUnitOfWork struct {
+	db *bolt.DB
+}
+
+func NewBoltUnitOfWork(db *bolt.DB) *BoltUnitOfWork {
+	return &BoltUnitOfWork{
+		db: db,
+	}
+}
+
+func (u *BoltUnitOfWork) Do(fn func(repos usecases.TxRepositories) error) error {
+	return u.db.Update(func(tx *bolt.Tx) error {
+		store := boltStore{db: u.db, tx: tx}
+		return fn(usecases.TxRepositories{
+			Appointments: &BoltAppointmentRepository{boltStore: store},
+			Schedules:    &BoltScheduleRepository{boltStore: store},
+			Series:       &BoltAppointmentSeriesRepository{boltStore: store},
+		})
+	})
+}
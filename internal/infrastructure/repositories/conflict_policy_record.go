@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+// conflictPolicyRecord is the JSON form of a schedule's conflict policy,
+// shared by the bolt records and the postgres conflict_policy column.
+// Durations are in nanoseconds. Stored marks a record written from a
+// policy, as opposed to the empty one of a schedule stored before schedules
+// had policies.
+type conflictPolicyRecord struct {
+	Stored            bool          `json:"stored,omitempty"`
+	PreBuffer         time.Duration `json:"pre_buffer,omitempty"`
+	PostBuffer        time.Duration `json:"post_buffer,omitempty"`
+	OverlapTolerance  time.Duration `json:"overlap_tolerance,omitempty"`
+	MaxConcurrent     int           `json:"max_concurrent,omitempty"`
+	BusyStatuses      []string      `json:"busy_statuses"`
+	BusyResponses     []string      `json:"busy_responses"`
+	MajorThreshold    float64       `json:"major_threshold,omitempty"`
+	CriticalThreshold float64       `json:"critical_threshold,omitempty"`
+}
+
+func newConflictPolicyRecord(policy entities.ConflictPolicy) conflictPolicyRecord {
+	record := conflictPolicyRecord{
+		Stored:            true,
+		PreBuffer:         policy.PreBuffer,
+		PostBuffer:        policy.PostBuffer,
+		OverlapTolerance:  policy.OverlapTolerance,
+		MaxConcurrent:     policy.MaxConcurrent,
+		BusyStatuses:      make([]string, 0, len(policy.BusyStatuses)),
+		BusyResponses:     make([]string, 0, len(policy.BusyResponses)),
+		MajorThreshold:    policy.MajorThreshold,
+		CriticalThreshold: policy.CriticalThreshold,
+	}
+
+	for _, status := range policy.BusyStatuses {
+		record.BusyStatuses = append(record.BusyStatuses, string(status))
+	}
+	for _, response := range policy.BusyResponses {
+		record.BusyResponses = append(record.BusyResponses, string(response))
+	}
+	return record
+}
+
+// toConflictPolicy falls back to the default policy for records written
+// before schedules had one.
+func (r conflictPolicyRecord) toConflictPolicy() entities.ConflictPolicy {
+	if !r.Stored {
+		return entities.DefaultConflictPolicy()
+	}
+
+	policy := entities.ConflictPolicy{
+		PreBuffer:         r.PreBuffer,
+		PostBuffer:        r.PostBuffer,
+		OverlapTolerance:  r.OverlapTolerance,
+		MaxConcurrent:     r.MaxConcurrent,
+		BusyStatuses:      make([]entities.AppointmentStatus, 0, len(r.BusyStatuses)),
+		BusyResponses:     make([]entities.ResponseStatus, 0, len(r.BusyResponses)),
+		MajorThreshold:    r.MajorThreshold,
+		CriticalThreshold: r.CriticalThreshold,
+	}
+
+	for _, status := range r.BusyStatuses {
+		policy.BusyStatuses = append(policy.BusyStatuses, entities.AppointmentStatus(status))
+	}
+	for _, response := range r.BusyResponses {
+		policy.BusyResponses = append(policy.BusyResponses, entities.ResponseStatus(response))
+	}
+	return policy
+}
@@ -1,20 +1,30 @@
 package repositories
 
 import (
-	"errors"
 	"sync"
 
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
 	"github.com/visiab/appointment-calculator/internal/domain/entities"
 )
 
 type MemoryAppointmentRepository struct {
 	appointments map[string]*entities.Appointment
-	mu           sync.RWMutex
+	mu           *sync.RWMutex
+	journal      *memoryJournal
 }
 
 func NewMemoryAppointmentRepository() *MemoryAppointmentRepository {
 	return &MemoryAppointmentRepository{
 		appointments: make(map[string]*entities.Appointment),
+		mu:           &sync.RWMutex{},
+	}
+}
+
+func (r *MemoryAppointmentRepository) withJournal(journal *memoryJournal) *MemoryAppointmentRepository {
+	return &MemoryAppointmentRepository{
+		appointments: r.appointments,
+		mu:           r.mu,
+		journal:      journal,
 	}
 }
 
@@ -22,8 +32,7 @@ func (r *MemoryAppointmentRepository) Save(appointment *entities.Appointment) er
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
-	r.appointments[appointment.ID()] = appointment
-	return nil
+	return r.write(appointment)
 }
 
 func (r *MemoryAppointmentRepository) FindByID(id string) (*entities.Appointment, error) {
@@ -32,9 +41,9 @@ func (r *MemoryAppointmentRepository) FindByID(id string) (*entities.Appointment
 	
 	appointment, exists := r.appointments[id]
 	if !exists {
-		return nil, errors.New("appointment not found")
+		return nil, usecases.ErrAppointmentNotFound
 	}
-	return appointment, nil
+	return cloneAppointment(appointment), nil
 }
 
 func (r *MemoryAppointmentRepository) FindByParticipant(participantID string) ([]*entities.Appointment, error) {
@@ -43,9 +52,9 @@ func (r *MemoryAppointmentRepository) FindByParticipant(participantID string) ([
 	
 	var result []*entities.Appointment
 	for _, appointment := range r.appointments {
-		for _, attendee := range appointment.Attendees() {
+		for _, attendee := range appointment.AttendeeIDs() {
 			if attendee == participantID {
-				result = append(result, appointment)
+				result = append(result, cloneAppointment(appointment))
 				break
 			}
 		}
@@ -58,11 +67,10 @@ func (r *MemoryAppointmentRepository) Update(appointment *entities.Appointment)
 	defer r.mu.Unlock()
 	
 	if _, exists := r.appointments[appointment.ID()]; !exists {
-		return errors.New("appointment not found")
+		return usecases.ErrAppointmentNotFound
 	}
 	
-	r.appointments[appointment.ID()] = appointment
-	return nil
+	return r.write(appointment)
 }
 
 func (r *MemoryAppointmentRepository) Delete(id string) error {
@@ -70,10 +78,10 @@ func (r *MemoryAppointmentRepository) Delete(id string) error {
 	defer r.mu.Unlock()
 	
 	if _, exists := r.appointments[id]; !exists {
-		return errors.New("appointment not found")
+		return usecases.ErrAppointmentNotFound
 	}
 	
-	delete(r.appointments, id)
+	r.put(id, nil)
 	return nil
 }
 
@@ -83,7 +91,77 @@ func (r *MemoryAppointmentRepository) FindAll() ([]*entities.Appointment, error)
 	
 	result := make([]*entities.Appointment, 0, len(r.appointments))
 	for _, appointment := range r.appointments {
-		result = append(result, appointment)
+		result = append(result, cloneAppointment(appointment))
 	}
 	return result, nil
 }
+
+func (r *MemoryAppointmentRepository) Query(query usecases.AppointmentQuery) ([]*entities.Appointment, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make([]*entities.Appointment, 0, len(r.appointments))
+	for _, appointment := range r.appointments {
+		all = append(all, appointment)
+	}
+
+	page, total := filterAppointments(all, query)
+	result := make([]*entities.Appointment, len(page))
+	for i, appointment := range page {
+		result[i] = cloneAppointment(appointment)
+	}
+	return result, total, nil
+}
+
+// write stores the appointment if it is based on the stored version.
+// Callers must hold the write lock.
+func (r *MemoryAppointmentRepository) write(appointment *entities.Appointment) error {
+	current := 0
+	if stored, exists := r.appointments[appointment.ID()]; exists {
+		current = stored.Version()
+	}
+
+	if current != appointment.Version() {
+		return usecases.ErrVersionConflict
+	}
+
+	appointment.IncrementVersion()
+	r.put(appointment.ID(), cloneAppointment(appointment))
+	return nil
+}
+
+// put stores or, when appointment is nil, deletes an entry. Callers must
+// hold the write lock.
+func (r *MemoryAppointmentRepository) put(id string, appointment *entities.Appointment) {
+	previous, existed := r.appointments[id]
+	r.journal.record(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if existed {
+			r.appointments[id] = previous
+		} else {
+			delete(r.appointments, id)
+		}
+	})
+
+	if appointment == nil {
+		delete(r.appointments, id)
+		return
+	}
+	r.appointments[id] = appointment
+}
+
+// findByIDs returns copies of the stored appointments, skipping IDs that no
+// longer exist.
+func (r *MemoryAppointmentRepository) findByIDs(ids []string) []*entities.Appointment {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entities.Appointment, 0, len(ids))
+	for _, id := range ids {
+		if appointment, exists := r.appointments[id]; exists {
+			result = append(result, cloneAppointment(appointment))
+		}
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+type MemoryAppointmentSeriesRepository struct {
+	series  map[string]*entities.AppointmentSeries
+	mu      *sync.RWMutex
+	journal *memoryJournal
+}
+
+func NewMemoryAppointmentSeriesRepository() *MemoryAppointmentSeriesRepository {
+	return &MemoryAppointmentSeriesRepository{
+		series: make(map[string]*entities.AppointmentSeries),
+		mu:     &sync.RWMutex{},
+	}
+}
+
+func (r *MemoryAppointmentSeriesRepository) withJournal(journal *memoryJournal) *MemoryAppointmentSeriesRepository {
+	return &MemoryAppointmentSeriesRepository{
+		series:  r.series,
+		mu:      r.mu,
+		journal: journal,
+	}
+}
+
+// Save stores the series if it is based on the stored version.
+func (r *MemoryAppointmentSeriesRepository) Save(series *entities.AppointmentSeries) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := 0
+	if stored, exists := r.series[series.ID()]; exists {
+		current = stored.Version()
+	}
+
+	if current != series.Version() {
+		return usecases.ErrVersionConflict
+	}
+
+	series.IncrementVersion()
+
+	previous, existed := r.series[series.ID()]
+	r.journal.record(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if existed {
+			r.series[series.ID()] = previous
+		} else {
+			delete(r.series, series.ID())
+		}
+	})
+
+	r.series[series.ID()] = cloneAppointmentSeries(series)
+	return nil
+}
+
+func (r *MemoryAppointmentSeriesRepository) FindByID(id string) (*entities.AppointmentSeries, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	series, exists := r.series[id]
+	if !exists {
+		return nil, usecases.ErrAppointmentSeriesNotFound
+	}
+	return cloneAppointmentSeries(series), nil
+}
+
+func (r *MemoryAppointmentSeriesRepository) FindIDsToExtend(until time.Time) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]string, 0)
+	for id, series := range r.series {
+		if series.Status() != entities.StatusCancelled && series.Horizon().Before(until) {
+			result = append(result, id)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func cloneAppointmentSeries(series *entities.AppointmentSeries) *entities.AppointmentSeries {
+	return entities.RestoreAppointmentSeries(
+		series.ID(),
+		series.Title(),
+		series.TimeRange(),
+		append([]entities.Attendee(nil), series.Attendees()...),
+		series.Location(),
+		series.Rule(),
+		series.Timezone(),
+		series.LocalTimePolicy(),
+		append(make([]time.Time, 0, len(series.Exceptions())), series.Exceptions()...),
+		append(make([]entities.OccurrenceOverride, 0, len(series.Overrides())), series.Overrides()...),
+		series.Horizon(),
+		series.Status(),
+		series.CreatedAt(),
+		series.UpdatedAt(),
+		series.Version(),
+	)
+}
@@ -1,10 +1,13 @@
 package repositories
 
 import (
-	"errors"
+	"fmt"
 	"sync"
+	"time"
 
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
 	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 )
 
 type MemoryParticipantRepository struct {
@@ -24,9 +27,9 @@ func (r *MemoryParticipantRepository) FindByID(id string) (*entities.Participant
 	
 	participant, exists := r.participants[id]
 	if !exists {
-		return nil, errors.New("participant not found")
+		return nil, usecases.ErrParticipantNotFound
 	}
-	return participant, nil
+	return cloneParticipant(participant), nil
 }
 
 func (r *MemoryParticipantRepository) FindByIDs(ids []string) ([]*entities.Participant, error) {
@@ -36,7 +39,7 @@ func (r *MemoryParticipantRepository) FindByIDs(ids []string) ([]*entities.Parti
 	result := make([]*entities.Participant, 0, len(ids))
 	for _, id := range ids {
 		if participant, exists := r.participants[id]; exists {
-			result = append(result, participant)
+			result = append(result, cloneParticipant(participant))
 		}
 	}
 	return result, nil
@@ -45,8 +48,12 @@ func (r *MemoryParticipantRepository) FindByIDs(ids []string) ([]*entities.Parti
 func (r *MemoryParticipantRepository) Save(participant *entities.Participant) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	
-	r.participants[participant.ID()] = participant
+
+	if err := r.checkEmailAvailable(participant); err != nil {
+		return err
+	}
+
+	r.participants[participant.ID()] = cloneParticipant(participant)
 	return nil
 }
 
@@ -56,10 +63,10 @@ func (r *MemoryParticipantRepository) FindByEmail(email string) (*entities.Parti
 	
 	for _, participant := range r.participants {
 		if participant.Email() == email {
-			return participant, nil
+			return cloneParticipant(participant), nil
 		}
 	}
-	return nil, errors.New("participant not found")
+	return nil, usecases.ErrParticipantNotFound
 }
 
 func (r *MemoryParticipantRepository) Update(participant *entities.Participant) error {
@@ -67,10 +74,14 @@ func (r *MemoryParticipantRepository) Update(participant *entities.Participant)
 	defer r.mu.Unlock()
 	
 	if _, exists := r.participants[participant.ID()]; !exists {
-		return errors.New("participant not found")
+		return usecases.ErrParticipantNotFound
 	}
-	
-	r.participants[participant.ID()] = participant
+
+	if err := r.checkEmailAvailable(participant); err != nil {
+		return err
+	}
+
+	r.participants[participant.ID()] = cloneParticipant(participant)
 	return nil
 }
 
@@ -79,7 +90,7 @@ func (r *MemoryParticipantRepository) Delete(id string) error {
 	defer r.mu.Unlock()
 	
 	if _, exists := r.participants[id]; !exists {
-		return errors.New("participant not found")
+		return usecases.ErrParticipantNotFound
 	}
 	
 	delete(r.participants, id)
@@ -92,7 +103,44 @@ func (r *MemoryParticipantRepository) FindAll() ([]*entities.Participant, error)
 	
 	result := make([]*entities.Participant, 0, len(r.participants))
 	for _, participant := range r.participants {
-		result = append(result, participant)
+		result = append(result, cloneParticipant(participant))
 	}
 	return result, nil
 }
+
+// checkEmailAvailable mirrors the unique email constraint of the SQL schema.
+func (r *MemoryParticipantRepository) checkEmailAvailable(participant *entities.Participant) error {
+	for id, existing := range r.participants {
+		if id != participant.ID() && existing.Email() == participant.Email() {
+			return fmt.Errorf("%w: %s", usecases.ErrDuplicateEmail, participant.Email())
+		}
+	}
+	return nil
+}
+
+func cloneParticipant(participant *entities.Participant) *entities.Participant {
+	return entities.RestoreParticipant(
+		participant.ID(),
+		participant.Name(),
+		participant.Email(),
+		participant.Timezone(),
+		append([]valueobjects.TimeSlot(nil), participant.Availability()...),
+		cloneAvailabilitySeries(participant.AvailabilitySeries()),
+		append([]string(nil), participant.HolidayCalendars()...),
+	)
+}
+
+func cloneAvailabilitySeries(series []entities.AvailabilitySeries) []entities.AvailabilitySeries {
+	result := make([]entities.AvailabilitySeries, 0, len(series))
+	for _, s := range series {
+		result = append(result, entities.RestoreAvailabilitySeries(
+			s.ID(),
+			s.TimeRange(),
+			s.Pattern(),
+			s.Interval(),
+			s.EndDate(),
+			append([]time.Time(nil), s.Exceptions()...),
+		))
+	}
+	return result
+}
@@ -1,20 +1,42 @@
 package repositories
 
 import (
-	"errors"
 	"sync"
 
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
 	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 )
 
+// scheduleEntry keeps the schedule's appointments by ID and resolves them
+// through the appointment repository on read, so a schedule always sees the
+// latest saved state of its appointments.
+type scheduleEntry struct {
+	schedule       *entities.Schedule
+	appointmentIDs []string
+}
+
 type MemoryScheduleRepository struct {
-	schedules map[string]*entities.Schedule
-	mu        sync.RWMutex
+	schedules       map[string]*scheduleEntry
+	appointmentRepo *MemoryAppointmentRepository
+	mu              *sync.RWMutex
+	journal         *memoryJournal
+}
+
+func NewMemoryScheduleRepository(appointmentRepo *MemoryAppointmentRepository) *MemoryScheduleRepository {
+	return &MemoryScheduleRepository{
+		schedules:       make(map[string]*scheduleEntry),
+		appointmentRepo: appointmentRepo,
+		mu:              &sync.RWMutex{},
+	}
 }
 
-func NewMemoryScheduleRepository() *MemoryScheduleRepository {
+func (r *MemoryScheduleRepository) withJournal(journal *memoryJournal) *MemoryScheduleRepository {
 	return &MemoryScheduleRepository{
-		schedules: make(map[string]*entities.Schedule),
+		schedules:       r.schedules,
+		appointmentRepo: r.appointmentRepo,
+		mu:              r.mu,
+		journal:         journal,
 	}
 }
 
@@ -22,19 +44,48 @@ func (r *MemoryScheduleRepository) FindByOwnerID(ownerID string) (*entities.Sche
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
-	for _, schedule := range r.schedules {
-		if schedule.OwnerID() == ownerID {
-			return schedule, nil
+	for _, entry := range r.schedules {
+		if entry.schedule.OwnerID() == ownerID {
+			return r.restore(entry), nil
 		}
 	}
-	return nil, errors.New("schedule not found")
+	return nil, usecases.ErrScheduleNotFound
 }
 
 func (r *MemoryScheduleRepository) Save(schedule *entities.Schedule) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
-	r.schedules[schedule.ID()] = schedule
+	current := 0
+	if stored, exists := r.schedules[schedule.ID()]; exists {
+		current = stored.schedule.Version()
+	}
+
+	if current != schedule.Version() {
+		return usecases.ErrVersionConflict
+	}
+	schedule.IncrementVersion()
+
+	// Keep a private copy of everything but the appointments
+	entry := &scheduleEntry{
+		schedule: entities.RestoreSchedule(
+			schedule.ID(),
+			schedule.OwnerID(),
+			schedule.Timezone(),
+			schedule.WorkingHours(),
+			append([]string(nil), schedule.HolidayCalendars()...),
+			nil,
+			append([]valueobjects.BlockedTime(nil), schedule.BlockedTimes()...),
+			schedule.ConflictPolicy(),
+			schedule.Version(),
+		),
+		appointmentIDs: make([]string, 0, len(schedule.Appointments())),
+	}
+	for _, appointment := range schedule.Appointments() {
+		entry.appointmentIDs = append(entry.appointmentIDs, appointment.ID())
+	}
+
+	r.put(schedule.ID(), entry)
 	return nil
 }
 
@@ -42,11 +93,11 @@ func (r *MemoryScheduleRepository) FindByID(id string) (*entities.Schedule, erro
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
-	schedule, exists := r.schedules[id]
+	entry, exists := r.schedules[id]
 	if !exists {
-		return nil, errors.New("schedule not found")
+		return nil, usecases.ErrScheduleNotFound
 	}
-	return schedule, nil
+	return r.restore(entry), nil
 }
 
 func (r *MemoryScheduleRepository) Delete(id string) error {
@@ -54,10 +105,10 @@ func (r *MemoryScheduleRepository) Delete(id string) error {
 	defer r.mu.Unlock()
 	
 	if _, exists := r.schedules[id]; !exists {
-		return errors.New("schedule not found")
+		return usecases.ErrScheduleNotFound
 	}
 	
-	delete(r.schedules, id)
+	r.put(id, nil)
 	return nil
 }
 
@@ -66,8 +117,43 @@ func (r *MemoryScheduleRepository) FindAll() ([]*entities.Schedule, error) {
 	defer r.mu.RUnlock()
 	
 	result := make([]*entities.Schedule, 0, len(r.schedules))
-	for _, schedule := range r.schedules {
-		result = append(result, schedule)
+	for _, entry := range r.schedules {
+		result = append(result, r.restore(entry))
 	}
 	return result, nil
 }
+
+func (r *MemoryScheduleRepository) restore(entry *scheduleEntry) *entities.Schedule {
+	return entities.RestoreSchedule(
+		entry.schedule.ID(),
+		entry.schedule.OwnerID(),
+		entry.schedule.Timezone(),
+		entry.schedule.WorkingHours(),
+		append([]string(nil), entry.schedule.HolidayCalendars()...),
+		r.appointmentRepo.findByIDs(entry.appointmentIDs),
+		append([]valueobjects.BlockedTime(nil), entry.schedule.BlockedTimes()...),
+		entry.schedule.ConflictPolicy(),
+		entry.schedule.Version(),
+	)
+}
+
+// put stores or, when entry is nil, deletes a schedule. Callers must hold
+// the write lock.
+func (r *MemoryScheduleRepository) put(id string, entry *scheduleEntry) {
+	previous, existed := r.schedules[id]
+	r.journal.record(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if existed {
+			r.schedules[id] = previous
+		} else {
+			delete(r.schedules, id)
+		}
+	})
+
+	if entry == nil {
+		delete(r.schedules, id)
+		return
+	}
+	r.schedules[id] = entry
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"sync"
+
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+// memoryJournal records how to undo each write made inside a unit of work.
+type memoryJournal struct {
+	undo []func()
+}
+
+func (j *memoryJournal) record(undo func()) {
+	if j != nil {
+		j.undo = append(j.undo, undo)
+	}
+}
+
+func (j *memoryJournal) rollback() {
+	for i := len(j.undo) - 1; i >= 0; i-- {
+		j.undo[i]()
+	}
+	j.undo = nil
+}
+
+type MemoryUnitOfWork struct {
+	appointmentRepo *MemoryAppointmentRepository
+	scheduleRepo    *MemoryScheduleRepository
+	seriesRepo      *MemoryAppointmentSeriesRepository
+	mu              sync.Mutex
+}
+
+func NewMemoryUnitOfWork(appointmentRepo *MemoryAppointmentRepository, scheduleRepo *MemoryScheduleRepository, seriesRepo *MemoryAppointmentSeriesRepository) *MemoryUnitOfWork {
+	return &MemoryUnitOfWork{
+		appointmentRepo: appointmentRepo,
+		scheduleRepo:    scheduleRepo,
+		seriesRepo:      seriesRepo,
+	}
+}
+
+func (u *MemoryUnitOfWork) Do(fn func(repos usecases.TxRepositories) error) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	journal := &memoryJournal{}
+	repos := usecases.TxRepositories{
+		Appointments: u.appointmentRepo.withJournal(journal),
+		Schedules:    u.scheduleRepo.withJournal(journal),
+		Series:       u.seriesRepo.withJournal(journal),
+	}
+
+	if err := fn(repos); err != nil {
+		journal.rollback()
+		return err
+	}
+	return nil
+}
+
+// The memory repositories hand out copies so that changes made to an entity
+// only become visible once it is saved, as with the database backends.
+
+func cloneAppointment(appointment *entities.Appointment) *entities.Appointment {
+	return entities.RestoreAppointment(
+		appointment.ID(),
+		appointment.Title(),
+		appointment.Description(),
+		appointment.TimeRange(),
+		append([]entities.Attendee(nil), appointment.Attendees()...),
+		appointment.Location(),
+		append([]string{}, appointment.Tags()...),
+		appointment.Metadata().Clone(),
+		appointment.Status(),
+		append([]entities.StatusTransition(nil), appointment.StatusHistory()...),
+		appointment.CreatedAt(),
+		appointment.UpdatedAt(),
+		appointment.Version(),
+	)
+}
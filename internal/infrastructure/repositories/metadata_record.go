@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// newMetadataRecord is the JSON form of metadata, shared by the bolt records
+// and the postgres metadata column. JSON keeps each value's type.
+func newMetadataRecord(metadata valueobjects.Metadata) map[string]interface{} {
+	result := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		result[key] = value.Value()
+	}
+	return result
+}
+
+func toMetadata(record map[string]interface{}) (valueobjects.Metadata, error) {
+	result := make(valueobjects.Metadata, len(record))
+	for key, raw := range record {
+		value, err := valueobjects.ParseMetadataValue(raw)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// restoreTags returns stored tags, or none for records written before
+// appointments had tags.
+func restoreTags(tags []string) []string {
+	if tags == nil {
+		return make([]string, 0)
+	}
+	return tags
+}
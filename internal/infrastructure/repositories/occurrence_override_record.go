@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// occurrenceOverrideRecord is the JSON form of an overridden series
+// occurrence, shared by the bolt records and the postgres overrides column.
+type occurrenceOverrideRecord struct {
+	RecurrenceID time.Time        `json:"recurrence_id"`
+	TimeRange    *timeRangeRecord `json:"time_range,omitempty"`
+	Title        *string          `json:"title,omitempty"`
+	Location     *string          `json:"location,omitempty"`
+}
+
+func newOccurrenceOverrideRecords(overrides []entities.OccurrenceOverride) []occurrenceOverrideRecord {
+	result := make([]occurrenceOverrideRecord, 0, len(overrides))
+	for _, override := range overrides {
+		record := occurrenceOverrideRecord{
+			RecurrenceID: override.RecurrenceID(),
+			Title:        override.Title(),
+			Location:     override.Location(),
+		}
+		if override.TimeRange() != nil {
+			timeRange := newTimeRangeRecord(*override.TimeRange())
+			record.TimeRange = &timeRange
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+func toOccurrenceOverrides(records []occurrenceOverrideRecord) ([]entities.OccurrenceOverride, error) {
+	result := make([]entities.OccurrenceOverride, 0, len(records))
+	for _, record := range records {
+		var timeRange *valueobjects.TimeRange
+		if record.TimeRange != nil {
+			moved, err := record.TimeRange.toTimeRange()
+			if err != nil {
+				return nil, err
+			}
+			timeRange = &moved
+		}
+		result = append(result, entities.NewOccurrenceOverride(record.RecurrenceID, timeRange, record.Title, record.Location))
+	}
+	return result, nil
+}
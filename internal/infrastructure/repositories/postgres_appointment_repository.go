@@ -0,0 +1,390 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// sqlExecutor is satisfied by both *sql.DB and *sql.Tx so the row helpers
+// can run inside or outside a transaction.
+type sqlExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+const appointmentSelect = `
+	SELECT a.id, a.title, a.description, a.start_time, a.end_time, a.location, a.tags, a.metadata, a.status, a.status_history, a.created_at, a.updated_at, a.version,
+		COALESCE(json_agg(json_build_object(
+			'participant_id', aa.participant_id, 'role', aa.role, 'response', aa.response, 'responded_at', aa.responded_at
+		) ORDER BY aa.position) FILTER (WHERE aa.participant_id IS NOT NULL), '[]')
+	FROM appointments a
+	LEFT JOIN appointment_attendees aa ON aa.appointment_id = a.id`
+
+type PostgresAppointmentRepository struct {
+	db sqlExecutor
+}
+
+func NewPostgresAppointmentRepository(db *sql.DB) *PostgresAppointmentRepository {
+	return &PostgresAppointmentRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresAppointmentRepository) Save(appointment *entities.Appointment) error {
+	history, err := json.Marshal(newStatusTransitionRecords(appointment.StatusHistory()))
+	if err != nil {
+		return err
+	}
+
+	metadata, err := json.Marshal(newMetadataRecord(appointment.Metadata()))
+	if err != nil {
+		return err
+	}
+
+	return withTx(r.db, func(tx sqlExecutor) error {
+		// The conflict branch only applies when the stored row is still at the
+		// version the appointment was loaded with
+		result, err := tx.Exec(`
+			INSERT INTO appointments (id, title, description, start_time, end_time, location, tags, metadata, status, status_history, created_at, updated_at, version)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13 + 1)
+			ON CONFLICT (id) DO UPDATE SET
+				title = EXCLUDED.title,
+				description = EXCLUDED.description,
+				start_time = EXCLUDED.start_time,
+				end_time = EXCLUDED.end_time,
+				location = EXCLUDED.location,
+				tags = EXCLUDED.tags,
+				metadata = EXCLUDED.metadata,
+				status = EXCLUDED.status,
+				status_history = EXCLUDED.status_history,
+				updated_at = EXCLUDED.updated_at,
+				version = EXCLUDED.version
+			WHERE appointments.version = $13`,
+			appointment.ID(),
+			appointment.Title(),
+			appointment.Description(),
+			appointment.TimeRange().StartTime(),
+			appointment.TimeRange().EndTime(),
+			appointment.Location(),
+			pq.Array(appointment.Tags()),
+			metadata,
+			string(appointment.Status()),
+			history,
+			appointment.CreatedAt(),
+			appointment.UpdatedAt(),
+			appointment.Version(),
+		)
+		if err != nil {
+			return err
+		}
+
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return usecases.ErrVersionConflict
+		}
+
+		appointment.IncrementVersion()
+		return replaceAttendees(tx, appointment)
+	})
+}
+
+func (r *PostgresAppointmentRepository) FindByID(id string) (*entities.Appointment, error) {
+	appointments, err := queryAppointments(r.db, appointmentSelect+` WHERE a.id = $1 GROUP BY a.id`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(appointments) == 0 {
+		return nil, usecases.ErrAppointmentNotFound
+	}
+	return appointments[0], nil
+}
+
+func (r *PostgresAppointmentRepository) FindByParticipant(participantID string) ([]*entities.Appointment, error) {
+	return queryAppointments(r.db, appointmentSelect+`
+		WHERE a.id IN (SELECT appointment_id FROM appointment_attendees WHERE participant_id = $1)
+		GROUP BY a.id
+		ORDER BY a.start_time`, participantID)
+}
+
+func (r *PostgresAppointmentRepository) Update(appointment *entities.Appointment) error {
+	history, err := json.Marshal(newStatusTransitionRecords(appointment.StatusHistory()))
+	if err != nil {
+		return err
+	}
+
+	metadata, err := json.Marshal(newMetadataRecord(appointment.Metadata()))
+	if err != nil {
+		return err
+	}
+
+	return withTx(r.db, func(tx sqlExecutor) error {
+		result, err := tx.Exec(`
+			UPDATE appointments
+			SET title = $2, description = $3, start_time = $4, end_time = $5, location = $6, tags = $7, metadata = $8,
+				status = $9, status_history = $10, updated_at = $11, version = version + 1
+			WHERE id = $1 AND version = $12`,
+			appointment.ID(),
+			appointment.Title(),
+			appointment.Description(),
+			appointment.TimeRange().StartTime(),
+			appointment.TimeRange().EndTime(),
+			appointment.Location(),
+			pq.Array(appointment.Tags()),
+			metadata,
+			string(appointment.Status()),
+			history,
+			appointment.UpdatedAt(),
+			appointment.Version(),
+		)
+		if err != nil {
+			return err
+		}
+
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			var exists bool
+			if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM appointments WHERE id = $1)`, appointment.ID()).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return usecases.ErrAppointmentNotFound
+			}
+			return usecases.ErrVersionConflict
+		}
+
+		appointment.IncrementVersion()
+		return replaceAttendees(tx, appointment)
+	})
+}
+
+func (r *PostgresAppointmentRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM appointments WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return usecases.ErrAppointmentNotFound
+	}
+	return nil
+}
+
+func (r *PostgresAppointmentRepository) FindAll() ([]*entities.Appointment, error) {
+	return queryAppointments(r.db, appointmentSelect+` GROUP BY a.id ORDER BY a.start_time`)
+}
+
+func (r *PostgresAppointmentRepository) Query(query usecases.AppointmentQuery) ([]*entities.Appointment, int, error) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.ParticipantID != "" {
+		conditions = append(conditions, `a.id IN (SELECT appointment_id FROM appointment_attendees WHERE participant_id = `+arg(query.ParticipantID)+`)`)
+	}
+
+	if len(query.Statuses) > 0 {
+		statuses := make([]string, len(query.Statuses))
+		for i, status := range query.Statuses {
+			statuses[i] = string(status)
+		}
+		conditions = append(conditions, `a.status = ANY(`+arg(pq.Array(statuses))+`)`)
+	}
+
+	if len(query.Tags) > 0 {
+		conditions = append(conditions, `a.tags @> `+arg(pq.Array(query.Tags)))
+	}
+
+	if len(query.MetadataKeys) > 0 {
+		conditions = append(conditions, `a.metadata ?& `+arg(pq.Array(query.MetadataKeys)))
+	}
+
+	if !query.From.IsZero() {
+		conditions = append(conditions, `a.end_time > `+arg(query.From))
+	}
+	if !query.To.IsZero() {
+		conditions = append(conditions, `a.start_time < `+arg(query.To))
+	}
+
+	if query.Location != "" {
+		conditions = append(conditions, `LOWER(a.location) = LOWER(`+arg(query.Location)+`)`)
+	}
+
+	if query.TitleSearch != "" {
+		pattern := "%" + likeEscaper.Replace(query.TitleSearch) + "%"
+		conditions = append(conditions, `a.title ILIKE `+arg(pattern))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM appointments a`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// Title ordering uses byte order to match the cursor keys
+	sortColumn := map[usecases.AppointmentSortField]string{
+		usecases.SortByStartTime: "a.start_time",
+		usecases.SortByCreatedAt: "a.created_at",
+		usecases.SortByTitle:     `a.title COLLATE "C"`,
+	}[query.SortBy]
+	if sortColumn == "" {
+		sortColumn = "a.start_time"
+	}
+
+	direction, comparison := "ASC", ">"
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if query.After != nil {
+		key, err := query.SortBy.ParseKey(query.After.Key)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		cursor := fmt.Sprintf("(%s, a.id) %s (%s, %s)", sortColumn, comparison, arg(key), arg(query.After.ID))
+		if where == "" {
+			where = " WHERE " + cursor
+		} else {
+			where += " AND " + cursor
+		}
+	}
+
+	statement := appointmentSelect + where + fmt.Sprintf(" GROUP BY a.id ORDER BY %s %s, a.id %s", sortColumn, direction, direction)
+	if query.Limit > 0 {
+		statement += " LIMIT " + arg(query.Limit)
+	}
+	if query.Offset > 0 {
+		statement += " OFFSET " + arg(query.Offset)
+	}
+
+	appointments, err := queryAppointments(r.db, statement, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return appointments, total, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func replaceAttendees(tx sqlExecutor, appointment *entities.Appointment) error {
+	if _, err := tx.Exec(`DELETE FROM appointment_attendees WHERE appointment_id = $1`, appointment.ID()); err != nil {
+		return err
+	}
+
+	for i, attendee := range appointment.Attendees() {
+		_, err := tx.Exec(
+			`INSERT INTO appointment_attendees (appointment_id, participant_id, position, role, response, responded_at)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			appointment.ID(), attendee.ParticipantID(), i, string(attendee.Role()), string(attendee.Response()), attendee.RespondedAt(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func queryAppointments(q sqlExecutor, query string, args ...interface{}) ([]*entities.Appointment, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*entities.Appointment, 0)
+	for rows.Next() {
+		var (
+			id, title, description    string
+			location, status          string
+			startTime, endTime        time.Time
+			tags                      []string
+			createdAt, updatedAt      time.Time
+			version                   int
+			metadataJSON, historyJSON []byte
+			attendeesJSON             []byte
+		)
+
+		err := rows.Scan(&id, &title, &description, &startTime, &endTime, &location, pq.Array(&tags), &metadataJSON, &status, &historyJSON, &createdAt, &updatedAt, &version, &attendeesJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		var metadataRecord map[string]interface{}
+		if err := json.Unmarshal(metadataJSON, &metadataRecord); err != nil {
+			return nil, err
+		}
+
+		metadata, err := toMetadata(metadataRecord)
+		if err != nil {
+			return nil, err
+		}
+
+		var attendees []attendeeRecord
+		if err := json.Unmarshal(attendeesJSON, &attendees); err != nil {
+			return nil, err
+		}
+
+		var history []statusTransitionRecord
+		if err := json.Unmarshal(historyJSON, &history); err != nil {
+			return nil, err
+		}
+
+		timeRange, err := valueobjects.NewTimeRange(startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, entities.RestoreAppointment(
+			id,
+			title,
+			description,
+			timeRange,
+			toAttendees(attendees),
+			location,
+			restoreTags(tags),
+			metadata,
+			entities.AppointmentStatus(status),
+			toStatusHistory(history),
+			createdAt,
+			updatedAt,
+			version,
+		))
+	}
+	return result, rows.Err()
+}
+
+// withTx runs fn in a new transaction, or in the caller's transaction when
+// the repository is already bound to one by a unit of work.
+func withTx(q sqlExecutor, fn func(tx sqlExecutor) error) error {
+	db, ok := q.(*sql.DB)
+	if !ok {
+		return fn(q)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
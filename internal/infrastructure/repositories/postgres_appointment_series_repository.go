@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+type PostgresAppointmentSeriesRepository struct {
+	db sqlExecutor
+}
+
+func NewPostgresAppointmentSeriesRepository(db *sql.DB) *PostgresAppointmentSeriesRepository {
+	return &PostgresAppointmentSeriesRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresAppointmentSeriesRepository) Save(series *entities.AppointmentSeries) error {
+	exceptions, err := json.Marshal(series.Exceptions())
+	if err != nil {
+		return err
+	}
+
+	overrides, err := json.Marshal(newOccurrenceOverrideRecords(series.Overrides()))
+	if err != nil {
+		return err
+	}
+
+	attendeeDetails, err := json.Marshal(newAttendeeRecords(series.Attendees()))
+	if err != nil {
+		return err
+	}
+
+	// The conflict branch only applies when the stored row is still at the
+	// version the series was loaded with
+	result, err := r.db.Exec(`
+		INSERT INTO appointment_series (id, title, start_time, end_time, attendees, attendee_details, location, rrule, timezone,
+			dst_gap_policy, dst_ambiguity_policy, exdates, overrides, horizon, status, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18 + 1)
+		ON CONFLICT (id) DO UPDATE SET
+			title = EXCLUDED.title,
+			start_time = EXCLUDED.start_time,
+			end_time = EXCLUDED.end_time,
+			attendees = EXCLUDED.attendees,
+			attendee_details = EXCLUDED.attendee_details,
+			location = EXCLUDED.location,
+			rrule = EXCLUDED.rrule,
+			timezone = EXCLUDED.timezone,
+			dst_gap_policy = EXCLUDED.dst_gap_policy,
+			dst_ambiguity_policy = EXCLUDED.dst_ambiguity_policy,
+			exdates = EXCLUDED.exdates,
+			overrides = EXCLUDED.overrides,
+			horizon = EXCLUDED.horizon,
+			status = EXCLUDED.status,
+			updated_at = EXCLUDED.updated_at,
+			version = EXCLUDED.version
+		WHERE appointment_series.version = $18`,
+		series.ID(),
+		series.Title(),
+		series.TimeRange().StartTime(),
+		series.TimeRange().EndTime(),
+		pq.Array(series.AttendeeIDs()),
+		attendeeDetails,
+		series.Location(),
+		series.Rule(),
+		series.Timezone().String(),
+		string(series.LocalTimePolicy().Gap),
+		string(series.LocalTimePolicy().Ambiguity),
+		exceptions,
+		overrides,
+		series.Horizon(),
+		string(series.Status()),
+		series.CreatedAt(),
+		series.UpdatedAt(),
+		series.Version(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return usecases.ErrVersionConflict
+	}
+
+	series.IncrementVersion()
+	return nil
+}
+
+func (r *PostgresAppointmentSeriesRepository) FindByID(id string) (*entities.AppointmentSeries, error) {
+	var (
+		title, location, rule, timezone, status string
+		gapPolicy, ambiguityPolicy              string
+		startTime, endTime, horizon             time.Time
+		createdAt, updatedAt                    time.Time
+		version                                 int
+		attendees                               pq.StringArray
+		attendeesJSON                           []byte
+		exceptionsJSON, overridesJSON           []byte
+	)
+
+	err := r.db.QueryRow(`
+		SELECT title, start_time, end_time, attendees, attendee_details, location, rrule, timezone,
+			dst_gap_policy, dst_ambiguity_policy, exdates, overrides, horizon, status, created_at, updated_at, version
+		FROM appointment_series
+		WHERE id = $1`, id).Scan(
+		&title, &startTime, &endTime, &attendees, &attendeesJSON, &location, &rule, &timezone,
+		&gapPolicy, &ambiguityPolicy, &exceptionsJSON, &overridesJSON, &horizon, &status, &createdAt, &updatedAt, &version,
+	)
+	if err == sql.ErrNoRows {
+		return nil, usecases.ErrAppointmentSeriesNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	timeRange, err := valueobjects.NewTimeRange(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	zone, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	localTime, err := valueobjects.NewLocalTimePolicy(gapPolicy, ambiguityPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Series stored before attendees had roles only have their IDs
+	attendeeRecords := make([]attendeeRecord, 0, len(attendees))
+	if attendeesJSON != nil {
+		if err := json.Unmarshal(attendeesJSON, &attendeeRecords); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, participantID := range attendees {
+			attendeeRecords = append(attendeeRecords, attendeeRecord{
+				ParticipantID: participantID,
+				Role:          string(entities.RoleRequired),
+				Response:      string(entities.ResponseNeedsAction),
+			})
+		}
+	}
+
+	exceptions := make([]time.Time, 0)
+	if err := json.Unmarshal(exceptionsJSON, &exceptions); err != nil {
+		return nil, err
+	}
+
+	var overrideRecords []occurrenceOverrideRecord
+	if err := json.Unmarshal(overridesJSON, &overrideRecords); err != nil {
+		return nil, err
+	}
+
+	overrides, err := toOccurrenceOverrides(overrideRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.RestoreAppointmentSeries(
+		id,
+		title,
+		timeRange,
+		toAttendees(attendeeRecords),
+		location,
+		rule,
+		zone,
+		localTime,
+		exceptions,
+		overrides,
+		horizon,
+		entities.AppointmentStatus(status),
+		createdAt,
+		updatedAt,
+		version,
+	), nil
+}
+
+func (r *PostgresAppointmentSeriesRepository) FindIDsToExtend(until time.Time) ([]string, error) {
+	rows, err := r.db.Query(`
+		SELECT id
+		FROM appointment_series
+		WHERE status <> $1 AND horizon < $2
+		ORDER BY id`, string(entities.StatusCancelled), until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result = append(result, id)
+	}
+	return result, rows.Err()
+}
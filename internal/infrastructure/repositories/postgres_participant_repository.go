@@ -0,0 +1,301 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+type PostgresParticipantRepository struct {
+	db sqlExecutor
+}
+
+func NewPostgresParticipantRepository(db *sql.DB) *PostgresParticipantRepository {
+	return &PostgresParticipantRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresParticipantRepository) FindByID(id string) (*entities.Participant, error) {
+	participants, err := r.query(`WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(participants) == 0 {
+		return nil, usecases.ErrParticipantNotFound
+	}
+	return participants[0], nil
+}
+
+func (r *PostgresParticipantRepository) FindByIDs(ids []string) ([]*entities.Participant, error) {
+	participants, err := r.query(`WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the caller's ordering, as the memory repository does
+	byID := make(map[string]*entities.Participant, len(participants))
+	for _, participant := range participants {
+		byID[participant.ID()] = participant
+	}
+
+	result := make([]*entities.Participant, 0, len(ids))
+	for _, id := range ids {
+		if participant, exists := byID[id]; exists {
+			result = append(result, participant)
+		}
+	}
+	return result, nil
+}
+
+func (r *PostgresParticipantRepository) Save(participant *entities.Participant) error {
+	return withTx(r.db, func(tx sqlExecutor) error {
+		_, err := tx.Exec(`
+			INSERT INTO participants (id, name, email, timezone, holiday_calendars)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (id) DO UPDATE SET
+				name = EXCLUDED.name,
+				email = EXCLUDED.email,
+				timezone = EXCLUDED.timezone,
+				holiday_calendars = EXCLUDED.holiday_calendars`,
+			participant.ID(),
+			participant.Name(),
+			participant.Email(),
+			participant.Timezone().String(),
+			pq.Array(participant.HolidayCalendars()),
+		)
+		if err != nil {
+			return err
+		}
+
+		return replaceAvailability(tx, participant)
+	})
+}
+
+func (r *PostgresParticipantRepository) FindByEmail(email string) (*entities.Participant, error) {
+	participants, err := r.query(`WHERE email = $1`, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(participants) == 0 {
+		return nil, usecases.ErrParticipantNotFound
+	}
+	return participants[0], nil
+}
+
+func (r *PostgresParticipantRepository) Update(participant *entities.Participant) error {
+	return withTx(r.db, func(tx sqlExecutor) error {
+		result, err := tx.Exec(
+			`UPDATE participants SET name = $2, email = $3, timezone = $4, holiday_calendars = $5 WHERE id = $1`,
+			participant.ID(),
+			participant.Name(),
+			participant.Email(),
+			participant.Timezone().String(),
+			pq.Array(participant.HolidayCalendars()),
+		)
+		if err != nil {
+			return err
+		}
+
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return usecases.ErrParticipantNotFound
+		}
+
+		return replaceAvailability(tx, participant)
+	})
+}
+
+func (r *PostgresParticipantRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM participants WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return usecases.ErrParticipantNotFound
+	}
+	return nil
+}
+
+func (r *PostgresParticipantRepository) FindAll() ([]*entities.Participant, error) {
+	return r.query(`ORDER BY name`)
+}
+
+func (r *PostgresParticipantRepository) query(clause string, args ...interface{}) ([]*entities.Participant, error) {
+	rows, err := r.db.Query(`SELECT id, name, email, timezone, holiday_calendars FROM participants `+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	type participantRow struct {
+		id, name, email, timezone string
+		holidayCalendars          pq.StringArray
+	}
+
+	records := make([]participantRow, 0)
+	for rows.Next() {
+		var record participantRow
+		if err := rows.Scan(&record.id, &record.name, &record.email, &record.timezone, &record.holidayCalendars); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	rows.Close()
+
+	result := make([]*entities.Participant, 0, len(records))
+	for _, record := range records {
+		location, err := time.LoadLocation(record.timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		availability, err := r.findAvailability(record.id)
+		if err != nil {
+			return nil, err
+		}
+
+		series, err := r.findAvailabilitySeries(record.id)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, entities.RestoreParticipant(record.id, record.name, record.email, location, availability, series, []string(record.holidayCalendars)))
+	}
+	return result, nil
+}
+
+func (r *PostgresParticipantRepository) findAvailability(participantID string) ([]valueobjects.TimeSlot, error) {
+	rows, err := r.db.Query(`
+		SELECT id, start_time, end_time, is_available, resource_id
+		FROM participant_availability
+		WHERE participant_id = $1
+		ORDER BY start_time`, participantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]valueobjects.TimeSlot, 0)
+	for rows.Next() {
+		var (
+			id, resourceID     string
+			startTime, endTime time.Time
+			isAvailable        bool
+		)
+		if err := rows.Scan(&id, &startTime, &endTime, &isAvailable, &resourceID); err != nil {
+			return nil, err
+		}
+
+		timeRange, err := valueobjects.NewTimeRange(startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, valueobjects.RestoreTimeSlot(id, timeRange, isAvailable, resourceID))
+	}
+	return result, rows.Err()
+}
+
+func (r *PostgresParticipantRepository) findAvailabilitySeries(participantID string) ([]entities.AvailabilitySeries, error) {
+	rows, err := r.db.Query(`
+		SELECT id, start_time, end_time, pattern, interval_count, end_date, exceptions
+		FROM participant_availability_series
+		WHERE participant_id = $1
+		ORDER BY start_time`, participantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]entities.AvailabilitySeries, 0)
+	for rows.Next() {
+		var (
+			id, pattern        string
+			startTime, endTime time.Time
+			interval           int
+			endDate            sql.NullTime
+			exceptionsJSON     []byte
+			exceptions         []time.Time
+		)
+		if err := rows.Scan(&id, &startTime, &endTime, &pattern, &interval, &endDate, &exceptionsJSON); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(exceptionsJSON, &exceptions); err != nil {
+			return nil, err
+		}
+
+		timeRange, err := valueobjects.NewTimeRange(startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+
+		var until *time.Time
+		if endDate.Valid {
+			until = &endDate.Time
+		}
+
+		if exceptions == nil {
+			exceptions = make([]time.Time, 0)
+		}
+		result = append(result, entities.RestoreAvailabilitySeries(id, timeRange, pattern, interval, until, exceptions))
+	}
+	return result, rows.Err()
+}
+
+func replaceAvailability(tx sqlExecutor, participant *entities.Participant) error {
+	if _, err := tx.Exec(`DELETE FROM participant_availability WHERE participant_id = $1`, participant.ID()); err != nil {
+		return err
+	}
+
+	for _, slot := range participant.Availability() {
+		_, err := tx.Exec(`
+			INSERT INTO participant_availability (id, participant_id, start_time, end_time, is_available, resource_id)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			slot.ID(),
+			participant.ID(),
+			slot.TimeRange().StartTime(),
+			slot.TimeRange().EndTime(),
+			slot.IsAvailable(),
+			slot.ResourceID(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM participant_availability_series WHERE participant_id = $1`, participant.ID()); err != nil {
+		return err
+	}
+
+	for _, series := range participant.AvailabilitySeries() {
+		exceptions, err := json.Marshal(series.Exceptions())
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO participant_availability_series (id, participant_id, start_time, end_time, pattern, interval_count, end_date, exceptions)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			series.ID(),
+			participant.ID(),
+			series.TimeRange().StartTime(),
+			series.TimeRange().EndTime(),
+			series.Pattern(),
+			series.Interval(),
+			series.EndDate(),
+			exceptions,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,393 @@
+package repositories_test
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/database"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/repositories"
+	infraServices "github.com/visiab/appointment-calculator/internal/infrastructure/services"
+)
+
+// openPostgresTestDB connects to the database in POSTGRES_TEST_DSN and
+// migrates it, skipping the test when it isn't set. Tests use fresh IDs and
+// remove what they store, so the database may be shared.
+func openPostgresTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, db.Ping())
+
+	migrator, err := database.NewMigrator(db)
+	require.NoError(t, err)
+	_, err = migrator.Up()
+	require.NoError(t, err)
+	return db
+}
+
+func mustTimeRange(t *testing.T, start time.Time, duration time.Duration) valueobjects.TimeRange {
+	t.Helper()
+
+	timeRange, err := valueobjects.NewTimeRange(start, start.Add(duration))
+	require.NoError(t, err)
+	return timeRange
+}
+
+func newStoredAppointment(t *testing.T, repo *repositories.PostgresAppointmentRepository, ownerID string, start time.Time) *entities.Appointment {
+	t.Helper()
+
+	appointment, err := entities.NewAppointment("Review", mustTimeRange(t, start, time.Hour), entities.RequiredAttendees([]string{ownerID}), "Room 1")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(appointment))
+	t.Cleanup(func() { repo.Delete(appointment.ID()) })
+	return appointment
+}
+
+func TestPostgresParticipantRepository(t *testing.T) {
+	db := openPostgresTestDB(t)
+	repo := repositories.NewPostgresParticipantRepository(db)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	start := time.Date(2030, 3, 18, 9, 0, 0, 0, berlin)
+
+	participant, err := entities.NewParticipant("Alice", "alice-"+uuid.NewString()+"@example.com", berlin)
+	require.NoError(t, err)
+	participant.SetHolidayCalendars([]string{"DE"})
+	slot := valueobjects.NewTimeSlot(mustTimeRange(t, start, time.Hour), true, "")
+	participant.AddAvailability(slot)
+	series, err := entities.NewAvailabilitySeries(mustTimeRange(t, start.Add(2*time.Hour), 2*time.Hour), "weekly", 2, nil)
+	require.NoError(t, err)
+	participant.AddAvailabilitySeries(series)
+
+	require.NoError(t, repo.Save(participant))
+	t.Cleanup(func() { repo.Delete(participant.ID()) })
+
+	stored, err := repo.FindByID(participant.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", stored.Name())
+	assert.Equal(t, participant.Email(), stored.Email())
+	assert.Equal(t, "Europe/Berlin", stored.Timezone().String())
+	assert.Equal(t, []string{"DE"}, stored.HolidayCalendars())
+
+	require.Len(t, stored.Availability(), 1)
+	assert.Equal(t, slot.ID(), stored.Availability()[0].ID())
+	assert.True(t, start.Equal(stored.Availability()[0].TimeRange().StartTime()))
+
+	require.Len(t, stored.AvailabilitySeries(), 1)
+	assert.Equal(t, series.ID(), stored.AvailabilitySeries()[0].ID())
+	assert.Equal(t, "weekly", stored.AvailabilitySeries()[0].Pattern())
+	assert.Equal(t, 2, stored.AvailabilitySeries()[0].Interval())
+
+	byEmail, err := repo.FindByEmail(participant.Email())
+	require.NoError(t, err)
+	assert.Equal(t, participant.ID(), byEmail.ID())
+
+	require.NoError(t, stored.Rename("Alice Smith"))
+	require.True(t, stored.RemoveAvailability(slot.ID()))
+	require.NoError(t, repo.Update(stored))
+
+	stored, err = repo.FindByID(participant.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Smith", stored.Name())
+	assert.Empty(t, stored.Availability())
+	assert.Len(t, stored.AvailabilitySeries(), 1)
+
+	require.NoError(t, repo.Delete(participant.ID()))
+	_, err = repo.FindByID(participant.ID())
+	assert.ErrorIs(t, err, usecases.ErrParticipantNotFound)
+	assert.ErrorIs(t, repo.Delete(participant.ID()), usecases.ErrParticipantNotFound)
+}
+
+func TestPostgresAppointmentRepository(t *testing.T) {
+	db := openPostgresTestDB(t)
+	repo := repositories.NewPostgresAppointmentRepository(db)
+
+	ownerID := uuid.NewString()
+	start := time.Date(2030, 3, 18, 9, 0, 0, 0, time.UTC)
+	appointment := newStoredAppointment(t, repo, ownerID, start)
+
+	stored, err := repo.FindByID(appointment.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Review", stored.Title())
+	assert.Equal(t, "Room 1", stored.Location())
+	assert.Equal(t, []string{ownerID}, stored.AttendeeIDs())
+	assert.True(t, start.Equal(stored.TimeRange().StartTime()))
+	assert.Equal(t, appointment.Version(), stored.Version())
+
+	// A copy loaded before another save is stale
+	stale, err := repo.FindByID(appointment.ID())
+	require.NoError(t, err)
+	stored.Reschedule(mustTimeRange(t, start.Add(time.Hour), time.Hour))
+	require.NoError(t, repo.Save(stored))
+	assert.ErrorIs(t, repo.Save(stale), usecases.ErrVersionConflict)
+
+	byParticipant, err := repo.FindByParticipant(ownerID)
+	require.NoError(t, err)
+	require.Len(t, byParticipant, 1)
+	assert.True(t, start.Add(time.Hour).Equal(byParticipant[0].TimeRange().StartTime()))
+
+	require.NoError(t, repo.Delete(appointment.ID()))
+	_, err = repo.FindByID(appointment.ID())
+	assert.ErrorIs(t, err, usecases.ErrAppointmentNotFound)
+	assert.ErrorIs(t, repo.Delete(appointment.ID()), usecases.ErrAppointmentNotFound)
+}
+
+func TestPostgresScheduleRepository(t *testing.T) {
+	db := openPostgresTestDB(t)
+	appointments := repositories.NewPostgresAppointmentRepository(db)
+	repo := repositories.NewPostgresScheduleRepository(db)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	start := time.Date(2030, 3, 18, 9, 0, 0, 0, berlin)
+	ownerID := uuid.NewString()
+
+	workingHours, err := valueobjects.DefaultWorkingHours().WithOverride("2030-03-19", nil)
+	require.NoError(t, err)
+	schedule, err := entities.NewSchedule(ownerID, berlin, workingHours)
+	require.NoError(t, err)
+	schedule.SetHolidayCalendars([]string{"DE", "US"})
+	policy := entities.DefaultConflictPolicy()
+	policy.PreBuffer = 10 * time.Minute
+	require.NoError(t, schedule.SetConflictPolicy(policy))
+	blocked := schedule.AddBlockedTime(mustTimeRange(t, start.Add(3*time.Hour), time.Hour), "Dentist")
+	appointment := newStoredAppointment(t, appointments, ownerID, start)
+	require.NoError(t, schedule.AddAppointment(appointment))
+
+	require.NoError(t, repo.Save(schedule))
+	t.Cleanup(func() { repo.Delete(schedule.ID()) })
+
+	stored, err := repo.FindByOwnerID(ownerID)
+	require.NoError(t, err)
+	assert.Equal(t, schedule.ID(), stored.ID())
+	assert.Equal(t, "Europe/Berlin", stored.Timezone().String())
+	assert.Equal(t, workingHours.Weekly(), stored.WorkingHours().Weekly())
+	assert.Equal(t, workingHours.Overrides(), stored.WorkingHours().Overrides())
+	assert.Equal(t, []string{"DE", "US"}, stored.HolidayCalendars())
+	assert.Equal(t, 10*time.Minute, stored.ConflictPolicy().PreBuffer)
+
+	require.Len(t, stored.BlockedTimes(), 1)
+	assert.Equal(t, blocked.ID(), stored.BlockedTimes()[0].ID())
+	assert.Equal(t, "Dentist", stored.BlockedTimes()[0].Reason())
+	require.Len(t, stored.Appointments(), 1)
+	assert.Equal(t, appointment.ID(), stored.Appointments()[0].ID())
+
+	stale, err := repo.FindByID(schedule.ID())
+	require.NoError(t, err)
+	require.NoError(t, stored.RemoveBlockedTime(blocked.ID()))
+	require.NoError(t, repo.Save(stored))
+	assert.ErrorIs(t, repo.Save(stale), usecases.ErrVersionConflict)
+
+	stored, err = repo.FindByOwnerID(ownerID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.BlockedTimes())
+
+	require.NoError(t, repo.Delete(schedule.ID()))
+	_, err = repo.FindByOwnerID(ownerID)
+	assert.ErrorIs(t, err, usecases.ErrScheduleNotFound)
+}
+
+func TestPostgresAppointmentSeriesRepository(t *testing.T) {
+	db := openPostgresTestDB(t)
+	repo := repositories.NewPostgresAppointmentSeriesRepository(db)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	start := time.Date(2030, 3, 4, 9, 0, 0, 0, newYork)
+	localTime := valueobjects.LocalTimePolicy{Gap: valueobjects.GapSkip, Ambiguity: valueobjects.AmbiguityLater}
+
+	series, err := entities.NewAppointmentSeries("Standup", mustTimeRange(t, start, 15*time.Minute), entities.RequiredAttendees([]string{uuid.NewString()}), "", "FREQ=DAILY", newYork, localTime)
+	require.NoError(t, err)
+	series.SetHorizon(start.AddDate(0, 0, 7))
+	require.NoError(t, repo.Save(series))
+	t.Cleanup(func() { db.Exec(`DELETE FROM appointment_series WHERE id = $1`, series.ID()) })
+
+	stored, err := repo.FindByID(series.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Standup", stored.Title())
+	assert.Equal(t, "FREQ=DAILY", stored.Rule())
+	assert.Equal(t, "America/New_York", stored.Timezone().String())
+	assert.Equal(t, localTime, stored.LocalTimePolicy())
+	assert.True(t, start.AddDate(0, 0, 7).Equal(stored.Horizon()))
+
+	ids, err := repo.FindIDsToExtend(start.AddDate(0, 0, 8))
+	require.NoError(t, err)
+	assert.Contains(t, ids, series.ID())
+
+	ids, err = repo.FindIDsToExtend(start.AddDate(0, 0, 6))
+	require.NoError(t, err)
+	assert.NotContains(t, ids, series.ID(), "the horizon is far enough")
+
+	stored.Cancel()
+	require.NoError(t, repo.Save(stored))
+	ids, err = repo.FindIDsToExtend(start.AddDate(0, 0, 8))
+	require.NoError(t, err)
+	assert.NotContains(t, ids, series.ID(), "cancelled series aren't extended")
+
+	_, err = repo.FindByID(uuid.NewString())
+	assert.ErrorIs(t, err, usecases.ErrAppointmentSeriesNotFound)
+}
+
+func TestPostgresUnitOfWorkRollsBack(t *testing.T) {
+	db := openPostgresTestDB(t)
+	appointments := repositories.NewPostgresAppointmentRepository(db)
+
+	appointment, err := entities.NewAppointment("Review", mustTimeRange(t, time.Date(2030, 3, 18, 9, 0, 0, 0, time.UTC), time.Hour), entities.RequiredAttendees([]string{uuid.NewString()}), "")
+	require.NoError(t, err)
+
+	failure := errors.New("rolled back")
+	err = repositories.NewPostgresUnitOfWork(db).Do(func(repos usecases.TxRepositories) error {
+		require.NoError(t, repos.Appointments.Save(appointment))
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	_, err = appointments.FindByID(appointment.ID())
+	assert.ErrorIs(t, err, usecases.ErrAppointmentNotFound)
+}
+
+func TestPostgresConcurrentBookingsForSameSlot(t *testing.T) {
+	db := openPostgresTestDB(t)
+	appointments := repositories.NewPostgresAppointmentRepository(db)
+	schedules := repositories.NewPostgresScheduleRepository(db)
+
+	// Bob has no schedule, so only the owner lock orders bookings for him
+	aliceID, bobID := uuid.NewString(), uuid.NewString()
+	schedule, err := entities.NewSchedule(aliceID, time.UTC, valueobjects.DefaultWorkingHours())
+	require.NoError(t, err)
+	require.NoError(t, schedules.Save(schedule))
+	t.Cleanup(func() { schedules.Delete(schedule.ID()) })
+
+	useCase := usecases.NewCreateAppointmentUseCase(repositories.NewPostgresUnitOfWork(db), infraServices.NewConsoleNotificationService(), services.NewConflictDetectionService(nil), nil, 0)
+
+	// A Monday, inside the default 09:00-17:00 working hours
+	start := time.Date(2030, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	const bookings = 10
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded []string
+		conflicts int
+	)
+
+	ready := make(chan struct{})
+	for i := 0; i < bookings; i++ {
+		attendees := []dto.AttendeeRequest{{ParticipantID: aliceID}, {ParticipantID: bobID}}
+		if i%2 == 1 {
+			attendees = []dto.AttendeeRequest{{ParticipantID: bobID}, {ParticipantID: aliceID}}
+		}
+
+		wg.Add(1)
+		go func(attendees []dto.AttendeeRequest) {
+			defer wg.Done()
+			<-ready
+
+			response, err := useCase.Execute(dto.CreateAppointmentRequest{
+				Title:     "Planning",
+				StartTime: start,
+				EndTime:   start.Add(time.Hour),
+				Attendees: attendees,
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				succeeded = append(succeeded, response.ID)
+			} else if kind, ok := domainerrors.KindOf(err); ok && kind == domainerrors.KindConflict {
+				conflicts++
+			} else {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(attendees)
+	}
+
+	close(ready)
+	wg.Wait()
+	for _, id := range succeeded {
+		t.Cleanup(func() { appointments.Delete(id) })
+	}
+
+	assert.Len(t, succeeded, 1)
+	assert.Equal(t, bookings-1, conflicts)
+
+	stored, err := schedules.FindByOwnerID(aliceID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Appointments(), 1)
+}
+
+func TestPostgresAppointmentQueryByTagsAndMetadata(t *testing.T) {
+	db := openPostgresTestDB(t)
+	repo := repositories.NewPostgresAppointmentRepository(db)
+
+	ownerID := uuid.NewString()
+	start := time.Date(2030, 3, 18, 9, 0, 0, 0, time.UTC)
+	ticket, err := valueobjects.NewStringMetadata("OPS-1")
+	require.NoError(t, err)
+	tagged := func(title string, tags []string, metadata valueobjects.Metadata) {
+		appointment, err := entities.NewAppointment(title, mustTimeRange(t, start, time.Hour), entities.RequiredAttendees([]string{ownerID}), "")
+		require.NoError(t, err)
+		require.NoError(t, appointment.SetTags(tags))
+		require.NoError(t, appointment.SetMetadata(metadata))
+		require.NoError(t, repo.Save(appointment))
+		t.Cleanup(func() { repo.Delete(appointment.ID()) })
+	}
+	tagged("Budget", []string{"finance", "q1"}, valueobjects.Metadata{"ticket": ticket})
+	tagged("Forecast", []string{"finance"}, valueobjects.Metadata{"ticket": ticket, "priority": valueobjects.NewNumberMetadata(1)})
+	tagged("Standup", []string{"team"}, valueobjects.Metadata{})
+
+	tests := []struct {
+		name         string
+		tags         []string
+		metadataKeys []string
+		want         []string
+	}{
+		{name: "one tag", tags: []string{"finance"}, want: []string{"Budget", "Forecast"}},
+		{name: "every tag", tags: []string{"finance", "q1"}, want: []string{"Budget"}},
+		{name: "unused tag", tags: []string{"sales"}, want: []string{}},
+		{name: "metadata key", metadataKeys: []string{"ticket"}, want: []string{"Budget", "Forecast"}},
+		{name: "every metadata key", metadataKeys: []string{"ticket", "priority"}, want: []string{"Forecast"}},
+		{name: "tag and metadata key", tags: []string{"q1"}, metadataKeys: []string{"priority"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, total, err := repo.Query(usecases.AppointmentQuery{
+				ParticipantID: ownerID,
+				Tags:          tt.tags,
+				MetadataKeys:  tt.metadataKeys,
+				SortBy:        usecases.SortByTitle,
+				Limit:         10,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.want), total)
+
+			titles := make([]string, 0, len(page))
+			for _, appointment := range page {
+				titles = append(titles, appointment.Title())
+			}
+			assert.Equal(t, tt.want, titles)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+type PostgresScheduleRepository struct {
+	db sqlExecutor
+}
+
+func NewPostgresScheduleRepository(db *sql.DB) *PostgresScheduleRepository {
+	return &PostgresScheduleRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresScheduleRepository) FindByOwnerID(ownerID string) (*entities.Schedule, error) {
+	// Inside a unit of work the owner is locked before the row is read. Row
+	// locks only cover owners that already have a schedule, so without this
+	// a booking for an owner without one could interleave with the creation
+	// of their schedule
+	if _, inTx := r.db.(*sql.Tx); inTx {
+		if _, err := r.db.Exec(`SELECT pg_advisory_xact_lock(hashtext('schedule:' || $1::text))`, ownerID); err != nil {
+			return nil, err
+		}
+	}
+	return r.findOne(`WHERE owner_id = $1`, ownerID)
+}
+
+func (r *PostgresScheduleRepository) FindByID(id string) (*entities.Schedule, error) {
+	return r.findOne(`WHERE id = $1`, id)
+}
+
+func (r *PostgresScheduleRepository) Save(schedule *entities.Schedule) error {
+	workingHours, err := json.Marshal(newWorkingHoursRecord(schedule.WorkingHours()))
+	if err != nil {
+		return err
+	}
+
+	conflictPolicy, err := json.Marshal(newConflictPolicyRecord(schedule.ConflictPolicy()))
+	if err != nil {
+		return err
+	}
+
+	return withTx(r.db, func(tx sqlExecutor) error {
+		result, err := tx.Exec(`
+			INSERT INTO schedules (id, owner_id, timezone, working_hours, holiday_calendars, conflict_policy, version)
+			VALUES ($1, $2, $3, $4, $5, $6, $7 + 1)
+			ON CONFLICT (id) DO UPDATE SET
+				owner_id = EXCLUDED.owner_id,
+				timezone = EXCLUDED.timezone,
+				working_hours = EXCLUDED.working_hours,
+				holiday_calendars = EXCLUDED.holiday_calendars,
+				conflict_policy = EXCLUDED.conflict_policy,
+				version = EXCLUDED.version
+			WHERE schedules.version = $7`,
+			schedule.ID(),
+			schedule.OwnerID(),
+			schedule.Timezone().String(),
+			workingHours,
+			pq.Array(schedule.HolidayCalendars()),
+			conflictPolicy,
+			schedule.Version(),
+		)
+		if err != nil {
+			return err
+		}
+
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return usecases.ErrVersionConflict
+		}
+		schedule.IncrementVersion()
+
+		if _, err := tx.Exec(`DELETE FROM schedule_appointments WHERE schedule_id = $1`, schedule.ID()); err != nil {
+			return err
+		}
+
+		for _, appointment := range schedule.Appointments() {
+			_, err := tx.Exec(
+				`INSERT INTO schedule_appointments (schedule_id, appointment_id) VALUES ($1, $2)`,
+				schedule.ID(), appointment.ID(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(`DELETE FROM schedule_blocked_times WHERE schedule_id = $1`, schedule.ID()); err != nil {
+			return err
+		}
+
+		for i, blocked := range schedule.BlockedTimes() {
+			_, err := tx.Exec(
+				`INSERT INTO schedule_blocked_times (schedule_id, position, id, start_time, end_time, reason) VALUES ($1, $2, $3, $4, $5, $6)`,
+				schedule.ID(), i, blocked.ID(), blocked.TimeRange().StartTime(), blocked.TimeRange().EndTime(), blocked.Reason(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *PostgresScheduleRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM schedules WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return usecases.ErrScheduleNotFound
+	}
+	return nil
+}
+
+func (r *PostgresScheduleRepository) FindAll() ([]*entities.Schedule, error) {
+	rows, err := r.db.Query(`SELECT id FROM schedules ORDER BY owner_id`)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	result := make([]*entities.Schedule, 0, len(ids))
+	for _, id := range ids {
+		schedule, err := r.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, schedule)
+	}
+	return result, nil
+}
+
+func (r *PostgresScheduleRepository) findOne(where string, args ...interface{}) (*entities.Schedule, error) {
+	var (
+		id, ownerID, timezone string
+		workingHoursJSON      []byte
+		conflictPolicyJSON    []byte
+		holidayCalendars      pq.StringArray
+		version               int
+	)
+
+	// Inside a unit of work the schedule row stays locked until commit, so
+	// concurrent bookings for the same owner check and write one at a time
+	query := `SELECT id, owner_id, timezone, working_hours, holiday_calendars, conflict_policy, version FROM schedules ` + where
+	if _, inTx := r.db.(*sql.Tx); inTx {
+		query += ` FOR UPDATE`
+	}
+
+	err := r.db.QueryRow(query, args...).Scan(&id, &ownerID, &timezone, &workingHoursJSON, &holidayCalendars, &conflictPolicyJSON, &version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecases.ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var record workingHoursRecord
+	if err := json.Unmarshal(workingHoursJSON, &record); err != nil {
+		return nil, err
+	}
+
+	workingHours, err := record.toWorkingHours()
+	if err != nil {
+		return nil, err
+	}
+
+	var policyRecord conflictPolicyRecord
+	if err := json.Unmarshal(conflictPolicyJSON, &policyRecord); err != nil {
+		return nil, err
+	}
+
+	appointments, err := queryAppointments(r.db, appointmentSelect+`
+		WHERE a.id IN (SELECT appointment_id FROM schedule_appointments WHERE schedule_id = $1)
+		GROUP BY a.id
+		ORDER BY a.start_time`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedTimes, err := r.findBlockedTimes(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.RestoreSchedule(id, ownerID, location, workingHours, []string(holidayCalendars), appointments, blockedTimes, policyRecord.toConflictPolicy(), version), nil
+}
+
+func (r *PostgresScheduleRepository) findBlockedTimes(scheduleID string) ([]valueobjects.BlockedTime, error) {
+	rows, err := r.db.Query(
+		`SELECT id, start_time, end_time, reason FROM schedule_blocked_times WHERE schedule_id = $1 ORDER BY position`,
+		scheduleID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]valueobjects.BlockedTime, 0)
+	for rows.Next() {
+		var (
+			id, reason         string
+			startTime, endTime time.Time
+		)
+		if err := rows.Scan(&id, &startTime, &endTime, &reason); err != nil {
+			return nil, err
+		}
+
+		timeRange, err := valueobjects.NewTimeRange(startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, valueobjects.RestoreBlockedTime(id, timeRange, reason))
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+)
+
+type PostgresUnitOfWork struct {
+	db *sql.DB
+}
+
+func NewPostgresUnitOfWork(db *sql.DB) *PostgresUnitOfWork {
+	return &PostgresUnitOfWork{
+		db: db,
+	}
+}
+
+func (u *PostgresUnitOfWork) Do(fn func(repos usecases.TxRepositories) error) error {
+	return withTx(u.db, func(tx sqlExecutor) error {
+		return fn(usecases.TxRepositories{
+			Appointments: &PostgresAppointmentRepository{db: tx},
+			Schedules:    &PostgresScheduleRepository{db: tx},
+			Series:       &PostgresAppointmentSeriesRepository{db: tx},
+		})
+	})
+}
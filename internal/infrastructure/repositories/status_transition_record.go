@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+// statusTransitionRecord is the JSON form of a recorded status change, shared
+// by the bolt records and the postgres status_history column.
+type statusTransitionRecord struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	ChangedBy string    `json:"changed_by,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+func newStatusTransitionRecords(history []entities.StatusTransition) []statusTransitionRecord {
+	result := make([]statusTransitionRecord, 0, len(history))
+	for _, transition := range history {
+		result = append(result, statusTransitionRecord{
+			From:      string(transition.From()),
+			To:        string(transition.To()),
+			ChangedBy: transition.ChangedBy(),
+			Reason:    transition.Reason(),
+			ChangedAt: transition.ChangedAt(),
+		})
+	}
+	return result
+}
+
+func toStatusHistory(records []statusTransitionRecord) []entities.StatusTransition {
+	result := make([]entities.StatusTransition, 0, len(records))
+	for _, record := range records {
+		result = append(result, entities.RestoreStatusTransition(
+			restoreStatus(record.From),
+			restoreStatus(record.To),
+			record.ChangedBy,
+			record.Reason,
+			record.ChangedAt,
+		))
+	}
+	return result
+}
+
+// restoreStatus reads a stored status, which is confirmed when it was stored
+// as scheduled.
+func restoreStatus(status string) entities.AppointmentStatus {
+	if entities.AppointmentStatus(status) == entities.StatusScheduled {
+		return entities.StatusConfirmed
+	}
+	return entities.AppointmentStatus(status)
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+)
+
+func TestStatusTransitionRecords(t *testing.T) {
+	changedAt := time.Date(2030, 3, 4, 9, 0, 0, 0, time.UTC)
+	history := []entities.StatusTransition{
+		entities.RestoreStatusTransition(entities.StatusTentative, entities.StatusConfirmed, "bob", "approved", changedAt),
+		entities.RestoreStatusTransition(entities.StatusConfirmed, entities.StatusCancelled, "", "", changedAt.Add(time.Hour)),
+	}
+
+	encoded, err := json.Marshal(newStatusTransitionRecords(history))
+	require.NoError(t, err)
+
+	var records []statusTransitionRecord
+	require.NoError(t, json.Unmarshal(encoded, &records))
+	assert.Equal(t, history, toStatusHistory(records))
+}
+
+// TestRestoreScheduledStatus reads history written before the lifecycle had
+// a tentative state, when confirmed appointments were stored as scheduled.
+func TestRestoreScheduledStatus(t *testing.T) {
+	assert.Equal(t, entities.StatusConfirmed, restoreStatus("scheduled"))
+	assert.Equal(t, entities.StatusTentative, restoreStatus("tentative"))
+
+	var records []statusTransitionRecord
+	require.NoError(t, json.Unmarshal([]byte(`[{"from": "scheduled", "to": "cancelled", "changed_at": "2030-03-04T09:00:00Z"}]`), &records))
+	history := toStatusHistory(records)
+	require.Len(t, history, 1)
+	assert.Equal(t, entities.StatusConfirmed, history[0].From())
+	assert.Equal(t, entities.StatusCancelled, history[0].To())
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+// workingHoursRecord is the JSON form of a working-hours template, shared by
+// the bolt records and the postgres working_hours column.
+type workingHoursRecord struct {
+	Weekly    map[string][]workingIntervalRecord `json:"weekly"`
+	Overrides map[string][]workingIntervalRecord `json:"overrides,omitempty"`
+}
+
+type workingIntervalRecord struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func newWorkingHoursRecord(workingHours valueobjects.WorkingHours) workingHoursRecord {
+	record := workingHoursRecord{
+		Weekly:    make(map[string][]workingIntervalRecord),
+		Overrides: make(map[string][]workingIntervalRecord),
+	}
+
+	for weekday, intervals := range workingHours.Weekly() {
+		record.Weekly[strings.ToLower(weekday.String())] = newWorkingIntervalRecords(intervals)
+	}
+
+	for date, intervals := range workingHours.Overrides() {
+		record.Overrides[date] = newWorkingIntervalRecords(intervals)
+	}
+
+	return record
+}
+
+func newWorkingIntervalRecords(intervals []valueobjects.WorkingInterval) []workingIntervalRecord {
+	result := make([]workingIntervalRecord, len(intervals))
+	for i, interval := range intervals {
+		result[i] = workingIntervalRecord{
+			Start: interval.Start().String(),
+			End:   interval.End().String(),
+		}
+	}
+	return result
+}
+
+// toWorkingHours falls back to the default template for records written
+// before schedules had weekly working hours.
+func (r workingHoursRecord) toWorkingHours() (valueobjects.WorkingHours, error) {
+	if r.Weekly == nil {
+		return valueobjects.DefaultWorkingHours(), nil
+	}
+
+	weekly := make(map[time.Weekday][]valueobjects.WorkingInterval, len(r.Weekly))
+	for name, intervals := range r.Weekly {
+		weekday, err := valueobjects.ParseWeekday(name)
+		if err != nil {
+			return valueobjects.WorkingHours{}, err
+		}
+
+		parsed, err := workingIntervals(intervals)
+		if err != nil {
+			return valueobjects.WorkingHours{}, err
+		}
+		weekly[weekday] = parsed
+	}
+
+	workingHours, err := valueobjects.NewWorkingHours(weekly)
+	if err != nil {
+		return valueobjects.WorkingHours{}, err
+	}
+
+	for date, intervals := range r.Overrides {
+		parsed, err := workingIntervals(intervals)
+		if err != nil {
+			return valueobjects.WorkingHours{}, err
+		}
+
+		workingHours, err = workingHours.WithOverride(date, parsed)
+		if err != nil {
+			return valueobjects.WorkingHours{}, err
+		}
+	}
+
+	return workingHours, nil
+}
+
+func workingIntervals(records []workingIntervalRecord) ([]valueobjects.WorkingInterval, error) {
+	result := make([]valueobjects.WorkingInterval, 0, len(records))
+	for _, record := range records {
+		start, err := valueobjects.ParseClockTime(record.Start)
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := valueobjects.ParseClockTime(record.End)
+		if err != nil {
+			return nil, err
+		}
+
+		interval, err := valueobjects.NewWorkingInterval(start, end)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, interval)
+	}
+	return result, nil
+}
@@ -3,7 +3,9 @@ package services
 import (
 	"fmt"
 	"log"
+	"strings"
 
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
 	"github.com/visiab/appointment-calculator/internal/domain/entities"
 )
 
@@ -19,19 +21,25 @@ func (s *ConsoleNotificationService) SendAppointmentCreated(appointment *entitie
 		appointment.Title(),
 		appointment.TimeRange().StartTime().Format("2006-01-02 15:04"),
 		appointment.TimeRange().EndTime().Format("2006-01-02 15:04"),
-		appointment.Attendees(),
+		appointment.AttendeeIDs(),
 	)
 	log.Println(message)
 	return nil
 }
 
-func (s *ConsoleNotificationService) SendAppointmentUpdated(appointment *entities.Appointment) error {
+func (s *ConsoleNotificationService) SendAppointmentUpdated(appointment *entities.Appointment, changes []usecases.AppointmentChange) error {
+	described := make([]string, len(changes))
+	for i, change := range changes {
+		described[i] = fmt.Sprintf("%s from %q to %q", change.Field, change.From, change.To)
+	}
+
 	message := fmt.Sprintf(
-		"[NOTIFICATION] Appointment Updated: %s (%s - %s) with attendees: %v",
+		"[NOTIFICATION] Appointment Updated: %s (%s - %s) with attendees: %v; changed %s",
 		appointment.Title(),
 		appointment.TimeRange().StartTime().Format("2006-01-02 15:04"),
 		appointment.TimeRange().EndTime().Format("2006-01-02 15:04"),
-		appointment.Attendees(),
+		appointment.AttendeeIDs(),
+		strings.Join(described, ", "),
 	)
 	log.Println(message)
 	return nil
@@ -43,7 +51,39 @@ func (s *ConsoleNotificationService) SendAppointmentCancelled(appointment *entit
 		appointment.Title(),
 		appointment.TimeRange().StartTime().Format("2006-01-02 15:04"),
 		appointment.TimeRange().EndTime().Format("2006-01-02 15:04"),
-		appointment.Attendees(),
+		appointment.AttendeeIDs(),
+	)
+	log.Println(message)
+	return nil
+}
+
+func (s *ConsoleNotificationService) SendAppointmentSeriesCreated(series *entities.AppointmentSeries, occurrences []*entities.Appointment) error {
+	message := fmt.Sprintf(
+		"[NOTIFICATION] Appointment Series Created: %s (%s from %s) with attendees: %v; %d occurrences booked",
+		series.Title(),
+		series.Rule(),
+		series.TimeRange().StartTime().Format("2006-01-02 15:04"),
+		series.AttendeeIDs(),
+		len(occurrences),
+	)
+	log.Println(message)
+	return nil
+}
+
+func (s *ConsoleNotificationService) SendAppointmentSeriesUpdated(series *entities.AppointmentSeries, changes []usecases.AppointmentChange, occurrences []*entities.Appointment) error {
+	described := make([]string, len(changes))
+	for i, change := range changes {
+		described[i] = fmt.Sprintf("%s from %q to %q", change.Field, change.From, change.To)
+	}
+
+	message := fmt.Sprintf(
+		"[NOTIFICATION] Appointment Series Updated: %s (%s from %s) with attendees: %v; changed %s; %d occurrences affected",
+		series.Title(),
+		series.Rule(),
+		series.TimeRange().StartTime().Format("2006-01-02 15:04"),
+		series.AttendeeIDs(),
+		strings.Join(described, ", "),
+		len(occurrences),
 	)
 	log.Println(message)
 	return nil
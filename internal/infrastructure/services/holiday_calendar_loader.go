@@ -0,0 +1,146 @@
+package services
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
+)
+
+//go:embed holidays/*.json
+var bundledHolidayCalendars embed.FS
+
+// holidayCalendarFile is the on-disk format of a holiday calendar. Each
+// holiday sets exactly one of: month and day (every year), date (once),
+// month, weekday and week (nth weekday, negative weeks counting from the end
+// of the month), or easter_offset (days from Easter Sunday).
+type holidayCalendarFile struct {
+	Region   string `json:"region"`
+	Name     string `json:"name"`
+	Holidays []struct {
+		Name         string `json:"name"`
+		Month        int    `json:"month,omitempty"`
+		Day          int    `json:"day,omitempty"`
+		Date         string `json:"date,omitempty"`
+		Weekday      string `json:"weekday,omitempty"`
+		Week         int    `json:"week,omitempty"`
+		EasterOffset *int   `json:"easter_offset,omitempty"`
+	} `json:"holidays"`
+}
+
+// LoadHolidayCalendars returns the bundled calendars followed by those in
+// path, which may be a single JSON file or a directory of them. Calendars
+// from path come last, so they replace bundled calendars for the same region.
+func LoadHolidayCalendars(path string) ([]*entities.HolidayCalendar, error) {
+	calendars, err := loadHolidayCalendarFS(bundledHolidayCalendars, "holidays")
+	if err != nil {
+		return nil, fmt.Errorf("bundled holiday calendars: %w", err)
+	}
+
+	if path == "" {
+		return calendars, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var userCalendars []*entities.HolidayCalendar
+	if info.IsDir() {
+		userCalendars, err = loadHolidayCalendarFS(os.DirFS(path), ".")
+	} else {
+		var calendar *entities.HolidayCalendar
+		calendar, err = loadHolidayCalendarFile(os.DirFS(filepath.Dir(path)), filepath.Base(path))
+		userCalendars = []*entities.HolidayCalendar{calendar}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append(calendars, userCalendars...), nil
+}
+
+func loadHolidayCalendarFS(fsys fs.FS, dir string) ([]*entities.HolidayCalendar, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	calendars := make([]*entities.HolidayCalendar, 0, len(names))
+	for _, name := range names {
+		calendar, err := loadHolidayCalendarFile(fsys, filepath.ToSlash(filepath.Join(dir, name)))
+		if err != nil {
+			return nil, err
+		}
+		calendars = append(calendars, calendar)
+	}
+	return calendars, nil
+}
+
+func loadHolidayCalendarFile(fsys fs.FS, name string) (*entities.HolidayCalendar, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var file holidayCalendarFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	rules := make([]entities.HolidayRule, 0, len(file.Holidays))
+	for _, holiday := range file.Holidays {
+		var (
+			rule entities.HolidayRule
+			err  error
+		)
+
+		switch {
+		case holiday.EasterOffset != nil:
+			rule = entities.NewEasterHolidayRule(holiday.Name, *holiday.EasterOffset)
+		case holiday.Date != "":
+			var date time.Time
+			date, err = time.Parse(valueobjects.DateLayout, holiday.Date)
+			rule = entities.NewDatedHolidayRule(holiday.Name, date)
+		case holiday.Weekday != "":
+			var weekday time.Weekday
+			weekday, err = valueobjects.ParseWeekday(holiday.Weekday)
+			if err == nil {
+				rule, err = entities.NewWeekdayHolidayRule(holiday.Name, time.Month(holiday.Month), weekday, holiday.Week)
+			}
+		case holiday.Month != 0:
+			rule, err = entities.NewFixedHolidayRule(holiday.Name, time.Month(holiday.Month), holiday.Day)
+		default:
+			err = errors.New("holiday has no date rule: " + holiday.Name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	calendar, err := entities.NewHolidayCalendar(file.Region, file.Name, rules)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return calendar, nil
+}
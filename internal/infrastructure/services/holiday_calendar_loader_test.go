@@ -0,0 +1,136 @@
+package services_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	domainServices "github.com/visiab/appointment-calculator/internal/domain/services"
+	"github.com/visiab/appointment-calculator/internal/infrastructure/services"
+)
+
+func writeHolidayCalendar(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+// TestLoadBundledHolidayCalendars checks each kind of rule in the bundled
+// calendars against the 2030 dates. Easter Sunday 2030 is April 21.
+func TestLoadBundledHolidayCalendars(t *testing.T) {
+	calendars, err := services.LoadHolidayCalendars("")
+	require.NoError(t, err)
+
+	holidays := domainServices.NewHolidayCalendarService(calendars)
+	regions := make([]string, 0)
+	for _, calendar := range holidays.Calendars() {
+		regions = append(regions, calendar.Region())
+	}
+	assert.Equal(t, []string{"DE", "FR", "GB", "US"}, regions)
+
+	tests := []struct {
+		region string
+		date   string
+		name   string
+	}{
+		{region: "US", date: "2030-01-01", name: "New Year's Day"},
+		{region: "US", date: "2030-05-27", name: "Memorial Day"},
+		{region: "US", date: "2030-11-28", name: "Thanksgiving Day"},
+		{region: "de", date: "2030-04-19", name: "Karfreitag"},
+		{region: "DE", date: "2030-04-22", name: "Ostermontag"},
+		{region: "DE", date: "2030-06-10", name: "Pfingstmontag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region+" "+tt.date, func(t *testing.T) {
+			calendar, exists := holidays.Calendar(tt.region)
+			require.True(t, exists)
+
+			var found bool
+			for _, holiday := range calendar.HolidaysIn(2030) {
+				if holiday.Date() == tt.date {
+					found = true
+					assert.Equal(t, tt.name, holiday.Name())
+				}
+			}
+			assert.True(t, found, "no holiday on %s", tt.date)
+		})
+	}
+}
+
+func TestLoadUserHolidayCalendars(t *testing.T) {
+	dir := t.TempDir()
+	writeHolidayCalendar(t, dir, "de.json", `{
+		"region": "DE",
+		"name": "Berlin",
+		"holidays": [
+			{"name": "Frauentag", "month": 3, "day": 8},
+			{"name": "Company offsite", "date": "2030-09-13"}
+		]
+	}`)
+	writeHolidayCalendar(t, dir, "notes.txt", "not a calendar")
+	path := writeHolidayCalendar(t, t.TempDir(), "acme.json", `{
+		"region": "ACME",
+		"name": "Acme Corp",
+		"holidays": [{"name": "Founders' Day", "month": 6, "weekday": "friday", "week": -1}]
+	}`)
+
+	t.Run("directory replaces bundled calendars", func(t *testing.T) {
+		calendars, err := services.LoadHolidayCalendars(dir)
+		require.NoError(t, err)
+
+		calendar, exists := domainServices.NewHolidayCalendarService(calendars).Calendar("DE")
+		require.True(t, exists)
+		assert.Equal(t, "Berlin", calendar.Name())
+
+		holidays := calendar.HolidaysIn(2030)
+		require.Len(t, holidays, 2)
+		assert.Equal(t, "2030-03-08", holidays[0].Date())
+		assert.Equal(t, "2030-09-13", holidays[1].Date())
+		assert.Len(t, calendar.HolidaysIn(2031), 1, "dated holidays happen once")
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		calendars, err := services.LoadHolidayCalendars(path)
+		require.NoError(t, err)
+
+		holidays := domainServices.NewHolidayCalendarService(calendars)
+		_, exists := holidays.Calendar("US")
+		assert.True(t, exists, "bundled calendars are kept")
+
+		calendar, exists := holidays.Calendar("acme")
+		require.True(t, exists)
+		holiday, ok := calendar.HolidayOn(2030, 6, 28)
+		require.True(t, ok)
+		assert.Equal(t, "Founders' Day", holiday.Name())
+	})
+}
+
+func TestLoadHolidayCalendarsRejectsInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "malformed JSON", content: `{"region": "XX",`},
+		{name: "no region", content: `{"name": "Nowhere", "holidays": []}`},
+		{name: "no date rule", content: `{"region": "XX", "holidays": [{"name": "Someday"}]}`},
+		{name: "invalid date", content: `{"region": "XX", "holidays": [{"name": "Someday", "date": "13/09/2030"}]}`},
+		{name: "invalid weekday", content: `{"region": "XX", "holidays": [{"name": "Someday", "month": 6, "weekday": "funday", "week": 1}]}`},
+		{name: "day out of range", content: `{"region": "XX", "holidays": [{"name": "Someday", "month": 2, "day": 30}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeHolidayCalendar(t, t.TempDir(), "xx.json", tt.content)
+			_, err := services.LoadHolidayCalendars(path)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := services.LoadHolidayCalendars(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
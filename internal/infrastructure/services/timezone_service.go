@@ -1,12 +1,15 @@
 package services
 
 import (
-	"errors"
+	"sync"
 	"time"
+
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
 )
 
 type TimezoneService struct {
 	cache map[string]*time.Location
+	mu    sync.RWMutex
 }
 
 func NewTimezoneService() *TimezoneService {
@@ -21,17 +24,22 @@ func (s *TimezoneService) GetLocation(timezone string) (*time.Location, error) {
 	}
 
 	// Check cache first
-	if location, exists := s.cache[timezone]; exists {
+	s.mu.RLock()
+	location, exists := s.cache[timezone]
+	s.mu.RUnlock()
+	if exists {
 		return location, nil
 	}
 
 	// Load and cache the location
 	location, err := time.LoadLocation(timezone)
 	if err != nil {
-		return nil, errors.New("invalid timezone: " + timezone)
+		return nil, domainerrors.Invalid("invalid timezone: " + timezone)
 	}
 
+	s.mu.Lock()
 	s.cache[timezone] = location
+	s.mu.Unlock()
 	return location, nil
 }
 
@@ -22,9 +22,12 @@ func SetupRoutes(router *gin.Engine) {
 	{
 		// This is where we would set up dependency injection
 		// For now, we'll create placeholder controllers
-		appointmentController := controllers.NewAppointmentController(nil, nil)
-		scheduleController := controllers.NewScheduleController(nil)
-		participantController := controllers.NewParticipantController()
+		appointmentController := controllers.NewAppointmentController(nil, nil, nil, nil, nil, nil, nil)
+		appointmentSeriesController := controllers.NewAppointmentSeriesController(nil, nil, nil, nil)
+		scheduleController := controllers.NewScheduleController(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		participantController := controllers.NewParticipantController(nil, nil, nil, nil, nil, nil, nil)
+		holidayController := controllers.NewHolidayController(nil, nil)
+		recurrenceController := controllers.NewRecurrenceController(nil)
 
 		// Appointment routes
 		appointments := v1.Group("/appointments")
@@ -34,15 +37,39 @@ func SetupRoutes(router *gin.Engine) {
 			appointments.GET("/:id", appointmentController.GetAppointment)
 			appointments.PUT("/:id", appointmentController.UpdateAppointment)
 			appointments.DELETE("/:id", appointmentController.CancelAppointment)
+			appointments.PUT("/:id/attendees/:participant_id/response", appointmentController.RespondToAppointment)
+			appointments.POST("/:id/confirm", appointmentController.ConfirmAppointment)
+			appointments.POST("/:id/start", appointmentController.StartAppointment)
+			appointments.POST("/:id/complete", appointmentController.CompleteAppointment)
+			appointments.POST("/:id/no-show", appointmentController.MarkAppointmentNoShow)
+			appointments.POST("/:id/reopen", appointmentController.ReopenAppointment)
+		}
+
+		// Appointment series routes
+		appointmentSeries := v1.Group("/appointment-series")
+		{
+			appointmentSeries.POST("", appointmentSeriesController.CreateSeries)
+			appointmentSeries.GET("/:id", appointmentSeriesController.GetSeries)
+			appointmentSeries.DELETE("/:id", appointmentSeriesController.CancelSeries)
+			appointmentSeries.PUT("/:id/occurrences/:recurrence_id", appointmentSeriesController.UpdateOccurrence)
+			appointmentSeries.DELETE("/:id/occurrences/:recurrence_id", appointmentSeriesController.CancelOccurrence)
 		}
 
 		// Schedule routes
 		schedules := v1.Group("/schedules")
 		{
+			schedules.POST("", scheduleController.CreateSchedule)
 			schedules.POST("/availability", scheduleController.FindAvailableTimeSlots)
 			schedules.GET("/:owner_id/overview", scheduleController.GetScheduleOverview)
 			schedules.GET("/:owner_id/detail", scheduleController.GetScheduleDetail)
+			schedules.PUT("/:owner_id/working-hours", scheduleController.UpdateWorkingHours)
+			schedules.PUT("/:owner_id/working-hours/overrides/:date", scheduleController.SetWorkingHoursOverride)
+			schedules.DELETE("/:owner_id/working-hours/overrides/:date", scheduleController.RemoveWorkingHoursOverride)
+			schedules.PUT("/:owner_id/holiday-calendars", scheduleController.UpdateHolidayCalendars)
+			schedules.PUT("/:owner_id/conflict-policy", scheduleController.UpdateConflictPolicy)
 			schedules.POST("/:owner_id/blocked-times", scheduleController.AddBlockedTime)
+			schedules.GET("/:owner_id/blocked-times", scheduleController.ListBlockedTimes)
+			schedules.DELETE("/:owner_id/blocked-times/:blocked_time_id", scheduleController.RemoveBlockedTime)
 		}
 
 		// Participant routes
@@ -51,8 +78,23 @@ func SetupRoutes(router *gin.Engine) {
 			participants.POST("", participantController.CreateParticipant)
 			participants.GET("/:id", participantController.GetParticipant)
 			participants.PUT("/:id", participantController.UpdateParticipant)
+			participants.DELETE("/:id", participantController.DeleteParticipant)
 			participants.POST("/:id/availability", participantController.AddAvailability)
 			participants.GET("/:id/availability", participantController.GetAvailability)
+			participants.DELETE("/:id/availability/:availability_id", participantController.RemoveAvailability)
+		}
+
+		// Recurrence routes
+		recurrences := v1.Group("/recurrences")
+		{
+			recurrences.POST("/preview", recurrenceController.PreviewRecurrence)
+		}
+
+		// Holiday calendar routes
+		holidayCalendars := v1.Group("/holiday-calendars")
+		{
+			holidayCalendars.GET("", holidayController.ListCalendars)
+			holidayCalendars.GET("/:region/holidays", holidayController.ListHolidays)
 		}
 	}
 }
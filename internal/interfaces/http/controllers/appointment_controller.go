@@ -2,95 +2,112 @@ package controllers
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/visiab/appointment-calculator/internal/application/dto"
 	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/interfaces/http/presenters"
 )
 
 type AppointmentController struct {
-	createUseCase *usecases.CreateAppointmentUseCase
-	updateUseCase *usecases.UpdateAppointmentUseCase
+	createUseCase  *usecases.CreateAppointmentUseCase
+	updateUseCase  *usecases.UpdateAppointmentUseCase
+	respondUseCase *usecases.RespondToAppointmentUseCase
+	statusUseCase  *usecases.ChangeAppointmentStatusUseCase
+	getUseCase     *usecases.GetAppointmentUseCase
+	listUseCase    *usecases.ListAppointmentsUseCase
+	presenter      *presenters.AppointmentPresenter
 }
 
 func NewAppointmentController(
 	createUseCase *usecases.CreateAppointmentUseCase,
 	updateUseCase *usecases.UpdateAppointmentUseCase,
+	respondUseCase *usecases.RespondToAppointmentUseCase,
+	statusUseCase *usecases.ChangeAppointmentStatusUseCase,
+	getUseCase *usecases.GetAppointmentUseCase,
+	listUseCase *usecases.ListAppointmentsUseCase,
+	presenter *presenters.AppointmentPresenter,
 ) *AppointmentController {
 	return &AppointmentController{
-		createUseCase: createUseCase,
-		updateUseCase: updateUseCase,
+		createUseCase:  createUseCase,
+		updateUseCase:  updateUseCase,
+		respondUseCase: respondUseCase,
+		statusUseCase:  statusUseCase,
+		getUseCase:     getUseCase,
+		listUseCase:    listUseCase,
+		presenter:      presenter,
 	}
 }
 
 func (c *AppointmentController) CreateAppointment(ctx *gin.Context) {
 	var request dto.CreateAppointmentRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		respondBindError(ctx, err)
 		return
 	}
 
 	response, err := c.createUseCase.Execute(request)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to create appointment",
-			"details": err.Error(),
-		})
+		respondError(ctx, err)
 		return
 	}
 
+	setETag(ctx, response.Version)
 	ctx.JSON(http.StatusCreated, response)
 }
 
 func (c *AppointmentController) UpdateAppointment(ctx *gin.Context) {
 	appointmentID := ctx.Param("id")
 	if appointmentID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Appointment ID is required",
-		})
+		respondProblem(ctx, http.StatusBadRequest, "Appointment ID is required")
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(ctx)
+	if err != nil {
+		respondIfMatchError(ctx, err)
 		return
 	}
 
 	var request dto.UpdateAppointmentRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		respondBindError(ctx, err)
 		return
 	}
 
-	response, err := c.updateUseCase.Execute(appointmentID, request)
+	response, err := c.updateUseCase.Execute(appointmentID, request, expectedVersion)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to update appointment",
-			"details": err.Error(),
-		})
+		respondError(ctx, err)
 		return
 	}
 
+	setETag(ctx, response.Version)
 	ctx.JSON(http.StatusOK, response)
 }
 
 func (c *AppointmentController) CancelAppointment(ctx *gin.Context) {
 	appointmentID := ctx.Param("id")
 	if appointmentID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Appointment ID is required",
-		})
+		respondProblem(ctx, http.StatusBadRequest, "Appointment ID is required")
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(ctx)
+	if err != nil {
+		respondIfMatchError(ctx, err)
+		return
+	}
+
+	// Who cancelled and why are optional query parameters
+	var request dto.ChangeAppointmentStatusRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		respondBindError(ctx, err)
 		return
 	}
 
-	err := c.updateUseCase.Cancel(appointmentID)
+	err = c.updateUseCase.Cancel(appointmentID, request, expectedVersion)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to cancel appointment",
-			"details": err.Error(),
-		})
+		respondError(ctx, err)
 		return
 	}
 
@@ -99,34 +116,122 @@ func (c *AppointmentController) CancelAppointment(ctx *gin.Context) {
 	})
 }
 
+// RespondToAppointment records an attendee's response to an invitation.
+func (c *AppointmentController) RespondToAppointment(ctx *gin.Context) {
+	appointmentID := ctx.Param("id")
+	participantID := ctx.Param("participant_id")
+	if appointmentID == "" || participantID == "" {
+		respondProblem(ctx, http.StatusBadRequest, "Appointment ID and participant ID are required")
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(ctx)
+	if err != nil {
+		respondIfMatchError(ctx, err)
+		return
+	}
+
+	var request dto.RespondToAppointmentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	appointment, err := c.respondUseCase.Execute(appointmentID, participantID, request, expectedVersion)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	setETag(ctx, appointment.Version())
+	ctx.JSON(http.StatusOK, c.presenter.PresentAppointment(appointment))
+}
+
+func (c *AppointmentController) ConfirmAppointment(ctx *gin.Context) {
+	c.changeStatus(ctx, usecases.ActionConfirm)
+}
+
+func (c *AppointmentController) StartAppointment(ctx *gin.Context) {
+	c.changeStatus(ctx, usecases.ActionStart)
+}
+
+func (c *AppointmentController) CompleteAppointment(ctx *gin.Context) {
+	c.changeStatus(ctx, usecases.ActionComplete)
+}
+
+func (c *AppointmentController) MarkAppointmentNoShow(ctx *gin.Context) {
+	c.changeStatus(ctx, usecases.ActionNoShow)
+}
+
+func (c *AppointmentController) ReopenAppointment(ctx *gin.Context) {
+	c.changeStatus(ctx, usecases.ActionReopen)
+}
+
+// changeStatus handles the lifecycle actions, whose body optionally says who
+// took the action and why.
+func (c *AppointmentController) changeStatus(ctx *gin.Context, action usecases.StatusAction) {
+	appointmentID := ctx.Param("id")
+	if appointmentID == "" {
+		respondProblem(ctx, http.StatusBadRequest, "Appointment ID is required")
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(ctx)
+	if err != nil {
+		respondIfMatchError(ctx, err)
+		return
+	}
+
+	var request dto.ChangeAppointmentStatusRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			respondBindError(ctx, err)
+			return
+		}
+	}
+
+	appointment, err := c.statusUseCase.Execute(appointmentID, action, request, expectedVersion)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	setETag(ctx, appointment.Version())
+	ctx.JSON(http.StatusOK, c.presenter.PresentAppointment(appointment))
+}
+
 func (c *AppointmentController) GetAppointment(ctx *gin.Context) {
 	appointmentID := ctx.Param("id")
 	if appointmentID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Appointment ID is required",
-		})
+		respondProblem(ctx, http.StatusBadRequest, "Appointment ID is required")
 		return
 	}
 
-	// This would require a GetAppointmentUseCase, for now return not implemented
-	ctx.JSON(http.StatusNotImplemented, gin.H{
-		"error": "Get appointment endpoint not implemented yet",
-	})
+	appointment, err := c.getUseCase.Execute(appointmentID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	setETag(ctx, appointment.Version())
+	ctx.JSON(http.StatusOK, c.presenter.PresentAppointment(appointment))
 }
 
 func (c *AppointmentController) ListAppointments(ctx *gin.Context) {
 	// Parse query parameters
-	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
-	participantID := ctx.Query("participant_id")
-
-	// This would require a ListAppointmentsUseCase, for now return not implemented
-	ctx.JSON(http.StatusNotImplemented, gin.H{
-		"error": "List appointments endpoint not implemented yet",
-		"params": gin.H{
-			"page":           page,
-			"limit":          limit,
-			"participant_id": participantID,
-		},
-	})
+	var request dto.ListAppointmentsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	result, err := c.listUseCase.Execute(request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	response := c.presenter.PresentAppointmentList(result.Appointments, result.Total, result.Page, result.Limit)
+	response.NextCursor = result.NextCursor
+	ctx.JSON(http.StatusOK, response)
 }
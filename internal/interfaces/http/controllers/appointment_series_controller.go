@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/interfaces/http/presenters"
+)
+
+type AppointmentSeriesController struct {
+	createUseCase *usecases.CreateAppointmentSeriesUseCase
+	getUseCase    *usecases.GetAppointmentSeriesUseCase
+	updateUseCase *usecases.UpdateAppointmentSeriesUseCase
+	presenter     *presenters.AppointmentPresenter
+}
+
+func NewAppointmentSeriesController(
+	createUseCase *usecases.CreateAppointmentSeriesUseCase,
+	getUseCase *usecases.GetAppointmentSeriesUseCase,
+	updateUseCase *usecases.UpdateAppointmentSeriesUseCase,
+	presenter *presenters.AppointmentPresenter,
+) *AppointmentSeriesController {
+	return &AppointmentSeriesController{
+		createUseCase: createUseCase,
+		getUseCase:    getUseCase,
+		updateUseCase: updateUseCase,
+		presenter:     presenter,
+	}
+}
+
+func (c *AppointmentSeriesController) CreateSeries(ctx *gin.Context) {
+	var request dto.CreateAppointmentSeriesRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	series, occurrences, err := c.createUseCase.Execute(request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	setETag(ctx, series.Version())
+	ctx.JSON(http.StatusCreated, c.presenter.PresentAppointmentSeries(series, occurrences))
+}
+
+func (c *AppointmentSeriesController) GetSeries(ctx *gin.Context) {
+	series, occurrences, err := c.getUseCase.Execute(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	setETag(ctx, series.Version())
+	ctx.JSON(http.StatusOK, c.presenter.PresentAppointmentSeries(series, occurrences))
+}
+
+// UpdateOccurrence edits the occurrence named by recurrence_id, the original
+// start of the occurrence, and depending on the scope the ones after it or
+// the whole series. After a split the response is the new series.
+func (c *AppointmentSeriesController) UpdateOccurrence(ctx *gin.Context) {
+	recurrenceID, err := entities.ParseRecurrenceID(ctx.Param("recurrence_id"))
+	if err != nil {
+		respondProblem(ctx, http.StatusBadRequest, "Invalid recurrence ID: "+err.Error())
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(ctx)
+	if err != nil {
+		respondIfMatchError(ctx, err)
+		return
+	}
+
+	var request dto.UpdateAppointmentSeriesRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	series, occurrences, err := c.updateUseCase.Execute(ctx.Param("id"), recurrenceID, request, expectedVersion)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	setETag(ctx, series.Version())
+	ctx.JSON(http.StatusOK, c.presenter.PresentAppointmentSeries(series, occurrences))
+}
+
+// CancelOccurrence cancels the occurrence named by recurrence_id, or with
+// ?scope=following or ?scope=all the ones after it or the whole series.
+func (c *AppointmentSeriesController) CancelOccurrence(ctx *gin.Context) {
+	recurrenceID, err := entities.ParseRecurrenceID(ctx.Param("recurrence_id"))
+	if err != nil {
+		respondProblem(ctx, http.StatusBadRequest, "Invalid recurrence ID: "+err.Error())
+		return
+	}
+
+	scope, err := usecases.ParseSeriesEditScope(ctx.DefaultQuery("scope", string(usecases.ScopeThisOccurrence)))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	c.cancel(ctx, recurrenceID, scope)
+}
+
+// CancelSeries cancels every occurrence of the series.
+func (c *AppointmentSeriesController) CancelSeries(ctx *gin.Context) {
+	c.cancel(ctx, time.Time{}, usecases.ScopeAllOccurrences)
+}
+
+func (c *AppointmentSeriesController) cancel(ctx *gin.Context, recurrenceID time.Time, scope usecases.SeriesEditScope) {
+	expectedVersion, err := parseIfMatch(ctx)
+	if err != nil {
+		respondIfMatchError(ctx, err)
+		return
+	}
+
+	err = c.updateUseCase.Cancel(ctx.Param("id"), recurrenceID, scope, expectedVersion)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Appointment series cancelled successfully",
+	})
+}
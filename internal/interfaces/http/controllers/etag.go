@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+)
+
+var errMalformedIfMatch = errors.New("If-Match must be \"*\" or a single quoted ETag")
+
+// ETags carry the entity version, e.g. "3".
+func setETag(ctx *gin.Context, version int) {
+	ctx.Header("ETag", fmt.Sprintf("%q", strconv.Itoa(version)))
+}
+
+// parseIfMatch returns the version required by the If-Match header, or nil
+// when the header is absent or "*", which any current version matches.
+//
+// Tags are compared strongly (RFC 9110 §13.1.1): a weak tag, or one this API
+// never issues, can't match any version and fails with
+// usecases.ErrPreconditionFailed.
+func parseIfMatch(ctx *gin.Context) (*int, error) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	weak := strings.HasPrefix(header, "W/")
+	opaque, ok := unquoteETag(strings.TrimPrefix(header, "W/"))
+	if !ok {
+		return nil, errMalformedIfMatch
+	}
+	if weak {
+		return nil, fmt.Errorf("%w: weak ETag W/%s never matches strongly", usecases.ErrPreconditionFailed, header[2:])
+	}
+
+	// "03" isn't the tag issued for version 3, so compare the text as well
+	version, err := strconv.Atoi(opaque)
+	if err != nil || version < 0 || strconv.Itoa(version) != opaque {
+		return nil, fmt.Errorf("%w: %s is not an ETag issued by this API", usecases.ErrPreconditionFailed, header)
+	}
+	return &version, nil
+}
+
+// unquoteETag returns the opaque part of a strong entity tag. Unlike a Go
+// string literal, an entity tag has no escapes and can't contain quotes,
+// spaces or control characters.
+func unquoteETag(tag string) (string, bool) {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return "", false
+	}
+
+	opaque := tag[1 : len(tag)-1]
+	for i := 0; i < len(opaque); i++ {
+		if c := opaque[i]; c <= ' ' || c == '"' || c == 0x7f {
+			return "", false
+		}
+	}
+	return opaque, true
+}
+
+// respondIfMatchError answers 400 for an If-Match header that can't be
+// parsed, and 412 for one that can't match.
+func respondIfMatchError(ctx *gin.Context, err error) {
+	if errors.Is(err, usecases.ErrPreconditionFailed) {
+		respondError(ctx, err)
+		return
+	}
+	respondProblem(ctx, http.StatusBadRequest, "Invalid If-Match header: "+err.Error())
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		header  string
+		version *int
+		status  int
+	}{
+		{name: "absent"},
+		{name: "any version", header: "*"},
+		{name: "strong", header: `"3"`, version: intPtr(3)},
+		{name: "weak", header: `W/"3"`, status: http.StatusPreconditionFailed},
+		{name: "not a version", header: `"abc"`, status: http.StatusPreconditionFailed},
+		{name: "not as issued", header: `"03"`, status: http.StatusPreconditionFailed},
+		{name: "unquoted", header: "3", status: http.StatusBadRequest},
+		{name: "escaped quote", header: `"3\""`, status: http.StatusBadRequest},
+		{name: "list", header: `"3", "4"`, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/appointments/a1", nil)
+			if tt.header != "" {
+				ctx.Request.Header.Set("If-Match", tt.header)
+			}
+
+			version, err := parseIfMatch(ctx)
+			if tt.status == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, tt.version, version)
+				return
+			}
+
+			require.Error(t, err)
+			respondIfMatchError(ctx, err)
+			assert.Equal(t, tt.status, recorder.Code)
+		})
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
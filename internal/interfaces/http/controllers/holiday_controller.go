@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+)
+
+type HolidayController struct {
+	listCalendarsUseCase *usecases.ListHolidayCalendarsUseCase
+	listHolidaysUseCase  *usecases.ListHolidaysUseCase
+}
+
+func NewHolidayController(
+	listCalendarsUseCase *usecases.ListHolidayCalendarsUseCase,
+	listHolidaysUseCase *usecases.ListHolidaysUseCase,
+) *HolidayController {
+	return &HolidayController{
+		listCalendarsUseCase: listCalendarsUseCase,
+		listHolidaysUseCase:  listHolidaysUseCase,
+	}
+}
+
+func (c *HolidayController) ListCalendars(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.listCalendarsUseCase.Execute())
+}
+
+func (c *HolidayController) ListHolidays(ctx *gin.Context) {
+	var request dto.ListHolidaysRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	response, err := c.listHolidaysUseCase.Execute(ctx.Param("region"), request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -5,125 +5,166 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
 )
 
 type ParticipantController struct {
-	// This would have use cases for participant management
+	createUseCase *usecases.CreateParticipantUseCase
+	getUseCase    *usecases.GetParticipantUseCase
+	updateUseCase *usecases.UpdateParticipantUseCase
+	deleteUseCase *usecases.DeleteParticipantUseCase
+
+	addAvailabilityUseCase    *usecases.AddAvailabilityUseCase
+	getAvailabilityUseCase    *usecases.GetAvailabilityUseCase
+	removeAvailabilityUseCase *usecases.RemoveAvailabilityUseCase
 }
 
-func NewParticipantController() *ParticipantController {
-	return &ParticipantController{}
+func NewParticipantController(
+	createUseCase *usecases.CreateParticipantUseCase,
+	getUseCase *usecases.GetParticipantUseCase,
+	updateUseCase *usecases.UpdateParticipantUseCase,
+	deleteUseCase *usecases.DeleteParticipantUseCase,
+	addAvailabilityUseCase *usecases.AddAvailabilityUseCase,
+	getAvailabilityUseCase *usecases.GetAvailabilityUseCase,
+	removeAvailabilityUseCase *usecases.RemoveAvailabilityUseCase,
+) *ParticipantController {
+	return &ParticipantController{
+		createUseCase:             createUseCase,
+		getUseCase:                getUseCase,
+		updateUseCase:             updateUseCase,
+		deleteUseCase:             deleteUseCase,
+		addAvailabilityUseCase:    addAvailabilityUseCase,
+		getAvailabilityUseCase:    getAvailabilityUseCase,
+		removeAvailabilityUseCase: removeAvailabilityUseCase,
+	}
 }
 
 func (c *ParticipantController) CreateParticipant(ctx *gin.Context) {
 	var request dto.CreateParticipantRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		respondBindError(ctx, err)
 		return
 	}
 
-	// This would require a CreateParticipantUseCase
-	ctx.JSON(http.StatusNotImplemented, gin.H{
-		"error":   "Create participant endpoint not implemented yet",
-		"request": request,
-	})
+	response, err := c.createUseCase.Execute(request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response)
 }
 
 func (c *ParticipantController) GetParticipant(ctx *gin.Context) {
 	participantID := ctx.Param("id")
 	if participantID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Participant ID is required",
-		})
+		respondProblem(ctx, http.StatusBadRequest, "Participant ID is required")
+		return
+	}
+
+	response, err := c.getUseCase.Execute(participantID)
+	if err != nil {
+		respondError(ctx, err)
 		return
 	}
 
-	// This would require a GetParticipantUseCase
-	ctx.JSON(http.StatusNotImplemented, gin.H{
-		"error":         "Get participant endpoint not implemented yet",
-		"participant_id": participantID,
-	})
+	ctx.JSON(http.StatusOK, response)
 }
 
 func (c *ParticipantController) UpdateParticipant(ctx *gin.Context) {
 	participantID := ctx.Param("id")
 	if participantID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Participant ID is required",
-		})
+		respondProblem(ctx, http.StatusBadRequest, "Participant ID is required")
 		return
 	}
 
 	var request dto.UpdateParticipantRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		respondBindError(ctx, err)
+		return
+	}
+
+	response, err := c.updateUseCase.Execute(participantID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *ParticipantController) DeleteParticipant(ctx *gin.Context) {
+	participantID := ctx.Param("id")
+	if participantID == "" {
+		respondProblem(ctx, http.StatusBadRequest, "Participant ID is required")
+		return
+	}
+
+	if err := c.deleteUseCase.Execute(participantID); err != nil {
+		respondError(ctx, err)
 		return
 	}
 
-	// This would require an UpdateParticipantUseCase
-	ctx.JSON(http.StatusNotImplemented, gin.H{
-		"error": "Update participant endpoint not implemented yet",
-		"params": gin.H{
-			"participant_id": participantID,
-			"request":        request,
-		},
-	})
+	ctx.Status(http.StatusNoContent)
 }
 
 func (c *ParticipantController) AddAvailability(ctx *gin.Context) {
 	participantID := ctx.Param("id")
 	if participantID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Participant ID is required",
-		})
+		respondProblem(ctx, http.StatusBadRequest, "Participant ID is required")
 		return
 	}
 
 	var request dto.AddAvailabilityRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		respondBindError(ctx, err)
 		return
 	}
 
-	// This would require an AddAvailabilityUseCase
-	ctx.JSON(http.StatusNotImplemented, gin.H{
-		"error": "Add availability endpoint not implemented yet",
-		"params": gin.H{
-			"participant_id": participantID,
-			"request":        request,
-		},
-	})
+	response, err := c.addAvailabilityUseCase.Execute(participantID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response)
 }
 
 func (c *ParticipantController) GetAvailability(ctx *gin.Context) {
 	participantID := ctx.Param("id")
 	if participantID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Participant ID is required",
-		})
+		respondProblem(ctx, http.StatusBadRequest, "Participant ID is required")
 		return
 	}
 
 	// Parse query parameters for date range
-	startDate := ctx.Query("start_date")
-	endDate := ctx.Query("end_date")
-
-	// This would require a GetAvailabilityUseCase
-	ctx.JSON(http.StatusNotImplemented, gin.H{
-		"error": "Get availability endpoint not implemented yet",
-		"params": gin.H{
-			"participant_id": participantID,
-			"start_date":     startDate,
-			"end_date":       endDate,
-		},
-	})
+	var request dto.GetAvailabilityRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	response, err := c.getAvailabilityUseCase.Execute(participantID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *ParticipantController) RemoveAvailability(ctx *gin.Context) {
+	participantID := ctx.Param("id")
+	availabilityID := ctx.Param("availability_id")
+	if participantID == "" || availabilityID == "" {
+		respondProblem(ctx, http.StatusBadRequest, "Participant ID and availability ID are required")
+		return
+	}
+
+	if err := c.removeAvailabilityUseCase.Execute(participantID, availabilityID); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
 }
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+)
+
+const problemContentType = "application/problem+json"
+
+func init() {
+	// Name invalid values by their JSON or query parameter names rather than
+	// the Go field names
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(requestFieldName)
+	}
+}
+
+// respondError writes err as a problem response: 412 when If-Match didn't
+// match, 404 for a missing entity, 409 for a clash with the current state,
+// 422 for invalid input, and 500 for anything else. The details of a 500
+// stay in the server log.
+func respondError(ctx *gin.Context, err error) {
+	status := errorStatus(err)
+	if status == http.StatusInternalServerError {
+		ctx.Error(err)
+		respondProblem(ctx, status, "")
+		return
+	}
+
+	problem := newProblem(ctx, status, err.Error())
+
+	var invalid *domainerrors.Error
+	if errors.As(err, &invalid) && invalid.Kind == domainerrors.KindValidation && invalid.Field != "" {
+		problem.Errors = []dto.FieldErrorResponse{{Field: invalid.Field, Message: invalid.Error()}}
+	}
+
+	var conflictErr *usecases.ConflictError
+	if errors.As(err, &conflictErr) {
+		problem.Conflicts = conflictErr.Conflicts
+		problem.Alternatives = conflictErr.Alternatives
+	}
+
+	writeProblem(ctx, problem)
+}
+
+func errorStatus(err error) int {
+	kind, _ := domainerrors.KindOf(err)
+	switch kind {
+	case domainerrors.KindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	case domainerrors.KindNotFound:
+		return http.StatusNotFound
+	case domainerrors.KindConflict:
+		return http.StatusConflict
+	case domainerrors.KindValidation:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondBindError reports a request body or query that couldn't be bound:
+// 422 listing every value that breaks a binding rule, or 400 when the
+// request can't be parsed at all.
+func respondBindError(ctx *gin.Context, err error) {
+	var invalid validator.ValidationErrors
+	if !errors.As(err, &invalid) {
+		respondProblem(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	problem := newProblem(ctx, http.StatusUnprocessableEntity, "The request has invalid values")
+	for _, fieldErr := range invalid {
+		problem.Errors = append(problem.Errors, dto.FieldErrorResponse{
+			Field:   fieldPath(fieldErr),
+			Message: fieldMessage(fieldErr),
+		})
+	}
+	writeProblem(ctx, problem)
+}
+
+func respondProblem(ctx *gin.Context, status int, detail string) {
+	writeProblem(ctx, newProblem(ctx, status, detail))
+}
+
+func newProblem(ctx *gin.Context, status int, detail string) dto.ProblemResponse {
+	return dto.ProblemResponse{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: ctx.Request.URL.Path,
+	}
+}
+
+func writeProblem(ctx *gin.Context, problem dto.ProblemResponse) {
+	ctx.Header("Content-Type", problemContentType)
+	ctx.JSON(problem.Status, problem)
+}
+
+// fieldPath drops the request type from the validator's namespace, e.g.
+// "CreateAppointmentRequest.attendees[0].participant_id".
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func fieldMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be an email address"
+	case "min":
+		if fieldErr.Kind() == reflect.Slice {
+			return "must have at least " + fieldErr.Param() + " entries"
+		}
+		return "must be at least " + fieldErr.Param()
+	case "max":
+		return "must be at most " + fieldErr.Param()
+	default:
+		return "fails the " + fieldErr.Tag() + " rule"
+	}
+}
+
+func requestFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/domain/domainerrors"
+)
+
+// recordProblem runs respond against a test context and decodes the problem
+// it writes.
+func recordProblem(t *testing.T, body string, respond func(ctx *gin.Context)) (*httptest.ResponseRecorder, dto.ProblemResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/appointments", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	respond(ctx)
+
+	assert.Equal(t, problemContentType, recorder.Header().Get("Content-Type"))
+	var problem dto.ProblemResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	assert.Equal(t, recorder.Code, problem.Status)
+	assert.Equal(t, http.StatusText(recorder.Code), problem.Title)
+	assert.Equal(t, "/api/v1/appointments", problem.Instance)
+	return recorder, problem
+}
+
+func TestRespondError(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		recorder, problem := recordProblem(t, "", func(ctx *gin.Context) {
+			respondError(ctx, fmt.Errorf("%w: a1", usecases.ErrAppointmentNotFound))
+		})
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, "appointment not found: a1", problem.Detail)
+	})
+
+	t.Run("precondition failed", func(t *testing.T) {
+		recorder, _ := recordProblem(t, "", func(ctx *gin.Context) {
+			respondError(ctx, fmt.Errorf("%w: stored version is 4", usecases.ErrPreconditionFailed))
+		})
+		assert.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+	})
+
+	t.Run("scheduling conflict", func(t *testing.T) {
+		start := time.Date(2030, 3, 4, 10, 0, 0, 0, time.UTC)
+		conflictErr := &usecases.ConflictError{
+			Conflicts: []dto.ParticipantConflictResponse{{
+				ParticipantID: "alice",
+				StartTime:     start,
+				EndTime:       start.Add(time.Hour),
+				Severity:      "critical",
+				Conflicts:     []dto.ConflictResponse{{Type: "appointment", SourceID: "a2"}},
+			}},
+			Alternatives: []dto.AlternativeSlotResponse{{StartTime: start.Add(time.Hour), EndTime: start.Add(2 * time.Hour)}},
+		}
+
+		recorder, problem := recordProblem(t, "", func(ctx *gin.Context) {
+			respondError(ctx, conflictErr)
+		})
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+		require.Len(t, problem.Conflicts, 1)
+		assert.Equal(t, "alice", problem.Conflicts[0].ParticipantID)
+		assert.Equal(t, "a2", problem.Conflicts[0].Conflicts[0].SourceID)
+		require.Len(t, problem.Alternatives, 1)
+		assert.True(t, start.Add(time.Hour).Equal(problem.Alternatives[0].StartTime))
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		err := domainerrors.AtField("attendees[1].role", "", domainerrors.Invalid("unknown attendee role: host"))
+		recorder, problem := recordProblem(t, "", func(ctx *gin.Context) {
+			respondError(ctx, err)
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "attendees[1].role", problem.Errors[0].Field)
+	})
+
+	t.Run("invalid without a field", func(t *testing.T) {
+		recorder, problem := recordProblem(t, "", func(ctx *gin.Context) {
+			respondError(ctx, domainerrors.Invalid("end time must be after start time"))
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Empty(t, problem.Errors)
+		assert.Equal(t, "end time must be after start time", problem.Detail)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		var ctxErrors []*gin.Error
+		recorder, problem := recordProblem(t, "", func(ctx *gin.Context) {
+			respondError(ctx, errors.New("pq: connection refused to db.internal:5432"))
+			ctxErrors = ctx.Errors
+		})
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Empty(t, problem.Detail)
+		assert.NotContains(t, recorder.Body.String(), "db.internal")
+		require.Len(t, ctxErrors, 1, "the error is kept for the server log")
+	})
+}
+
+func TestRespondBindError(t *testing.T) {
+	t.Run("invalid values", func(t *testing.T) {
+		recorder, problem := recordProblem(t, `{"email": "not an email"}`, func(ctx *gin.Context) {
+			var request dto.CreateParticipantRequest
+			respondBindError(ctx, ctx.ShouldBindJSON(&request))
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Equal(t, []dto.FieldErrorResponse{
+			{Field: "name", Message: "is required"},
+			{Field: "email", Message: "must be an email address"},
+		}, problem.Errors)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		body := `{"title": "Review", "start_time": "2030-03-04T10:00:00Z", "end_time": "2030-03-04T11:00:00Z", "attendees": []}`
+		recorder, problem := recordProblem(t, body, func(ctx *gin.Context) {
+			var request dto.CreateAppointmentRequest
+			respondBindError(ctx, ctx.ShouldBindJSON(&request))
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Equal(t, []dto.FieldErrorResponse{{Field: "attendees", Message: "must have at least 1 entries"}}, problem.Errors)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		recorder, problem := recordProblem(t, `{"name": `, func(ctx *gin.Context) {
+			var request dto.CreateParticipantRequest
+			respondBindError(ctx, ctx.ShouldBindJSON(&request))
+		})
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Empty(t, problem.Errors)
+	})
+}
@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/visiab/appointment-calculator/internal/application/dto"
+	"github.com/visiab/appointment-calculator/internal/application/usecases"
+)
+
+type RecurrenceController struct {
+	previewUseCase *usecases.PreviewRecurrenceUseCase
+}
+
+func NewRecurrenceController(previewUseCase *usecases.PreviewRecurrenceUseCase) *RecurrenceController {
+	return &RecurrenceController{
+		previewUseCase: previewUseCase,
+	}
+}
+
+// PreviewRecurrence expands a rule and reports which occurrences conflict
+// with the attendees' schedules, without booking anything.
+func (c *RecurrenceController) PreviewRecurrence(ctx *gin.Context) {
+	var request dto.PreviewRecurrenceRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	response, err := c.previewUseCase.Execute(request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -6,111 +6,289 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/visiab/appointment-calculator/internal/application/dto"
 	"github.com/visiab/appointment-calculator/internal/application/usecases"
+	"github.com/visiab/appointment-calculator/internal/interfaces/http/presenters"
 )
 
 type ScheduleController struct {
 	findAvailableTimeSlotsUseCase *usecases.FindAvailableTimeSlotsUseCase
+	createScheduleUseCase         *usecases.CreateScheduleUseCase
+	getScheduleUseCase            *usecases.GetScheduleUseCase
+	updateWorkingHoursUseCase     *usecases.UpdateWorkingHoursUseCase
+	addBlockedTimeUseCase         *usecases.AddBlockedTimeUseCase
+	listBlockedTimesUseCase       *usecases.ListBlockedTimesUseCase
+	removeBlockedTimeUseCase      *usecases.RemoveBlockedTimeUseCase
+	updateHolidayCalendarsUseCase *usecases.UpdateScheduleHolidayCalendarsUseCase
+	updateConflictPolicyUseCase   *usecases.UpdateConflictPolicyUseCase
+	presenter                     *presenters.SchedulePresenter
 }
 
 func NewScheduleController(
 	findAvailableTimeSlotsUseCase *usecases.FindAvailableTimeSlotsUseCase,
+	createScheduleUseCase *usecases.CreateScheduleUseCase,
+	getScheduleUseCase *usecases.GetScheduleUseCase,
+	updateWorkingHoursUseCase *usecases.UpdateWorkingHoursUseCase,
+	addBlockedTimeUseCase *usecases.AddBlockedTimeUseCase,
+	listBlockedTimesUseCase *usecases.ListBlockedTimesUseCase,
+	removeBlockedTimeUseCase *usecases.RemoveBlockedTimeUseCase,
+	updateHolidayCalendarsUseCase *usecases.UpdateScheduleHolidayCalendarsUseCase,
+	updateConflictPolicyUseCase *usecases.UpdateConflictPolicyUseCase,
+	presenter *presenters.SchedulePresenter,
 ) *ScheduleController {
 	return &ScheduleController{
 		findAvailableTimeSlotsUseCase: findAvailableTimeSlotsUseCase,
+		createScheduleUseCase:         createScheduleUseCase,
+		getScheduleUseCase:            getScheduleUseCase,
+		updateWorkingHoursUseCase:     updateWorkingHoursUseCase,
+		addBlockedTimeUseCase:         addBlockedTimeUseCase,
+		listBlockedTimesUseCase:       listBlockedTimesUseCase,
+		removeBlockedTimeUseCase:      removeBlockedTimeUseCase,
+		updateHolidayCalendarsUseCase: updateHolidayCalendarsUseCase,
+		updateConflictPolicyUseCase:   updateConflictPolicyUseCase,
+		presenter:                     presenter,
 	}
 }
 
 func (c *ScheduleController) FindAvailableTimeSlots(ctx *gin.Context) {
 	var query dto.AvailabilityQuery
 	if err := ctx.ShouldBindJSON(&query); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		respondBindError(ctx, err)
 		return
 	}
 
 	result, err := c.findAvailableTimeSlotsUseCase.Execute(query)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to find available time slots",
-			"details": err.Error(),
-		})
+		respondError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, result)
 }
 
+func (c *ScheduleController) CreateSchedule(ctx *gin.Context) {
+	var request dto.CreateScheduleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	schedule, err := c.createScheduleUseCase.Execute(request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.presenter.PresentScheduleOverview(schedule))
+}
+
 func (c *ScheduleController) GetScheduleOverview(ctx *gin.Context) {
 	ownerID := ctx.Param("owner_id")
 	if ownerID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Owner ID is required",
-		})
+		respondProblem(ctx, http.StatusBadRequest, "Owner ID is required")
 		return
 	}
 
-	// This would require a GetScheduleOverviewUseCase
-	ctx.JSON(http.StatusNotImplemented, gin.H{
-		"error": "Get schedule overview endpoint not implemented yet",
-		"owner_id": ownerID,
-	})
+	var request dto.ScheduleWindowRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	schedule, err := c.getScheduleUseCase.Execute(ownerID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentScheduleOverview(schedule))
 }
 
 func (c *ScheduleController) GetScheduleDetail(ctx *gin.Context) {
 	ownerID := ctx.Param("owner_id")
 	if ownerID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Owner ID is required",
-		})
+		respondProblem(ctx, http.StatusBadRequest, "Owner ID is required")
 		return
 	}
 
 	// Parse query parameters for date range
-	startDate := ctx.Query("start_date")
-	endDate := ctx.Query("end_date")
-
-	// This would require a GetScheduleDetailUseCase
-	ctx.JSON(http.StatusNotImplemented, gin.H{
-		"error": "Get schedule detail endpoint not implemented yet",
-		"params": gin.H{
-			"owner_id":   ownerID,
-			"start_date": startDate,
-			"end_date":   endDate,
-		},
-	})
+	var request dto.ScheduleWindowRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	schedule, err := c.getScheduleUseCase.Execute(ownerID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentScheduleDetail(schedule))
 }
 
-func (c *ScheduleController) AddBlockedTime(ctx *gin.Context) {
+func (c *ScheduleController) UpdateWorkingHours(ctx *gin.Context) {
 	ownerID := ctx.Param("owner_id")
 	if ownerID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Owner ID is required",
-		})
+		respondProblem(ctx, http.StatusBadRequest, "Owner ID is required")
 		return
 	}
 
-	var request struct {
-		StartTime string `json:"start_time" binding:"required"`
-		EndTime   string `json:"end_time" binding:"required"`
-		Reason    string `json:"reason"`
+	var request dto.UpdateWorkingHoursRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
 	}
 
+	schedule, err := c.updateWorkingHoursUseCase.Execute(ownerID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentScheduleOverview(schedule))
+}
+
+func (c *ScheduleController) SetWorkingHoursOverride(ctx *gin.Context) {
+	ownerID := ctx.Param("owner_id")
+	date := ctx.Param("date")
+	if ownerID == "" || date == "" {
+		respondProblem(ctx, http.StatusBadRequest, "Owner ID and date are required")
+		return
+	}
+
+	var request dto.SetWorkingHoursOverrideRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		respondBindError(ctx, err)
+		return
+	}
+
+	schedule, err := c.updateWorkingHoursUseCase.SetOverride(ownerID, date, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentScheduleOverview(schedule))
+}
+
+func (c *ScheduleController) RemoveWorkingHoursOverride(ctx *gin.Context) {
+	ownerID := ctx.Param("owner_id")
+	date := ctx.Param("date")
+	if ownerID == "" || date == "" {
+		respondProblem(ctx, http.StatusBadRequest, "Owner ID and date are required")
+		return
+	}
+
+	if _, err := c.updateWorkingHoursUseCase.RemoveOverride(ownerID, date); err != nil {
+		respondError(ctx, err)
 		return
 	}
 
-	// This would require an AddBlockedTimeUseCase
-	ctx.JSON(http.StatusNotImplemented, gin.H{
-		"error": "Add blocked time endpoint not implemented yet",
-		"params": gin.H{
-			"owner_id": ownerID,
-			"request":  request,
-		},
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *ScheduleController) UpdateHolidayCalendars(ctx *gin.Context) {
+	ownerID := ctx.Param("owner_id")
+	if ownerID == "" {
+		respondProblem(ctx, http.StatusBadRequest, "Owner ID is required")
+		return
+	}
+
+	var request dto.UpdateHolidayCalendarsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	schedule, err := c.updateHolidayCalendarsUseCase.Execute(ownerID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentScheduleOverview(schedule))
+}
+
+func (c *ScheduleController) UpdateConflictPolicy(ctx *gin.Context) {
+	ownerID := ctx.Param("owner_id")
+	if ownerID == "" {
+		respondProblem(ctx, http.StatusBadRequest, "Owner ID is required")
+		return
+	}
+
+	var request dto.UpdateConflictPolicyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	schedule, err := c.updateConflictPolicyUseCase.Execute(ownerID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.presenter.PresentScheduleOverview(schedule))
+}
+
+func (c *ScheduleController) AddBlockedTime(ctx *gin.Context) {
+	ownerID := ctx.Param("owner_id")
+	if ownerID == "" {
+		respondProblem(ctx, http.StatusBadRequest, "Owner ID is required")
+		return
+	}
+
+	var request dto.AddBlockedTimeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	blocked, err := c.addBlockedTimeUseCase.Execute(ownerID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.presenter.PresentBlockedTime(*blocked))
+}
+
+func (c *ScheduleController) ListBlockedTimes(ctx *gin.Context) {
+	ownerID := ctx.Param("owner_id")
+	if ownerID == "" {
+		respondProblem(ctx, http.StatusBadRequest, "Owner ID is required")
+		return
+	}
+
+	var request dto.ScheduleWindowRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	blockedTimes, err := c.listBlockedTimesUseCase.Execute(ownerID, request)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.BlockedTimeListResponse{
+		OwnerID:      ownerID,
+		BlockedTimes: c.presenter.PresentBlockedTimes(blockedTimes),
 	})
 }
+
+func (c *ScheduleController) RemoveBlockedTime(ctx *gin.Context) {
+	ownerID := ctx.Param("owner_id")
+	blockedTimeID := ctx.Param("blocked_time_id")
+	if ownerID == "" || blockedTimeID == "" {
+		respondProblem(ctx, http.StatusBadRequest, "Owner ID and blocked time ID are required")
+		return
+	}
+
+	if err := c.removeBlockedTimeUseCase.Execute(ownerID, blockedTimeID); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -1,8 +1,11 @@
 package presenters
 
 import (
+	"sort"
+
 	"github.com/visiab/appointment-calculator/internal/application/dto"
 	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 )
 
 type AppointmentPresenter struct{}
@@ -12,18 +15,31 @@ func NewAppointmentPresenter() *AppointmentPresenter {
 }
 
 func (p *AppointmentPresenter) PresentAppointment(appointment *entities.Appointment) dto.AppointmentResponse {
-	return dto.AppointmentResponse{
-		ID:        appointment.ID(),
-		Title:     appointment.Title(),
-		StartTime: appointment.TimeRange().StartTime(),
-		EndTime:   appointment.TimeRange().EndTime(),
-		Duration:  appointment.TimeRange().Duration().String(),
-		Attendees: appointment.Attendees(),
-		Location:  appointment.Location(),
-		Status:    appointment.Status(),
-		CreatedAt: appointment.CreatedAt(),
-		UpdatedAt: appointment.UpdatedAt(),
+	response := dto.AppointmentResponse{
+		ID:          appointment.ID(),
+		Title:       appointment.Title(),
+		Description: appointment.Description(),
+		StartTime:   appointment.TimeRange().StartTime(),
+		EndTime:     appointment.TimeRange().EndTime(),
+		Duration:    appointment.TimeRange().Duration().String(),
+		Attendees:   presentAttendees(appointment.Attendees()),
+		OrganizerID: appointment.OrganizerID(),
+		Location:    appointment.Location(),
+		Tags:        appointment.Tags(),
+		Metadata:    presentMetadata(appointment.Metadata()),
+		Status:      appointment.Status(),
+		CreatedAt:   appointment.CreatedAt(),
+		UpdatedAt:   appointment.UpdatedAt(),
+		Version:     appointment.Version(),
+
+		StatusHistory: presentStatusHistory(appointment.StatusHistory()),
+	}
+
+	if seriesID := appointment.SeriesID(); seriesID != "" {
+		response.SeriesID = seriesID
+		response.RecurrenceID = entities.FormatRecurrenceID(appointment.RecurrenceID())
 	}
+	return response
 }
 
 func (p *AppointmentPresenter) PresentAppointmentList(appointments []*entities.Appointment, total, page, limit int) dto.AppointmentListResponse {
@@ -40,15 +56,104 @@ func (p *AppointmentPresenter) PresentAppointmentList(appointments []*entities.A
 	}
 }
 
+// PresentAppointmentSeries presents a series with its materialized
+// occurrences, in time order.
+func (p *AppointmentPresenter) PresentAppointmentSeries(series *entities.AppointmentSeries, occurrences []*entities.Appointment) dto.AppointmentSeriesResponse {
+	response := dto.AppointmentSeriesResponse{
+		ID:                 series.ID(),
+		Title:              series.Title(),
+		StartTime:          series.TimeRange().StartTime(),
+		EndTime:            series.TimeRange().EndTime(),
+		Attendees:          presentAttendees(series.Attendees()),
+		Location:           series.Location(),
+		RRule:              series.Rule(),
+		Timezone:           series.Timezone().String(),
+		DSTGapPolicy:       string(series.LocalTimePolicy().Gap),
+		DSTAmbiguityPolicy: string(series.LocalTimePolicy().Ambiguity),
+		ExDates:            series.Exceptions(),
+		Overrides:          make([]dto.OccurrenceOverrideResponse, 0, len(series.Overrides())),
+		MaterializedUntil:  series.Horizon(),
+		Status:             series.Status(),
+		CreatedAt:          series.CreatedAt(),
+		UpdatedAt:          series.UpdatedAt(),
+		Version:            series.Version(),
+		Occurrences:        make([]dto.AppointmentResponse, 0, len(occurrences)),
+	}
+
+	for _, override := range series.Overrides() {
+		overrideResponse := dto.OccurrenceOverrideResponse{
+			RecurrenceID: entities.FormatRecurrenceID(override.RecurrenceID()),
+			Title:        override.Title(),
+			Location:     override.Location(),
+		}
+		if timeRange := override.TimeRange(); timeRange != nil {
+			startTime, endTime := timeRange.StartTime(), timeRange.EndTime()
+			overrideResponse.StartTime = &startTime
+			overrideResponse.EndTime = &endTime
+		}
+		response.Overrides = append(response.Overrides, overrideResponse)
+	}
+
+	sorted := append([]*entities.Appointment(nil), occurrences...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].TimeRange().StartTime().Before(sorted[j].TimeRange().StartTime())
+	})
+	for _, occurrence := range sorted {
+		response.Occurrences = append(response.Occurrences, p.PresentAppointment(occurrence))
+	}
+
+	return response
+}
+
 func (p *AppointmentPresenter) PresentCreateResponse(appointment *entities.Appointment) dto.CreateAppointmentResponse {
 	return dto.CreateAppointmentResponse{
-		ID:        appointment.ID(),
-		Title:     appointment.Title(),
-		StartTime: appointment.TimeRange().StartTime(),
-		EndTime:   appointment.TimeRange().EndTime(),
-		Attendees: appointment.Attendees(),
-		Location:  appointment.Location(),
-		Status:    appointment.Status(),
-		CreatedAt: appointment.CreatedAt(),
+		ID:          appointment.ID(),
+		Title:       appointment.Title(),
+		Description: appointment.Description(),
+		StartTime:   appointment.TimeRange().StartTime(),
+		EndTime:     appointment.TimeRange().EndTime(),
+		Attendees:   presentAttendees(appointment.Attendees()),
+		OrganizerID: appointment.OrganizerID(),
+		Location:    appointment.Location(),
+		Tags:        appointment.Tags(),
+		Metadata:    presentMetadata(appointment.Metadata()),
+		Status:      appointment.Status(),
+		CreatedAt:   appointment.CreatedAt(),
+		Version:     appointment.Version(),
+	}
+}
+
+func presentMetadata(metadata valueobjects.Metadata) map[string]interface{} {
+	response := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		response[key] = value.Value()
+	}
+	return response
+}
+
+func presentAttendees(attendees []entities.Attendee) []dto.AttendeeResponse {
+	responses := make([]dto.AttendeeResponse, len(attendees))
+	for i, attendee := range attendees {
+		responses[i] = dto.AttendeeResponse{
+			ParticipantID: attendee.ParticipantID(),
+			Role:          attendee.Role(),
+			Response:      attendee.Response(),
+			RespondedAt:   attendee.RespondedAt(),
+		}
+	}
+	return responses
+}
+
+func presentStatusHistory(history []entities.StatusTransition) []dto.StatusTransitionResponse {
+	responses := make([]dto.StatusTransitionResponse, len(history))
+	for i, transition := range history {
+		responses[i] = dto.StatusTransitionResponse{
+			From:      transition.From(),
+			To:        transition.To(),
+			ChangedBy: transition.ChangedBy(),
+			Reason:    transition.Reason(),
+			ChangedAt: transition.ChangedAt(),
+		}
 	}
+	return responses
 }
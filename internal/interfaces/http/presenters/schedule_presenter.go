@@ -1,10 +1,12 @@
 package presenters
 
 import (
+	"strings"
 	"time"
 
 	"github.com/visiab/appointment-calculator/internal/application/dto"
 	"github.com/visiab/appointment-calculator/internal/domain/entities"
+	"github.com/visiab/appointment-calculator/internal/domain/valueobjects"
 )
 
 type SchedulePresenter struct {
@@ -29,10 +31,12 @@ func (p *SchedulePresenter) PresentScheduleOverview(schedule *entities.Schedule)
 	}
 
 	return dto.ScheduleOverview{
+		ID:                schedule.ID(),
 		OwnerID:           schedule.OwnerID(),
 		Timezone:          schedule.Timezone().String(),
-		WorkingHoursStart: p.formatTime(schedule.WorkingHours().StartTime()),
-		WorkingHoursEnd:   p.formatTime(schedule.WorkingHours().EndTime()),
+		WorkingHours:      p.PresentWorkingHours(schedule.WorkingHours()),
+		HolidayCalendars:  schedule.HolidayCalendars(),
+		ConflictPolicy:    p.PresentConflictPolicy(schedule.ConflictPolicy()),
 		AppointmentsToday: todayAppointments,
 		NextAppointment:   nextAppointmentResponse,
 		TotalAppointments: len(appointments),
@@ -48,31 +52,30 @@ func (p *SchedulePresenter) PresentScheduleDetail(schedule *entities.Schedule) d
 		appointmentResponses[i] = p.appointmentPresenter.PresentAppointment(appointment)
 	}
 
-	blockedTimes := make([]struct {
-		StartTime time.Time `json:"start_time"`
-		EndTime   time.Time `json:"end_time"`
-		Reason    string    `json:"reason"`
-	}, len(schedule.BlockedTimes()))
-
-	for i, blockedTime := range schedule.BlockedTimes() {
-		blockedTimes[i] = struct {
-			StartTime time.Time `json:"start_time"`
-			EndTime   time.Time `json:"end_time"`
-			Reason    string    `json:"reason"`
-		}{
-			StartTime: blockedTime.StartTime(),
-			EndTime:   blockedTime.EndTime(),
-			Reason:    "Blocked time", // This could be enhanced with actual reasons
-		}
-	}
-
 	return dto.ScheduleDetail{
 		ScheduleOverview: overview,
 		Appointments:     appointmentResponses,
-		BlockedTimes:     blockedTimes,
+		BlockedTimes:     p.PresentBlockedTimes(schedule.BlockedTimes()),
 	}
 }
 
+func (p *SchedulePresenter) PresentBlockedTime(blockedTime valueobjects.BlockedTime) dto.BlockedTimeResponse {
+	return dto.BlockedTimeResponse{
+		ID:        blockedTime.ID(),
+		StartTime: blockedTime.TimeRange().StartTime(),
+		EndTime:   blockedTime.TimeRange().EndTime(),
+		Reason:    blockedTime.Reason(),
+	}
+}
+
+func (p *SchedulePresenter) PresentBlockedTimes(blockedTimes []valueobjects.BlockedTime) []dto.BlockedTimeResponse {
+	responses := make([]dto.BlockedTimeResponse, len(blockedTimes))
+	for i, blockedTime := range blockedTimes {
+		responses[i] = p.PresentBlockedTime(blockedTime)
+	}
+	return responses
+}
+
 func (p *SchedulePresenter) countTodayAppointments(appointments []*entities.Appointment) int {
 	today := time.Now().Truncate(24 * time.Hour)
 	tomorrow := today.Add(24 * time.Hour)
@@ -109,6 +112,51 @@ func (p *SchedulePresenter) findNextAppointment(appointments []*entities.Appoint
 	return nextAppointment
 }
 
-func (p *SchedulePresenter) formatTime(t time.Time) string {
-	return t.Format("15:04")
+func (p *SchedulePresenter) PresentWorkingHours(workingHours valueobjects.WorkingHours) dto.WorkingHoursDTO {
+	response := dto.WorkingHoursDTO{
+		Weekly:    make(map[string][]dto.WorkingIntervalDTO),
+		Overrides: make(map[string][]dto.WorkingIntervalDTO),
+	}
+
+	for weekday, intervals := range workingHours.Weekly() {
+		response.Weekly[strings.ToLower(weekday.String())] = p.presentWorkingIntervals(intervals)
+	}
+
+	for date, intervals := range workingHours.Overrides() {
+		response.Overrides[date] = p.presentWorkingIntervals(intervals)
+	}
+
+	return response
+}
+
+func (p *SchedulePresenter) presentWorkingIntervals(intervals []valueobjects.WorkingInterval) []dto.WorkingIntervalDTO {
+	result := make([]dto.WorkingIntervalDTO, len(intervals))
+	for i, interval := range intervals {
+		result[i] = dto.WorkingIntervalDTO{
+			Start: interval.Start().String(),
+			End:   interval.End().String(),
+		}
+	}
+	return result
+}
+
+func (p *SchedulePresenter) PresentConflictPolicy(policy entities.ConflictPolicy) dto.ConflictPolicyDTO {
+	response := dto.ConflictPolicyDTO{
+		PreBufferMinutes:        int(policy.PreBuffer / time.Minute),
+		PostBufferMinutes:       int(policy.PostBuffer / time.Minute),
+		OverlapToleranceMinutes: int(policy.OverlapTolerance / time.Minute),
+		MaxConcurrent:           policy.MaxConcurrent,
+		BusyStatuses:            make([]string, 0, len(policy.BusyStatuses)),
+		BusyResponses:           make([]string, 0, len(policy.BusyResponses)),
+		MajorThreshold:          policy.MajorThreshold,
+		CriticalThreshold:       policy.CriticalThreshold,
+	}
+
+	for _, status := range policy.BusyStatuses {
+		response.BusyStatuses = append(response.BusyStatuses, string(status))
+	}
+	for _, status := range policy.BusyResponses {
+		response.BusyResponses = append(response.BusyResponses, string(status))
+	}
+	return response
 }